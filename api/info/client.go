@@ -5,7 +5,13 @@ package info
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
 	"net/netip"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -14,7 +20,11 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 )
 
-var _ Client = (*client)(nil)
+var (
+	_ Client = (*client)(nil)
+
+	ErrMissingDate = errors.New("response is missing the Date header")
+)
 
 // Client interface for an Info API Client.
 // See also AwaitBootstrapped.
@@ -31,6 +41,23 @@ type Client interface {
 	Upgrades(context.Context, ...rpc.Option) (*upgrade.Config, error)
 	Uptime(context.Context, ...rpc.Option) (*UptimeResponse, error)
 	GetVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, error)
+	// NetworkTime returns the current time according to the clock of the
+	// node, as reported by the Date header of its response.
+	NetworkTime(context.Context, ...rpc.Option) (time.Time, error)
+}
+
+// WithDialContext returns an option that issues requests over connections
+// created by [dial] rather than over TCP. This allows the client to reach a
+// node serving its API on a unix domain socket or over a custom transport.
+//
+// When [dial] ignores the address, the host of the URI provided to the client
+// is unused, but the URI must still be a valid http URI.
+func WithDialContext(dial func(ctx context.Context, network, addr string) (net.Conn, error)) rpc.Option {
+	return rpc.WithHTTPClient(&http.Client{
+		Transport: &http.Transport{
+			DialContext: dial,
+		},
+	})
 }
 
 // Client implementation for an Info API Client
@@ -40,8 +67,15 @@ type client struct {
 
 // NewClient returns a new Info API Client
 func NewClient(uri string) Client {
+	return NewClientWithOptions(uri)
+}
+
+// NewClientWithOptions returns a new Info API Client that applies [options] to
+// every request.
+func NewClientWithOptions(uri string, options ...rpc.Option) Client {
 	return &client{requester: rpc.NewEndpointRequester(
-		uri + "/ext/info",
+		uri+"/ext/info",
+		options...,
 	)}
 }
 
@@ -123,6 +157,29 @@ func (c *client) GetVMs(ctx context.Context, options ...rpc.Option) (map[ids.ID]
 	return res.VMs, err
 }
 
+func (c *client) NetworkTime(ctx context.Context, options ...rpc.Option) (time.Time, error) {
+	var (
+		res    = &GetNetworkIDReply{}
+		header http.Header
+	)
+	err := c.requester.SendRequest(
+		ctx,
+		"info.getNetworkID",
+		struct{}{},
+		res,
+		append(slices.Clip(options), rpc.WithResponseHeader(&header))...,
+	)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	date := header.Get("Date")
+	if date == "" {
+		return time.Time{}, ErrMissingDate
+	}
+	return http.ParseTime(date)
+}
+
 // AwaitBootstrapped polls the node every [freq] to check if [chainID] has
 // finished bootstrapping. Returns true once [chainID] reports that it has
 // finished bootstrapping.
@@ -144,3 +201,45 @@ func AwaitBootstrapped(ctx context.Context, c Client, chainID string, freq time.
 		}
 	}
 }
+
+// NodeInfo is the identity of a node as reported by GetNodeID.
+type NodeInfo struct {
+	NodeID  ids.NodeID
+	NodePOP *signer.ProofOfPossession
+}
+
+// GetNodeIDs concurrently calls GetNodeID on the Info API of each of the nodes
+// at [uris]. The results of the nodes that responded are keyed by their URI.
+// If any node failed to respond, the errors of each failed node are joined and
+// returned along with the results of the other nodes.
+func GetNodeIDs(ctx context.Context, uris []string, options ...rpc.Option) (map[string]*NodeInfo, error) {
+	var (
+		wg      sync.WaitGroup
+		lock    sync.Mutex
+		results = make(map[string]*NodeInfo, len(uris))
+		errs    []error
+	)
+	for _, uri := range uris {
+		wg.Add(1)
+		go func(uri string) {
+			defer wg.Done()
+
+			nodeID, nodePOP, err := NewClient(uri).GetNodeID(ctx, options...)
+
+			lock.Lock()
+			defer lock.Unlock()
+
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to fetch nodeID from %s: %w", uri, err))
+				return
+			}
+			results[uri] = &NodeInfo{
+				NodeID:  nodeID,
+				NodePOP: nodePOP,
+			}
+		}(uri)
+	}
+	wg.Wait()
+
+	return results, errors.Join(errs...)
+}
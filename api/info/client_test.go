@@ -5,12 +5,20 @@ package info
 
 import (
 	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 )
 
 type mockClient struct {
@@ -69,3 +77,112 @@ func TestClient(t *testing.T) {
 		require.True(bootstrapped)
 	}
 }
+
+func TestGetNodeIDs(t *testing.T) {
+	require := require.New(t)
+
+	// newNode returns the URI of a node that responds to info.getNodeID with
+	// [reply].
+	newNode := func(reply *GetNodeIDReply) string {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      0,
+				"result":  reply,
+			})
+		}))
+		t.Cleanup(server.Close)
+		return server.URL
+	}
+
+	expected := make(map[string]*NodeInfo)
+	uris := make([]string, 0, 4)
+	for i := 0; i < 3; i++ {
+		sk, err := bls.NewSigner()
+		require.NoError(err)
+
+		nodeInfo := &NodeInfo{
+			NodeID:  ids.GenerateTestNodeID(),
+			NodePOP: signer.NewProofOfPossession(sk),
+		}
+		uri := newNode(&GetNodeIDReply{
+			NodeID:  nodeInfo.NodeID,
+			NodePOP: nodeInfo.NodePOP,
+		})
+		expected[uri] = nodeInfo
+		uris = append(uris, uri)
+	}
+
+	failingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failingServer.Close()
+	uris = append(uris, failingServer.URL)
+
+	nodeInfos, err := GetNodeIDs(context.Background(), uris)
+	require.ErrorIs(err, rpc.ErrUnexpectedStatusCode)
+	require.NotContains(nodeInfos, failingServer.URL)
+	require.Len(nodeInfos, len(expected))
+	for uri, nodeInfo := range expected {
+		require.Contains(nodeInfos, uri)
+		require.Equal(nodeInfo.NodeID, nodeInfos[uri].NodeID)
+		require.Equal(nodeInfo.NodePOP.PublicKey, nodeInfos[uri].NodePOP.PublicKey)
+		require.Equal(nodeInfo.NodePOP.ProofOfPossession, nodeInfos[uri].NodePOP.ProofOfPossession)
+	}
+}
+
+func TestNetworkTime(t *testing.T) {
+	require := require.New(t)
+
+	// The clock of the node is intentionally far from the local clock.
+	nodeTime := time.Date(2020, time.January, 2, 3, 4, 5, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Date", nodeTime.Format(http.TimeFormat))
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      0,
+			"result":  &GetNetworkIDReply{},
+		})
+	}))
+	defer server.Close()
+
+	now, err := NewClient(server.URL).NetworkTime(context.Background())
+	require.NoError(err)
+	require.Equal(nodeTime, now)
+}
+
+func TestWithDialContext(t *testing.T) {
+	require := require.New(t)
+
+	socketPath := filepath.Join(t.TempDir(), "info.sock")
+	listener, err := net.Listen("unix", socketPath)
+	require.NoError(err)
+
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      0,
+				"result": &GetNetworkIDReply{
+					NetworkID: 12345,
+				},
+			})
+		}),
+		ReadHeaderTimeout: time.Second,
+	}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Close()
+
+	var dialer net.Dialer
+	c := NewClientWithOptions(
+		"http://localhost",
+		WithDialContext(func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", socketPath)
+		}),
+	)
+	networkID, err := c.GetNetworkID(context.Background())
+	require.NoError(err)
+	require.Equal(uint32(12345), networkID)
+}
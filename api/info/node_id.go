@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package info
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+)
+
+var (
+	ErrNodeIDMismatch           = errors.New("nodeID does not match staking certificate")
+	ErrMissingProofOfPossession = errors.New("missing proof of possession")
+	ErrPublicKeyMismatch        = errors.New("public key does not match proof of possession")
+)
+
+// VerifyNodeIDMatchesPoP verifies that [nodeID] is the ID of the node that is
+// identified by the DER encoded staking certificate [cert] and that [pop] is a
+// valid proof of possession.
+//
+// The BLS key of a node is not derived from its staking certificate, so this
+// can not prove that [pop] was generated by the node. It does guarantee that
+// the identity material was not corrupted or assembled from an unrelated
+// certificate before being registered.
+func VerifyNodeIDMatchesPoP(nodeID ids.NodeID, pop *signer.ProofOfPossession, cert []byte) error {
+	stakingCert, err := staking.ParseCertificate(cert)
+	if err != nil {
+		return fmt.Errorf("failed to parse staking certificate: %w", err)
+	}
+
+	certNodeID := ids.NodeIDFromCert(stakingCert)
+	if certNodeID != nodeID {
+		return fmt.Errorf("%w: expected %s but certificate is for %s",
+			ErrNodeIDMismatch,
+			nodeID,
+			certNodeID,
+		)
+	}
+
+	if pop == nil {
+		return ErrMissingProofOfPossession
+	}
+	if err := pop.Verify(); err != nil {
+		return fmt.Errorf("invalid proof of possession for %s: %w", nodeID, err)
+	}
+	return nil
+}
+
+// VerifyNodePoP verifies that [pop] is a valid proof of possession of
+// [expectedPublicKey], the compressed BLS public key that is being registered.
+//
+// Proofs of possession are not bound to a network, so a valid [pop] does not
+// imply that the node is configured for the expected network. That should be
+// checked separately, for example with the network ID reported by the node.
+func VerifyNodePoP(pop *signer.ProofOfPossession, expectedPublicKey [bls.PublicKeyLen]byte) error {
+	if pop == nil {
+		return ErrMissingProofOfPossession
+	}
+	if pop.PublicKey != expectedPublicKey {
+		return fmt.Errorf("%w: expected %x but proof of possession is for %x",
+			ErrPublicKeyMismatch,
+			expectedPublicKey,
+			pop.PublicKey,
+		)
+	}
+	if err := pop.Verify(); err != nil {
+		return fmt.Errorf("invalid proof of possession for %x: %w", expectedPublicKey, err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package info
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+)
+
+func TestVerifyNodeIDMatchesPoP(t *testing.T) {
+	newCert := func(t *testing.T) ([]byte, ids.NodeID) {
+		tlsCert, err := staking.NewTLSCert()
+		require.NoError(t, err)
+
+		cert, err := staking.ParseCertificate(tlsCert.Leaf.Raw)
+		require.NoError(t, err)
+		return tlsCert.Leaf.Raw, ids.NodeIDFromCert(cert)
+	}
+
+	cert, nodeID := newCert(t)
+	_, otherNodeID := newCert(t)
+
+	sk, err := bls.NewSigner()
+	require.NoError(t, err)
+	pop := signer.NewProofOfPossession(sk)
+
+	otherSK, err := bls.NewSigner()
+	require.NoError(t, err)
+	invalidPoP := signer.NewProofOfPossession(sk)
+	invalidPoP.ProofOfPossession = signer.NewProofOfPossession(otherSK).ProofOfPossession
+
+	tests := []struct {
+		name        string
+		nodeID      ids.NodeID
+		pop         *signer.ProofOfPossession
+		cert        []byte
+		expectedErr error
+	}{
+		{
+			name:   "matching",
+			nodeID: nodeID,
+			pop:    pop,
+			cert:   cert,
+		},
+		{
+			name:        "nodeID from a different certificate",
+			nodeID:      otherNodeID,
+			pop:         pop,
+			cert:        cert,
+			expectedErr: ErrNodeIDMismatch,
+		},
+		{
+			name:        "proof of possession of a different key",
+			nodeID:      nodeID,
+			pop:         invalidPoP,
+			cert:        cert,
+			expectedErr: signer.ErrInvalidProofOfPossession,
+		},
+		{
+			name:        "missing proof of possession",
+			nodeID:      nodeID,
+			cert:        cert,
+			expectedErr: ErrMissingProofOfPossession,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifyNodeIDMatchesPoP(test.nodeID, test.pop, test.cert)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestVerifyNodePoP(t *testing.T) {
+	sk, err := bls.NewSigner()
+	require.NoError(t, err)
+	pop := signer.NewProofOfPossession(sk)
+
+	otherSK, err := bls.NewSigner()
+	require.NoError(t, err)
+	otherPoP := signer.NewProofOfPossession(otherSK)
+
+	invalidPoP := signer.NewProofOfPossession(sk)
+	invalidPoP.ProofOfPossession = otherPoP.ProofOfPossession
+
+	tests := []struct {
+		name              string
+		pop               *signer.ProofOfPossession
+		expectedPublicKey [bls.PublicKeyLen]byte
+		expectedErr       error
+	}{
+		{
+			name:              "valid",
+			pop:               pop,
+			expectedPublicKey: pop.PublicKey,
+		},
+		{
+			name:              "proof of possession of a different key",
+			pop:               otherPoP,
+			expectedPublicKey: pop.PublicKey,
+			expectedErr:       ErrPublicKeyMismatch,
+		},
+		{
+			name:              "invalid proof of possession",
+			pop:               invalidPoP,
+			expectedPublicKey: pop.PublicKey,
+			expectedErr:       signer.ErrInvalidProofOfPossession,
+		},
+		{
+			name:              "missing proof of possession",
+			expectedPublicKey: pop.PublicKey,
+			expectedErr:       ErrMissingProofOfPossession,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifyNodePoP(test.pop, test.expectedPublicKey)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/ava-labs/avalanchego/upgrade"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/set"
@@ -31,6 +32,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/avalanchego/vms/propertyfx"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/types"
 )
 
 var errNoChainProvided = errors.New("argument 'chain' not given")
@@ -212,6 +214,19 @@ type Peer struct {
 	peer.Info
 
 	Benched []string `json:"benched"`
+	// PublicKey is the compressed BLS public key of the peer. It is only
+	// populated if the peer is a primary network validator with a registered
+	// BLS public key.
+	PublicKey types.JSONByteSlice `json:"publicKey,omitempty"`
+}
+
+// BLSPublicKey returns the parsed BLS public key of the peer, or nil if the
+// peer did not report a BLS public key.
+func (p *Peer) BLSPublicKey() (*bls.PublicKey, error) {
+	if len(p.PublicKey) == 0 {
+		return nil, nil
+	}
+	return bls.PublicKeyFromCompressedBytes(p.PublicKey)
 }
 
 // PeersReply are the results from calling Peers
@@ -245,6 +260,9 @@ func (i *Info) Peers(_ *http.Request, args *PeersArgs, reply *PeersReply) error
 			Info:    peer,
 			Benched: benchedAliases,
 		}
+		if vdr, ok := i.validators.GetValidator(constants.PrimaryNetworkID, peer.ID); ok && vdr.PublicKey != nil {
+			peerInfo[index].PublicKey = bls.PublicKeyToCompressedBytes(vdr.PublicKey)
+		}
 	}
 
 	reply.Peers = peerInfo
@@ -11,6 +11,12 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/vmsmock"
 )
@@ -94,3 +100,52 @@ func TestGetVMsGetAliasesFails(t *testing.T) {
 	err := resources.info.GetVMs(nil, nil, &reply)
 	require.ErrorIs(t, err, errTest)
 }
+
+type peerInfoNetwork struct {
+	network.Network
+
+	peers []peer.Info
+}
+
+func (n *peerInfoNetwork) PeerInfo([]ids.NodeID) []peer.Info {
+	return n.peers
+}
+
+func TestPeersPublicKey(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSigner()
+	require.NoError(err)
+	pk := sk.PublicKey()
+
+	var (
+		validatorNodeID    = ids.GenerateTestNodeID()
+		nonValidatorNodeID = ids.GenerateTestNodeID()
+		vdrs               = validators.NewManager()
+	)
+	require.NoError(vdrs.AddStaker(constants.PrimaryNetworkID, validatorNodeID, pk, ids.Empty, 1))
+
+	info := &Info{
+		log:        logging.NoLog{},
+		validators: vdrs,
+		networking: &peerInfoNetwork{
+			peers: []peer.Info{
+				{ID: validatorNodeID},
+				{ID: nonValidatorNodeID},
+			},
+		},
+		benchlist: benchlist.NewNoBenchlist(),
+	}
+
+	reply := PeersReply{}
+	require.NoError(info.Peers(nil, &PeersArgs{}, &reply))
+	require.Len(reply.Peers, 2)
+
+	peerPK, err := reply.Peers[0].BLSPublicKey()
+	require.NoError(err)
+	require.Equal(pk, peerPK)
+
+	peerPK, err = reply.Peers[1].BLSPublicKey()
+	require.NoError(err)
+	require.Nil(peerPK)
+}
@@ -11,6 +11,7 @@ import (
 
 var (
 	ErrUnsupportedType           = errors.New("unsupported type")
+	ErrUnknownTypeID             = errors.New("unknown type ID")
 	ErrMaxSliceLenExceeded       = errors.New("max slice length exceeded")
 	ErrDoesNotImplementInterface = errors.New("does not implement interface")
 	ErrUnexportedField           = errors.New("unexported field")
@@ -104,7 +104,7 @@ func (c *linearCodec) UnpackPrefix(p *wrappers.Packer, valueType reflect.Type) (
 	// Get a type that implements the interface
 	implementingType, ok := c.registeredTypes.GetValue(typeID)
 	if !ok {
-		return reflect.Value{}, fmt.Errorf("couldn't unmarshal interface: unknown type ID %d", typeID)
+		return reflect.Value{}, fmt.Errorf("couldn't unmarshal interface: %w %d", codec.ErrUnknownTypeID, typeID)
 	}
 	// Ensure type actually does implement the interface
 	if !implementingType.Implements(valueType) {
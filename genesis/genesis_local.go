@@ -4,6 +4,8 @@
 package genesis
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	_ "embed"
@@ -32,9 +34,15 @@ const (
 )
 
 var (
+	ErrUnknownLocalKey = errors.New("unknown local key")
+
 	VMRQKey *secp256k1.PrivateKey
 	EWOQKey *secp256k1.PrivateKey
 
+	// localKeys are the keys with funds allocated in the local genesis.
+	// [EWOQKey] is first because it holds the most unlocked funds.
+	localKeys []*secp256k1.PrivateKey
+
 	//go:embed genesis_local.json
 	localGenesisConfigJSON []byte
 
@@ -113,4 +121,22 @@ func init() {
 	if errs.Err != nil {
 		panic(errs.Err)
 	}
+
+	localKeys = []*secp256k1.PrivateKey{
+		EWOQKey,
+		VMRQKey,
+	}
+}
+
+// LocalKey returns the pre-funded local network key at [index]. Index 0 is
+// always [EWOQKey].
+func LocalKey(index int) (*secp256k1.PrivateKey, error) {
+	if index < 0 || index >= len(localKeys) {
+		return nil, fmt.Errorf("%w: index %d is not in [0, %d)",
+			ErrUnknownLocalKey,
+			index,
+			len(localKeys),
+		)
+	}
+	return localKeys[index], nil
 }
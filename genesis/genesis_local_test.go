@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+)
+
+func TestLocalKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		index       int
+		expectedKey *secp256k1.PrivateKey
+		expectedErr error
+	}{
+		{
+			name:        "ewoq",
+			index:       0,
+			expectedKey: EWOQKey,
+		},
+		{
+			name:        "vmrq",
+			index:       1,
+			expectedKey: VMRQKey,
+		},
+		{
+			name:        "negative",
+			index:       -1,
+			expectedErr: ErrUnknownLocalKey,
+		},
+		{
+			name:        "out of range",
+			index:       2,
+			expectedErr: ErrUnknownLocalKey,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			key, err := LocalKey(test.index)
+			require.ErrorIs(t, err, test.expectedErr)
+			require.Equal(t, test.expectedKey, key)
+		})
+	}
+}
@@ -379,8 +379,7 @@ var _ = e2e.DescribePChain("[L1]", func() {
 			subnetRegisterNode.NodeID,
 			registerNodePoP.PublicKey,
 			expiry,
-			warpmessage.PChainOwner{},
-			warpmessage.PChainOwner{},
+			warpmessage.L1ValidatorOwners{},
 			registerWeight,
 		)
 		require.NoError(err)
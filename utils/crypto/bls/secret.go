@@ -4,8 +4,13 @@
 package bls
 
 import (
+	"bytes"
 	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"io"
+	"os"
 	"runtime"
 
 	blst "github.com/supranational/blst/bindings/go"
@@ -14,7 +19,10 @@ import (
 const SecretKeyLen = blst.BLST_SCALAR_BYTES
 
 var (
+	ErrSignerCleared = errors.New("signer was cleared")
+
 	errFailedSecretKeyDeserialize = errors.New("couldn't deserialize secret key")
+	errMalformedSecretKeyFile     = errors.New("malformed secret key file")
 
 	// The ciphersuite is more commonly known as G2ProofOfPossession.
 	// There are two digests to ensure that message space for normal
@@ -26,32 +34,90 @@ var (
 
 type SecretKey = blst.SecretKey
 
+// Signer signs messages with a BLS secret key.
+//
+// Sign and SignProofOfPossession return nil if the signer is no longer able to
+// sign, such as a [LocalSigner] that was cleared. Use [Sign] and
+// [SignProofOfPossession] to receive an error instead.
 type Signer interface {
 	PublicKey() *PublicKey
 	Sign(msg []byte) *Signature
 	SignProofOfPossession(msg []byte) *Signature
 }
 
+// Sign returns the signature of [msg] by [signer].
+//
+// Returns [ErrSignerCleared] if [signer] is no longer able to sign.
+func Sign(signer Signer, msg []byte) (*Signature, error) {
+	sig := signer.Sign(msg)
+	if sig == nil {
+		return nil, ErrSignerCleared
+	}
+	return sig, nil
+}
+
+// SignProofOfPossession returns the proof of possession signature of [msg] by
+// [signer].
+//
+// Returns [ErrSignerCleared] if [signer] is no longer able to sign.
+func SignProofOfPossession(signer Signer, msg []byte) (*Signature, error) {
+	sig := signer.SignProofOfPossession(msg)
+	if sig == nil {
+		return nil, ErrSignerCleared
+	}
+	return sig, nil
+}
+
+// VerifyKeyPair returns true if [pk] is the public key of [signer].
+func VerifyKeyPair(signer Signer, pk *PublicKey) bool {
+	return pk != nil && signer.PublicKey().Equals(pk)
+}
+
 type LocalSigner struct {
 	sk *SecretKey
+	// pk is retained after sk is cleared, as the public key is not secret.
+	pk *PublicKey
+}
+
+func newLocalSigner(sk *SecretKey) *LocalSigner {
+	return &LocalSigner{
+		sk: sk,
+		pk: new(PublicKey).From(sk),
+	}
 }
 
 // NewSecretKey generates a new secret key from the local source of
 // cryptographically secure randomness.
 func NewSigner() (*LocalSigner, error) {
+	return NewSignerFromReader(rand.Reader)
+}
+
+// NewSignerFromReader generates a new secret key from the entropy provided by
+// [r].
+//
+// WARNING: The secret key is only as unpredictable as [r]. Readers that are not
+// cryptographically secure, such as a seeded math/rand source or a fixed
+// buffer, must only be used to produce reproducible keys in tests and must
+// never be used in production. Use [NewSigner] otherwise.
+func NewSignerFromReader(r io.Reader) (*LocalSigner, error) {
 	var ikm [32]byte
-	_, err := rand.Read(ikm[:])
+	_, err := io.ReadFull(r, ikm[:])
 	if err != nil {
 		return nil, err
 	}
 	sk := blst.KeyGen(ikm[:])
 	ikm = [32]byte{} // zero out the ikm
 
-	return &LocalSigner{sk: sk}, nil
+	return newLocalSigner(sk), nil
 }
 
 // ToBytes returns the big-endian format of the secret key.
+//
+// If the secret key has been cleared, nil is returned.
 func (s *LocalSigner) ToBytes() []byte {
+	if s.sk == nil {
+		return nil
+	}
 	return s.sk.Serialize()
 }
 
@@ -65,21 +131,82 @@ func SecretKeyFromBytes(skBytes []byte) (*LocalSigner, error) {
 	runtime.SetFinalizer(sk, func(sk *SecretKey) {
 		sk.Zeroize()
 	})
-	return &LocalSigner{sk: sk}, nil
+	return newLocalSigner(sk), nil
+}
+
+// SecretKeyFromFile reads the secret key stored at [path]. The file may
+// contain either the raw big-endian format of the secret key or its hex
+// encoding. Surrounding whitespace is ignored for hex encoded keys.
+func SecretKeyFromFile(path string) (*LocalSigner, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(fileBytes) == SecretKeyLen {
+		return SecretKeyFromBytes(fileBytes)
+	}
+
+	hexBytes := bytes.TrimSpace(fileBytes)
+	if len(hexBytes) != hex.EncodedLen(SecretKeyLen) {
+		return nil, fmt.Errorf("%w: expected %d raw bytes or %d hex characters but got %d bytes",
+			errMalformedSecretKeyFile,
+			SecretKeyLen,
+			hex.EncodedLen(SecretKeyLen),
+			len(fileBytes),
+		)
+	}
+	skBytes := make([]byte, SecretKeyLen)
+	if _, err := hex.Decode(skBytes, hexBytes); err != nil {
+		return nil, fmt.Errorf("%w: %w", errMalformedSecretKeyFile, err)
+	}
+	return SecretKeyFromBytes(skBytes)
 }
 
 // PublicKey returns the public key that corresponds to this secret
 // key.
+//
+// The public key remains available after the secret key has been cleared.
 func (s *LocalSigner) PublicKey() *PublicKey {
-	return new(PublicKey).From(s.sk)
+	return s.pk
 }
 
 // Sign [msg] to authorize this message
+//
+// If the secret key has been cleared, nil is returned.
 func (s *LocalSigner) Sign(msg []byte) *Signature {
+	if s.sk == nil {
+		return nil
+	}
 	return new(Signature).Sign(s.sk, msg, ciphersuiteSignature)
 }
 
 // Sign [msg] to prove the ownership
+//
+// If the secret key has been cleared, nil is returned.
 func (s *LocalSigner) SignProofOfPossession(msg []byte) *Signature {
+	if s.sk == nil {
+		return nil
+	}
 	return new(Signature).Sign(s.sk, msg, ciphersuiteProofOfPossession)
 }
+
+// Clear zeroes the secret key and makes the signer unusable. After Clear is
+// called, all signing requests return nil, and [Sign] and
+// [SignProofOfPossession] return [ErrSignerCleared].
+//
+// Clearing is best-effort: the Go runtime may have copied the secret key
+// elsewhere in memory, and those copies are not zeroed.
+//
+// Clear must not be called concurrently with other methods on the signer.
+func (s *LocalSigner) Clear() {
+	if s.sk == nil {
+		return
+	}
+	s.sk.Zeroize()
+	s.sk = nil
+}
+
+// Cleared returns true if Clear has been called.
+func (s *LocalSigner) Cleared() bool {
+	return s.sk == nil
+}
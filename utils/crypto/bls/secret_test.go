@@ -4,6 +4,11 @@
 package bls
 
 import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -11,6 +16,26 @@ import (
 	"github.com/ava-labs/avalanchego/utils"
 )
 
+func TestNewSignerFromReader(t *testing.T) {
+	require := require.New(t)
+
+	seed := bytes.Repeat([]byte{0x01}, 32)
+
+	sk0, err := NewSignerFromReader(bytes.NewReader(seed))
+	require.NoError(err)
+	sk1, err := NewSignerFromReader(bytes.NewReader(seed))
+	require.NoError(err)
+	require.Equal(sk0.ToBytes(), sk1.ToBytes())
+
+	otherSeed := bytes.Repeat([]byte{0x02}, 32)
+	sk2, err := NewSignerFromReader(bytes.NewReader(otherSeed))
+	require.NoError(err)
+	require.NotEqual(sk0.ToBytes(), sk2.ToBytes())
+
+	_, err = NewSignerFromReader(bytes.NewReader(seed[:31]))
+	require.ErrorIs(err, io.ErrUnexpectedEOF)
+}
+
 func TestSecretKeyFromBytesZero(t *testing.T) {
 	require := require.New(t)
 
@@ -47,3 +72,101 @@ func TestSecretKeyBytes(t *testing.T) {
 	require.Equal(skBytes, sk2Bytes)
 	require.Equal(sig, sig2)
 }
+
+func TestSecretKeyFromFile(t *testing.T) {
+	sk, err := NewSigner()
+	require.NoError(t, err)
+	skBytes := sk.ToBytes()
+
+	tests := []struct {
+		name        string
+		contents    []byte
+		expectedErr error
+	}{
+		{
+			name:     "raw",
+			contents: skBytes,
+		},
+		{
+			name:     "hex",
+			contents: []byte(hex.EncodeToString(skBytes)),
+		},
+		{
+			name:     "hex with whitespace",
+			contents: []byte(" " + hex.EncodeToString(skBytes) + "\n"),
+		},
+		{
+			name:        "truncated raw",
+			contents:    skBytes[:SecretKeyLen-1],
+			expectedErr: errMalformedSecretKeyFile,
+		},
+		{
+			name:        "truncated hex",
+			contents:    []byte(hex.EncodeToString(skBytes)[:2*SecretKeyLen-2]),
+			expectedErr: errMalformedSecretKeyFile,
+		},
+		{
+			name:        "invalid hex",
+			contents:    []byte(hex.EncodeToString(skBytes)[:2*SecretKeyLen-1] + "z"),
+			expectedErr: errMalformedSecretKeyFile,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			path := filepath.Join(t.TempDir(), "signer.key")
+			require.NoError(os.WriteFile(path, test.contents, 0o600))
+
+			sk2, err := SecretKeyFromFile(path)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.Equal(skBytes, sk2.ToBytes())
+		})
+	}
+}
+
+func TestSecretKeyClear(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := NewSigner()
+	require.NoError(err)
+
+	msg := utils.RandomBytes(1234)
+	sig, err := Sign(sk, msg)
+	require.NoError(err)
+	require.True(Verify(sk.PublicKey(), sig, msg))
+	require.False(sk.Cleared())
+
+	pk := sk.PublicKey()
+	sk.Clear()
+	require.True(sk.Cleared())
+	require.Equal(pk, sk.PublicKey())
+	require.Nil(sk.ToBytes())
+	require.Nil(sk.Sign(msg))
+	require.Nil(sk.SignProofOfPossession(msg))
+
+	_, err = Sign(sk, msg)
+	require.ErrorIs(err, ErrSignerCleared)
+	_, err = SignProofOfPossession(sk, msg)
+	require.ErrorIs(err, ErrSignerCleared)
+
+	// Clearing an already cleared key is a noop.
+	sk.Clear()
+	require.True(sk.Cleared())
+}
+
+func TestVerifyKeyPair(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := NewSigner()
+	require.NoError(err)
+	otherSK, err := NewSigner()
+	require.NoError(err)
+
+	require.True(VerifyKeyPair(sk, sk.PublicKey()))
+	require.False(VerifyKeyPair(sk, otherSK.PublicKey()))
+	require.False(VerifyKeyPair(sk, nil))
+}
@@ -0,0 +1,43 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keychain
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var _ Keychain = (*combinedKeychain)(nil)
+
+// combinedKeychain returns the signer of the first keychain that is able to
+// sign for an address.
+type combinedKeychain struct {
+	keychains []Keychain
+}
+
+// Combine returns a keychain that is able to sign for the addresses of all of
+// [keychains]. If multiple keychains are able to sign for an address, the
+// signer of the first one is used.
+func Combine(keychains ...Keychain) Keychain {
+	return &combinedKeychain{
+		keychains: keychains,
+	}
+}
+
+func (c *combinedKeychain) Get(addr ids.ShortID) (Signer, bool) {
+	for _, kc := range c.keychains {
+		if signer, ok := kc.Get(addr); ok {
+			return signer, true
+		}
+	}
+	return nil, false
+}
+
+func (c *combinedKeychain) Addresses() set.Set[ids.ShortID] {
+	var addrs set.Set[ids.ShortID]
+	for _, kc := range c.keychains {
+		addrs.Union(kc.Addresses())
+	}
+	return addrs
+}
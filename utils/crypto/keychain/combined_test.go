@@ -0,0 +1,88 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keychain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+// testKeychain is a Keychain that holds a signer for each of its addresses.
+type testKeychain map[ids.ShortID]Signer
+
+func (k testKeychain) Get(addr ids.ShortID) (Signer, bool) {
+	signer, ok := k[addr]
+	return signer, ok
+}
+
+func (k testKeychain) Addresses() set.Set[ids.ShortID] {
+	addrs := set.NewSet[ids.ShortID](len(k))
+	for addr := range k {
+		addrs.Add(addr)
+	}
+	return addrs
+}
+
+func TestCombine(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		sharedAddr  = ids.GenerateTestShortID()
+		firstAddr   = ids.GenerateTestShortID()
+		secondAddr  = ids.GenerateTestShortID()
+		unknownAddr = ids.GenerateTestShortID()
+
+		firstShared  = &ledgerSigner{addr: sharedAddr, idx: 0}
+		firstSigner  = &ledgerSigner{addr: firstAddr, idx: 1}
+		secondShared = &ledgerSigner{addr: sharedAddr, idx: 2}
+		secondSigner = &ledgerSigner{addr: secondAddr, idx: 3}
+
+		kc = Combine(
+			testKeychain{
+				sharedAddr: firstShared,
+				firstAddr:  firstSigner,
+			},
+			testKeychain{
+				sharedAddr: secondShared,
+				secondAddr: secondSigner,
+			},
+		)
+	)
+
+	require.Equal(set.Of(sharedAddr, firstAddr, secondAddr), kc.Addresses())
+
+	tests := []struct {
+		addr           ids.ShortID
+		expectedSigner Signer
+		expectedOK     bool
+	}{
+		{
+			addr:           sharedAddr,
+			expectedSigner: firstShared,
+			expectedOK:     true,
+		},
+		{
+			addr:           firstAddr,
+			expectedSigner: firstSigner,
+			expectedOK:     true,
+		},
+		{
+			addr:           secondAddr,
+			expectedSigner: secondSigner,
+			expectedOK:     true,
+		},
+		{
+			addr: unknownAddr,
+		},
+	}
+	for _, test := range tests {
+		signer, ok := kc.Get(test.addr)
+		require.Equal(test.expectedOK, ok)
+		require.Equal(test.expectedSigner, signer)
+	}
+}
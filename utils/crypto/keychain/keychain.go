@@ -29,6 +29,15 @@ type Signer interface {
 	Address() ids.ShortID
 }
 
+// ExternalSigner signs hashes on behalf of a set of addresses whose private
+// keys are held outside of the process, such as in an HSM or a remote KMS.
+type ExternalSigner interface {
+	// Sign returns the signature of [hash] by the key associated with [addr].
+	Sign(addr ids.ShortID, hash []byte) ([]byte, error)
+	// Addresses returns the addresses that this signer is able to sign for.
+	Addresses() []ids.ShortID
+}
+
 // Keychain maintains a set of addresses together with their corresponding
 // signers
 type Keychain interface {
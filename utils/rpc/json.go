@@ -6,13 +6,25 @@ package rpc
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 
 	rpc "github.com/gorilla/rpc/v2/json2"
 )
 
+var (
+	// ErrMethodNotFound is returned when the server does not serve the
+	// requested method, such as when the server runs a release that predates
+	// the method.
+	ErrMethodNotFound = errors.New("method not found")
+	// ErrUnexpectedStatusCode is returned when the server responds with a non
+	// successful status code.
+	ErrUnexpectedStatusCode = errors.New("received unexpected status code")
+)
+
 func SendJSONRequest(
 	ctx context.Context,
 	uri *url.URL,
@@ -42,22 +54,38 @@ func SendJSONRequest(
 	request.Header = ops.headers
 	request.Header.Set("Content-Type", "application/json")
 
-	resp, err := http.DefaultClient.Do(request)
+	resp, err := ops.HTTPClient().Do(request)
 	if err != nil {
 		return fmt.Errorf("failed to issue request: %w", err)
 	}
+	if respHeader := ops.ResponseHeader(); respHeader != nil {
+		*respHeader = resp.Header
+	}
 
 	// Return an error for any non successful status code
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
 		// Drop any error during close to report the original error
 		_ = resp.Body.Close()
-		return fmt.Errorf("received status code: %d", resp.StatusCode)
+		return fmt.Errorf("%w: %d", ErrUnexpectedStatusCode, resp.StatusCode)
 	}
 
 	if err := rpc.DecodeClientResponse(resp.Body, reply); err != nil {
 		// Drop any error during close to report the original error
 		_ = resp.Body.Close()
+
+		var jsonErr *rpc.Error
+		if errors.As(err, &jsonErr) && isMethodNotFound(jsonErr) {
+			return fmt.Errorf("%w: %w", ErrMethodNotFound, err)
+		}
 		return fmt.Errorf("failed to decode client response: %w", err)
 	}
 	return resp.Body.Close()
 }
+
+// isMethodNotFound returns true if [err] was reported because the server does
+// not serve the requested service or method.
+func isMethodNotFound(err *rpc.Error) bool {
+	return err.Code == rpc.E_NO_METHOD ||
+		strings.HasPrefix(err.Message, "rpc: can't find service") ||
+		strings.HasPrefix(err.Message, "rpc: can't find method")
+}
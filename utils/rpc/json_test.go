@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gorilla/rpc/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/json"
+)
+
+type testService struct{}
+
+// EchoReply is exported because the server only serves methods whose
+// arguments and replies are exported.
+type EchoReply testReply
+
+func (*testService) Echo(_ *http.Request, args *EchoReply, reply *EchoReply) error {
+	reply.Value = args.Value
+	return nil
+}
+
+func TestSendJSONRequestMethodNotFound(t *testing.T) {
+	server := rpc.NewServer()
+	server.RegisterCodec(json.NewCodec(), "application/json")
+	require.NoError(t, server.RegisterService(&testService{}, "test"))
+
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	tests := []struct {
+		name        string
+		method      string
+		expectedErr error
+	}{
+		{
+			name:   "known method",
+			method: "test.echo",
+		},
+		{
+			name:        "unknown method",
+			method:      "test.unknown",
+			expectedErr: ErrMethodNotFound,
+		},
+		{
+			name:        "unknown service",
+			method:      "unknown.echo",
+			expectedErr: ErrMethodNotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			uri, err := url.Parse(httpServer.URL)
+			require.NoError(err)
+
+			var reply EchoReply
+			err = SendJSONRequest(
+				context.Background(),
+				uri,
+				test.method,
+				&EchoReply{Value: "value"},
+				&reply,
+			)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr == nil {
+				require.Equal("value", reply.Value)
+			}
+		})
+	}
+}
@@ -13,6 +13,9 @@ type Option func(*Options)
 type Options struct {
 	headers     http.Header
 	queryParams url.Values
+	httpClient  *http.Client
+	// respHeader, if non-nil, is populated with the headers of the response.
+	respHeader *http.Header
 }
 
 func NewOptions(ops []Option) *Options {
@@ -38,6 +41,21 @@ func (o *Options) QueryParams() url.Values {
 	return o.queryParams
 }
 
+// HTTPClient returns the client that should be used to issue the request. If
+// no client was provided, [http.DefaultClient] is returned.
+func (o *Options) HTTPClient() *http.Client {
+	if o.httpClient != nil {
+		return o.httpClient
+	}
+	return http.DefaultClient
+}
+
+// ResponseHeader returns the location that the headers of the response should
+// be written to. If no location was provided, nil is returned.
+func (o *Options) ResponseHeader() *http.Header {
+	return o.respHeader
+}
+
 func WithHeader(key, val string) Option {
 	return func(o *Options) {
 		o.headers.Set(key, val)
@@ -49,3 +67,20 @@ func WithQueryParam(key, val string) Option {
 		o.queryParams.Set(key, val)
 	}
 }
+
+// WithHTTPClient specifies the client to issue the request with. Sharing a
+// single client across multiple API clients allows connections to the same
+// node to be reused.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *Options) {
+		o.httpClient = client
+	}
+}
+
+// WithResponseHeader populates [header] with the headers of the response once
+// the request has been issued.
+func WithResponseHeader(header *http.Header) Option {
+	return func(o *Options) {
+		o.respHeader = header
+	}
+}
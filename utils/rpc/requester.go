@@ -6,6 +6,7 @@ package rpc
 import (
 	"context"
 	"net/url"
+	"slices"
 )
 
 var _ EndpointRequester = (*avalancheEndpointRequester)(nil)
@@ -15,12 +16,16 @@ type EndpointRequester interface {
 }
 
 type avalancheEndpointRequester struct {
-	uri string
+	uri     string
+	options []Option
 }
 
-func NewEndpointRequester(uri string) EndpointRequester {
+// NewEndpointRequester returns a requester for [uri]. The provided [options]
+// are applied to every request before any request specific options.
+func NewEndpointRequester(uri string, options ...Option) EndpointRequester {
 	return &avalancheEndpointRequester{
-		uri: uri,
+		uri:     uri,
+		options: options,
 	}
 }
 
@@ -42,6 +47,6 @@ func (e *avalancheEndpointRequester) SendRequest(
 		method,
 		params,
 		reply,
-		options...,
+		append(slices.Clip(e.options), options...)...,
 	)
 }
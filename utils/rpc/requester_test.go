@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type countingTransport struct {
+	numRequests atomic.Int64
+}
+
+func (c *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	c.numRequests.Add(1)
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+type testReply struct {
+	Value string `json:"value"`
+}
+
+func newTestServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","result":{"value":"` + r.Header.Get("X-Test") + `"},"id":1}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestEndpointRequesterHTTPClient(t *testing.T) {
+	require := require.New(t)
+
+	server := newTestServer(t)
+	transport := &countingTransport{}
+	httpClient := &http.Client{
+		Transport: transport,
+	}
+
+	requester := NewEndpointRequester(server.URL, WithHTTPClient(httpClient))
+	for i := 0; i < 3; i++ {
+		var reply testReply
+		require.NoError(requester.SendRequest(context.Background(), "test.method", struct{}{}, &reply))
+	}
+	require.Equal(int64(3), transport.numRequests.Load())
+}
+
+func TestEndpointRequesterOptionsOrder(t *testing.T) {
+	require := require.New(t)
+
+	server := newTestServer(t)
+	requester := NewEndpointRequester(server.URL, WithHeader("X-Test", "default"))
+
+	var reply testReply
+	require.NoError(requester.SendRequest(context.Background(), "test.method", struct{}{}, &reply))
+	require.Equal("default", reply.Value)
+
+	require.NoError(requester.SendRequest(context.Background(), "test.method", struct{}{}, &reply, WithHeader("X-Test", "override")))
+	require.Equal("override", reply.Value)
+}
+
+func TestEndpointRequesterResponseHeader(t *testing.T) {
+	require := require.New(t)
+
+	server := newTestServer(t)
+	requester := NewEndpointRequester(server.URL)
+
+	var (
+		header http.Header
+		reply  testReply
+	)
+	require.NoError(requester.SendRequest(
+		context.Background(),
+		"test.method",
+		struct{}{},
+		&reply,
+		WithResponseHeader(&header),
+	))
+	require.Equal("application/json", header.Get("Content-Type"))
+}
@@ -222,6 +222,12 @@ type client struct {
 
 // NewClient returns an AVM client for interacting with avm [chain]
 func NewClient(uri, chain string) Client {
+	return NewClientWithOptions(uri, chain)
+}
+
+// NewClientWithOptions returns an AVM client for interacting with avm [chain]
+// that applies [options] to every request.
+func NewClientWithOptions(uri, chain string, options ...rpc.Option) Client {
 	path := fmt.Sprintf(
 		"%s/ext/%s/%s",
 		uri,
@@ -229,7 +235,7 @@ func NewClient(uri, chain string) Client {
 		chain,
 	)
 	return &client{
-		requester: rpc.NewEndpointRequester(path),
+		requester: rpc.NewEndpointRequester(path, options...),
 	}
 }
 
@@ -5,9 +5,13 @@ package platformvm
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
@@ -18,14 +22,34 @@ import (
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 	"github.com/ava-labs/avalanchego/vms/components/gas"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
 	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	platformapi "github.com/ava-labs/avalanchego/vms/platformvm/api"
 )
 
-var _ Client = (*client)(nil)
+var (
+	_ Client = (*client)(nil)
+
+	ErrTxNoLongerAccepted     = errors.New("tx is no longer accepted")
+	ErrTxDropped              = errors.New("tx was dropped")
+	ErrHeightDecreased        = errors.New("height decreased")
+	ErrHeightNotInFuture      = errors.New("height is not in the future")
+	ErrMissingBlockTimestamp  = errors.New("block does not have a timestamp")
+	ErrInsufficientBlockTimes = errors.New("insufficient blocks to estimate block time")
+	ErrTxBlockNotFound        = errors.New("block including tx not found")
+)
+
+// expiryHeightSampleSize is the number of recent blocks that are sampled to
+// estimate the time between P-chain blocks in [ExpiryFromHeight].
+const expiryHeightSampleSize = 10
+
+// txBlockSearchDepth is the number of blocks below the current height that are
+// searched for the block including a tx in [AwaitTxConfirmations].
+const txBlockSearchDepth = 256
 
 // Client interface for interacting with the P Chain endpoint
 type Client interface {
@@ -79,6 +103,9 @@ type Client interface {
 	// GetL1Validator returns the requested L1 validator with [validationID] and
 	// the height at which it was calculated.
 	GetL1Validator(ctx context.Context, validationID ids.ID, options ...rpc.Option) (L1Validator, uint64, error)
+	// GetL1Validators returns the status of every current L1 validator of
+	// [subnetID], including inactive validators, sorted by validationID.
+	GetL1Validators(ctx context.Context, subnetID ids.ID, options ...rpc.Option) ([]L1ValidatorStatus, error)
 	// GetCurrentSupply returns an upper bound on the supply of AVAX in the system along with the P-chain height
 	GetCurrentSupply(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (uint64, uint64, error)
 	// SampleValidators returns the nodeIDs of a sample of [sampleSize] validators from the current validator set for subnet with ID [subnetID]
@@ -99,6 +126,12 @@ type Client interface {
 	GetTx(ctx context.Context, txID ids.ID, options ...rpc.Option) ([]byte, error)
 	// GetTxStatus returns the status of the transaction corresponding to [txID]
 	GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (*GetTxStatusResponse, error)
+	// GetMempoolTxs returns the IDs of the txs that are currently in the
+	// mempool of the node.
+	GetMempoolTxs(ctx context.Context, options ...rpc.Option) ([]ids.ID, error)
+	// GetMempoolTxBytes returns the bytes of the txs that are currently in the
+	// mempool of the node.
+	GetMempoolTxBytes(ctx context.Context, options ...rpc.Option) ([][]byte, error)
 	// GetStake returns the amount of nAVAX that [addrs] have cumulatively
 	// staked on the Primary Network.
 	//
@@ -137,6 +170,9 @@ type Client interface {
 	GetFeeConfig(ctx context.Context, options ...rpc.Option) (*gas.Config, error)
 	// GetFeeState returns the current fee state of the chain.
 	GetFeeState(ctx context.Context, options ...rpc.Option) (gas.State, gas.Price, time.Time, error)
+	// GetValidatorFeeState returns the current continuous fee state of the L1
+	// validators.
+	GetValidatorFeeState(ctx context.Context, options ...rpc.Option) (gas.Gas, gas.Price, time.Time, error)
 }
 
 // Client implementation for interacting with the P Chain endpoint
@@ -146,8 +182,15 @@ type client struct {
 
 // NewClient returns a Client for interacting with the P Chain endpoint
 func NewClient(uri string) Client {
+	return NewClientWithOptions(uri)
+}
+
+// NewClientWithOptions returns a Client for interacting with the P Chain
+// endpoint that applies [options] to every request.
+func NewClientWithOptions(uri string, options ...rpc.Option) Client {
 	return &client{requester: rpc.NewEndpointRequester(
-		uri + "/ext/P",
+		uri+"/ext/P",
+		options...,
 	)}
 }
 
@@ -256,6 +299,21 @@ type GetSubnetClientResponse struct {
 	ManagerAddress []byte
 }
 
+// IsL1 returns true if the subnet has been converted to an L1.
+func (r *GetSubnetClientResponse) IsL1() bool {
+	return r.ConversionID != ids.Empty
+}
+
+// Owner returns the owner that must authorize changes to the subnet, such as
+// its conversion to an L1.
+func (r *GetSubnetClientResponse) Owner() *secp256k1fx.OutputOwners {
+	return &secp256k1fx.OutputOwners{
+		Locktime:  r.Locktime,
+		Threshold: r.Threshold,
+		Addrs:     r.ControlKeys,
+	}
+}
+
 func (c *client) GetSubnet(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (GetSubnetClientResponse, error) {
 	res := &GetSubnetResponse{}
 	err := c.requester.SendRequest(ctx, "platform.getSubnet", &GetSubnetArgs{
@@ -406,6 +464,46 @@ func (c *client) GetL1Validator(
 	}, uint64(res.Height), err
 }
 
+func (c *client) GetL1Validators(
+	ctx context.Context,
+	subnetID ids.ID,
+	options ...rpc.Option,
+) ([]L1ValidatorStatus, error) {
+	res := &GetL1ValidatorsReply{}
+	err := c.requester.SendRequest(ctx, "platform.getL1Validators",
+		&GetL1ValidatorsArgs{
+			SubnetID: subnetID,
+		},
+		res, options...,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]L1ValidatorStatus, len(res.Validators))
+	for i, vdr := range res.Validators {
+		deactivationOwnerAddrs, err := address.ParseToIDs(vdr.DeactivationOwner.Addresses)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse deactivation owner of %s: %w", vdr.ValidationID, err)
+		}
+		statuses[i] = L1ValidatorStatus{
+			ValidationID: vdr.ValidationID,
+			NodeID:       vdr.NodeID,
+			Registered:   true,
+			Active:       vdr.Balance > 0,
+			Weight:       uint64(vdr.Weight),
+			Balance:      uint64(vdr.Balance),
+			DeactivationOwner: &secp256k1fx.OutputOwners{
+				Locktime:  uint64(vdr.DeactivationOwner.Locktime),
+				Threshold: uint32(vdr.DeactivationOwner.Threshold),
+				Addrs:     deactivationOwnerAddrs,
+			},
+			Height: uint64(res.Height),
+		}
+	}
+	return statuses, nil
+}
+
 func (c *client) GetCurrentSupply(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (uint64, uint64, error) {
 	res := &GetCurrentSupplyReply{}
 	err := c.requester.SendRequest(ctx, "platform.getCurrentSupply", &GetCurrentSupplyArgs{
@@ -493,6 +591,49 @@ func (c *client) GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Op
 	return res, err
 }
 
+func (c *client) GetMempoolTxs(ctx context.Context, options ...rpc.Option) ([]ids.ID, error) {
+	txIDs, _, err := c.getMempoolTxs(ctx, options...)
+	return txIDs, err
+}
+
+func (c *client) GetMempoolTxBytes(ctx context.Context, options ...rpc.Option) ([][]byte, error) {
+	_, txs, err := c.getMempoolTxs(ctx, options...)
+	return txs, err
+}
+
+// getMempoolTxs fetches every page of the txs in the mempool of the node.
+func (c *client) getMempoolTxs(ctx context.Context, options ...rpc.Option) ([]ids.ID, [][]byte, error) {
+	var (
+		txIDs     = []ids.ID{}
+		txs       = [][]byte{}
+		startTxID ids.ID
+	)
+	for {
+		res := &GetMempoolTxsReply{}
+		err := c.requester.SendRequest(ctx, "platform.getMempoolTxs", &GetMempoolTxsArgs{
+			StartTxID: startTxID,
+			Limit:     maxMempoolTxsPageSize,
+			Encoding:  formatting.Hex,
+		}, res, options...)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, txStr := range res.Txs {
+			txBytes, err := formatting.Decode(res.Encoding, txStr)
+			if err != nil {
+				return nil, nil, err
+			}
+			txs = append(txs, txBytes)
+		}
+		txIDs = append(txIDs, res.TxIDs...)
+
+		if len(res.TxIDs) < maxMempoolTxsPageSize {
+			return txIDs, txs, nil
+		}
+		startTxID = res.TxIDs[len(res.TxIDs)-1]
+	}
+}
+
 func (c *client) GetStake(
 	ctx context.Context,
 	addrs []ids.ShortID,
@@ -621,16 +762,41 @@ func (c *client) GetFeeState(ctx context.Context, options ...rpc.Option) (gas.St
 	return res.State, res.Price, res.Time, err
 }
 
+func (c *client) GetValidatorFeeState(ctx context.Context, options ...rpc.Option) (gas.Gas, gas.Price, time.Time, error) {
+	res := &GetValidatorFeeStateReply{}
+	err := c.requester.SendRequest(ctx, "platform.getValidatorFeeState", struct{}{}, res, options...)
+	return res.Excess, res.Price, res.Time, err
+}
+
 func AwaitTxAccepted(
 	c Client,
 	ctx context.Context,
 	txID ids.ID,
 	freq time.Duration,
 	options ...rpc.Option,
+) error {
+	return AwaitTxAcceptedWithGracePeriod(c, ctx, txID, freq, 0, options...)
+}
+
+// AwaitTxAcceptedWithGracePeriod waits until [txID] is accepted, like
+// [AwaitTxAccepted]. Shortly after a tx is issued, the node may report the tx
+// as unknown. If the node reports the tx as unknown or dropped, without
+// reporting the tx as processing, for longer than [gracePeriod], the tx is
+// considered to have been dropped and [ErrTxDropped] is returned.
+//
+// If [gracePeriod] is zero, the tx is never considered to have been dropped.
+func AwaitTxAcceptedWithGracePeriod(
+	c Client,
+	ctx context.Context,
+	txID ids.ID,
+	freq time.Duration,
+	gracePeriod time.Duration,
+	options ...rpc.Option,
 ) error {
 	ticker := time.NewTicker(freq)
 	defer ticker.Stop()
 
+	lastPending := time.Now()
 	for {
 		res, err := c.GetTxStatus(ctx, txID, options...)
 		if err != nil {
@@ -640,6 +806,17 @@ func AwaitTxAccepted(
 		switch res.Status {
 		case status.Committed, status.Aborted:
 			return nil
+		case status.Processing:
+			lastPending = time.Now()
+		default:
+			if unknownFor := time.Since(lastPending); gracePeriod > 0 && unknownFor > gracePeriod {
+				return fmt.Errorf("%w: %s has had status %s for %s",
+					ErrTxDropped,
+					txID,
+					res.Status,
+					unknownFor,
+				)
+			}
 		}
 
 		select {
@@ -650,6 +827,93 @@ func AwaitTxAccepted(
 	}
 }
 
+// AwaitTxConfirmations waits until at least [confirmations] blocks have been
+// accepted on top of the block that included [txID]. It assumes that [txID]
+// was already accepted, and searches up to [txBlockSearchDepth] blocks below
+// the current height for the block that included it.
+//
+// If [txID] is no longer reported as accepted, or the height of the chain
+// drops below the height of the block that included [txID], an error is
+// returned.
+func AwaitTxConfirmations(
+	c Client,
+	ctx context.Context,
+	txID ids.ID,
+	confirmations uint64,
+	freq time.Duration,
+	options ...rpc.Option,
+) error {
+	height, err := c.GetHeight(ctx, options...)
+	if err != nil {
+		return err
+	}
+	acceptedHeight, err := getTxBlockHeight(c, ctx, txID, height, options...)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(freq)
+	defer ticker.Stop()
+
+	for {
+		res, err := c.GetTxStatus(ctx, txID, options...)
+		if err != nil {
+			return err
+		}
+
+		switch res.Status {
+		case status.Committed, status.Aborted:
+		default:
+			return fmt.Errorf("%w: %s has status %s", ErrTxNoLongerAccepted, txID, res.Status)
+		}
+
+		height, err := c.GetHeight(ctx, options...)
+		if err != nil {
+			return err
+		}
+		if height < acceptedHeight {
+			return fmt.Errorf("%w: from %d to %d", ErrHeightDecreased, acceptedHeight, height)
+		}
+		if height-acceptedHeight >= confirmations {
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// getTxBlockHeight returns the height of the block that included [txID],
+// searching down from [height] for at most [txBlockSearchDepth] blocks.
+func getTxBlockHeight(
+	c Client,
+	ctx context.Context,
+	txID ids.ID,
+	height uint64,
+	options ...rpc.Option,
+) (uint64, error) {
+	for i := uint64(0); i < txBlockSearchDepth && i <= height; i++ {
+		blkHeight := height - i
+		blkBytes, err := c.GetBlockByHeight(ctx, blkHeight, options...)
+		if err != nil {
+			return 0, fmt.Errorf("failed to fetch block at height %d: %w", blkHeight, err)
+		}
+		blk, err := block.Parse(block.Codec, blkBytes)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse block at height %d: %w", blkHeight, err)
+		}
+		for _, tx := range blk.Txs() {
+			if tx.ID() == txID {
+				return blkHeight, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("%w: %s within %d blocks of height %d", ErrTxBlockNotFound, txID, txBlockSearchDepth, height)
+}
+
 // GetSubnetOwners returns a map of subnet ID to current subnet's owner
 func GetSubnetOwners(
 	c Client,
@@ -713,3 +977,123 @@ func GetOwners(
 	}
 	return owners, nil
 }
+
+// L1ValidatorStatus summarizes the current state of an L1 validator.
+type L1ValidatorStatus struct {
+	ValidationID ids.ID
+	NodeID       ids.NodeID
+	// Registered is false if the P-chain does not know the validationID. This
+	// is the case before the validator is registered and after it is removed.
+	Registered bool
+	// Active is true if the validator has a remaining balance to pay the
+	// continuous fee.
+	Active bool
+	// Weight is the current weight of the validator.
+	Weight uint64
+	// Balance is the remaining amount of AVAX the validator has for paying the
+	// continuous fee.
+	Balance uint64
+	// DeactivationOwner is the owner that is able to disable the validator.
+	DeactivationOwner *secp256k1fx.OutputOwners
+	// Height is the P-chain height at which the status was calculated.
+	Height uint64
+}
+
+// GetL1ValidatorStatus returns the status of the L1 validator with
+// [validationID]. If the P-chain does not know [validationID], a status with
+// Registered set to false is returned rather than an error, so that the
+// registration of a validator can be polled.
+func GetL1ValidatorStatus(
+	c Client,
+	ctx context.Context,
+	validationID ids.ID,
+	options ...rpc.Option,
+) (*L1ValidatorStatus, error) {
+	l1Validator, height, err := c.GetL1Validator(ctx, validationID, options...)
+	if err != nil {
+		// The JSON-RPC response only includes the error message, so
+		// database.ErrNotFound can not be compared directly.
+		if strings.HasSuffix(err.Error(), database.ErrNotFound.Error()) {
+			return &L1ValidatorStatus{}, nil
+		}
+		return nil, fmt.Errorf("failed to fetch L1 validator %s: %w", validationID, err)
+	}
+	return &L1ValidatorStatus{
+		ValidationID:      validationID,
+		NodeID:            l1Validator.NodeID,
+		Registered:        true,
+		Active:            l1Validator.Balance > 0,
+		Weight:            l1Validator.Weight,
+		Balance:           l1Validator.Balance,
+		DeactivationOwner: l1Validator.DeactivationOwner,
+		Height:            height,
+	}, nil
+}
+
+// ExpiryFromHeight returns the expiry to include in a RegisterL1Validator
+// message so that the message is no longer valid once the P-chain reaches
+// approximately [targetHeight].
+//
+// RegisterL1Validator messages only support an expiry in Unix seconds, so the
+// time at which [targetHeight] will be reached is estimated from the average
+// time between the most recently accepted blocks. P-chain blocks are only
+// produced when there are transactions to include, so the estimate should be
+// treated as approximate.
+//
+// The expiry is validated with [message.ExpiryFromDeadline].
+func ExpiryFromHeight(
+	c Client,
+	ctx context.Context,
+	targetHeight uint64,
+	options ...rpc.Option,
+) (uint64, error) {
+	height, err := c.GetHeight(ctx, options...)
+	if err != nil {
+		return 0, err
+	}
+	if targetHeight <= height {
+		return 0, fmt.Errorf("%w: %d <= %d", ErrHeightNotInFuture, targetHeight, height)
+	}
+
+	numBlocks := min(height, expiryHeightSampleSize)
+	if numBlocks == 0 {
+		return 0, ErrInsufficientBlockTimes
+	}
+
+	lastTime, err := GetBlockTime(c, ctx, height, options...)
+	if err != nil {
+		return 0, err
+	}
+	firstTime, err := GetBlockTime(c, ctx, height-numBlocks, options...)
+	if err != nil {
+		return 0, err
+	}
+
+	blockTime := lastTime.Sub(firstTime) / time.Duration(numBlocks)
+	deadline := lastTime.Add(blockTime * time.Duration(targetHeight-height))
+	return message.ExpiryFromDeadline(deadline)
+}
+
+// GetBlockTime returns the timestamp of the block at [height].
+//
+// Returns [ErrMissingBlockTimestamp] if the block was produced before Banff.
+func GetBlockTime(
+	c Client,
+	ctx context.Context,
+	height uint64,
+	options ...rpc.Option,
+) (time.Time, error) {
+	blkBytes, err := c.GetBlockByHeight(ctx, height, options...)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to fetch block at height %d: %w", height, err)
+	}
+	blk, err := block.Parse(block.Codec, blkBytes)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse block at height %d: %w", height, err)
+	}
+	banffBlk, ok := blk.(block.BanffBlock)
+	if !ok {
+		return time.Time{}, fmt.Errorf("%w: %T at height %d", ErrMissingBlockTimestamp, blk, height)
+	}
+	return banffBlk.Timestamp(), nil
+}
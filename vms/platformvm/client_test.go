@@ -0,0 +1,632 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var errTest = errors.New("non-nil error")
+
+// heightClient reports the next height in [heights] on every call to
+// GetHeight and reports the next status in [statuses] on every call to
+// GetTxStatus. Once exhausted, the last height and status are repeated. Blocks
+// are served from [blocks].
+type heightClient struct {
+	Client
+
+	heights  []uint64
+	statuses []status.Status
+	blocks   map[uint64][]byte
+}
+
+func (c *heightClient) GetHeight(context.Context, ...rpc.Option) (uint64, error) {
+	height := c.heights[0]
+	if len(c.heights) > 1 {
+		c.heights = c.heights[1:]
+	}
+	return height, nil
+}
+
+func (c *heightClient) GetBlockByHeight(_ context.Context, height uint64, _ ...rpc.Option) ([]byte, error) {
+	blkBytes, ok := c.blocks[height]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return blkBytes, nil
+}
+
+func (c *heightClient) GetTxStatus(context.Context, ids.ID, ...rpc.Option) (*GetTxStatusResponse, error) {
+	txStatus := c.statuses[0]
+	if len(c.statuses) > 1 {
+		c.statuses = c.statuses[1:]
+	}
+	return &GetTxStatusResponse{
+		Status: txStatus,
+	}, nil
+}
+
+func TestAwaitTxAcceptedWithGracePeriod(t *testing.T) {
+	tests := []struct {
+		name        string
+		statuses    []status.Status
+		gracePeriod time.Duration
+		expectedErr error
+	}{
+		{
+			name: "unknown then processing",
+			statuses: []status.Status{
+				status.Unknown,
+				status.Unknown,
+				status.Processing,
+				status.Committed,
+			},
+			gracePeriod: time.Hour,
+		},
+		{
+			name: "unknown without grace period",
+			statuses: []status.Status{
+				status.Unknown,
+				status.Committed,
+			},
+		},
+		{
+			name: "dropped after grace period",
+			statuses: []status.Status{
+				status.Processing,
+				status.Unknown,
+			},
+			gracePeriod: 10 * time.Millisecond,
+			expectedErr: ErrTxDropped,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &heightClient{
+				statuses: test.statuses,
+			}
+			err := AwaitTxAcceptedWithGracePeriod(
+				c,
+				context.Background(),
+				ids.GenerateTestID(),
+				time.Millisecond,
+				test.gracePeriod,
+			)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestAwaitTxConfirmations(t *testing.T) {
+	tx, err := txs.NewSigned(&txs.AdvanceTimeTx{Time: 1}, txs.Codec, nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		confirmations uint64
+		txHeight      uint64
+		heights       []uint64
+		statuses      []status.Status
+		expectedErr   error
+	}{
+		{
+			name:          "already confirmed",
+			confirmations: 0,
+			txHeight:      10,
+			heights:       []uint64{10},
+			statuses:      []status.Status{status.Committed},
+		},
+		{
+			name:          "counts from tx block",
+			confirmations: 2,
+			txHeight:      8,
+			heights:       []uint64{10},
+			statuses:      []status.Status{status.Committed},
+		},
+		{
+			name:          "waits for confirmations",
+			confirmations: 2,
+			txHeight:      10,
+			heights:       []uint64{10, 10, 11, 12},
+			statuses:      []status.Status{status.Committed},
+		},
+		{
+			name:          "aborted tx",
+			confirmations: 1,
+			txHeight:      10,
+			heights:       []uint64{10, 11},
+			statuses:      []status.Status{status.Aborted},
+		},
+		{
+			name:          "tx no longer accepted",
+			confirmations: 2,
+			txHeight:      10,
+			heights:       []uint64{10, 11},
+			statuses:      []status.Status{status.Committed, status.Processing},
+			expectedErr:   ErrTxNoLongerAccepted,
+		},
+		{
+			name:          "height decreased",
+			confirmations: 2,
+			txHeight:      10,
+			heights:       []uint64{10, 11, 9},
+			statuses:      []status.Status{status.Committed},
+			expectedErr:   ErrHeightDecreased,
+		},
+		{
+			name:          "tx block not found",
+			confirmations: 1,
+			txHeight:      11,
+			heights:       []uint64{10},
+			statuses:      []status.Status{status.Committed},
+			expectedErr:   ErrTxBlockNotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			blocks := make(map[uint64][]byte)
+			for height := uint64(0); height <= test.heights[0]; height++ {
+				var blkTxs []*txs.Tx
+				if height == test.txHeight {
+					blkTxs = []*txs.Tx{tx}
+				}
+				blk, err := block.NewBanffStandardBlock(time.Unix(0, 0), ids.GenerateTestID(), height, blkTxs)
+				require.NoError(err)
+				blocks[height] = blk.Bytes()
+			}
+
+			c := &heightClient{
+				heights:  test.heights,
+				statuses: test.statuses,
+				blocks:   blocks,
+			}
+			err := AwaitTxConfirmations(
+				c,
+				context.Background(),
+				tx.ID(),
+				test.confirmations,
+				time.Millisecond,
+			)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+type l1ValidatorClient struct {
+	Client
+
+	l1Validator L1Validator
+	height      uint64
+	err         error
+}
+
+func (c *l1ValidatorClient) GetL1Validator(context.Context, ids.ID, ...rpc.Option) (L1Validator, uint64, error) {
+	return c.l1Validator, c.height, c.err
+}
+
+func TestGetL1ValidatorStatus(t *testing.T) {
+	validationID := ids.GenerateTestID()
+	deactivationOwner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+	}
+	tests := []struct {
+		name           string
+		client         *l1ValidatorClient
+		expectedStatus *L1ValidatorStatus
+		expectedErr    error
+	}{
+		{
+			name: "active",
+			client: &l1ValidatorClient{
+				l1Validator: L1Validator{
+					DeactivationOwner: deactivationOwner,
+					Weight:            5,
+					Balance:           10,
+				},
+				height: 100,
+			},
+			expectedStatus: &L1ValidatorStatus{
+				ValidationID:      validationID,
+				Registered:        true,
+				Active:            true,
+				Weight:            5,
+				Balance:           10,
+				DeactivationOwner: deactivationOwner,
+				Height:            100,
+			},
+		},
+		{
+			name: "inactive",
+			client: &l1ValidatorClient{
+				l1Validator: L1Validator{
+					DeactivationOwner: deactivationOwner,
+					Weight:            5,
+				},
+				height: 100,
+			},
+			expectedStatus: &L1ValidatorStatus{
+				ValidationID:      validationID,
+				Registered:        true,
+				Weight:            5,
+				DeactivationOwner: deactivationOwner,
+				Height:            100,
+			},
+		},
+		{
+			name: "not registered",
+			client: &l1ValidatorClient{
+				// Errors are returned from the API as plain messages.
+				err: fmt.Errorf(
+					"fetching L1 validator %q failed: %s",
+					validationID,
+					database.ErrNotFound.Error(),
+				),
+			},
+			expectedStatus: &L1ValidatorStatus{},
+		},
+		{
+			name: "request failure",
+			client: &l1ValidatorClient{
+				err: errTest,
+			},
+			expectedErr: errTest,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status, err := GetL1ValidatorStatus(test.client, context.Background(), validationID)
+			require.ErrorIs(t, err, test.expectedErr)
+			require.Equal(t, test.expectedStatus, status)
+		})
+	}
+}
+
+// blockClient reports [height] from GetHeight and serves [blocks] from
+// GetBlockByHeight.
+type blockClient struct {
+	Client
+
+	height uint64
+	blocks map[uint64][]byte
+}
+
+func (c *blockClient) GetHeight(context.Context, ...rpc.Option) (uint64, error) {
+	return c.height, nil
+}
+
+func (c *blockClient) GetBlockByHeight(_ context.Context, height uint64, _ ...rpc.Option) ([]byte, error) {
+	blkBytes, ok := c.blocks[height]
+	if !ok {
+		return nil, database.ErrNotFound
+	}
+	return blkBytes, nil
+}
+
+func TestExpiryFromHeight(t *testing.T) {
+	var (
+		now       = time.Unix(time.Now().Unix(), 0)
+		blockTime = 2 * time.Second
+	)
+	newBanffBlock := func(t *testing.T, height uint64, timestamp time.Time) []byte {
+		blk, err := block.NewBanffStandardBlock(timestamp, ids.GenerateTestID(), height, nil)
+		require.NoError(t, err)
+		return blk.Bytes()
+	}
+	newApricotBlock := func(t *testing.T, height uint64) []byte {
+		blk, err := block.NewApricotStandardBlock(ids.GenerateTestID(), height, nil)
+		require.NoError(t, err)
+		return blk.Bytes()
+	}
+
+	tests := []struct {
+		name           string
+		height         uint64
+		blocks         map[uint64][]byte
+		targetHeight   uint64
+		expectedExpiry uint64
+		expectedErr    error
+	}{
+		{
+			name:   "estimated from sampled blocks",
+			height: 100,
+			blocks: map[uint64][]byte{
+				90:  newBanffBlock(t, 90, now.Add(-expiryHeightSampleSize*blockTime)),
+				100: newBanffBlock(t, 100, now),
+			},
+			targetHeight:   130,
+			expectedExpiry: uint64(now.Add(30 * blockTime).Unix()),
+		},
+		{
+			name:   "fewer blocks than the sample size",
+			height: 2,
+			blocks: map[uint64][]byte{
+				0: newBanffBlock(t, 0, now.Add(-2*blockTime)),
+				2: newBanffBlock(t, 2, now),
+			},
+			targetHeight:   3,
+			expectedExpiry: uint64(now.Add(blockTime).Unix()),
+		},
+		{
+			name:         "target height reached",
+			height:       100,
+			targetHeight: 100,
+			expectedErr:  ErrHeightNotInFuture,
+		},
+		{
+			name:         "no accepted blocks",
+			height:       0,
+			targetHeight: 1,
+			expectedErr:  ErrInsufficientBlockTimes,
+		},
+		{
+			name:   "block without timestamp",
+			height: 100,
+			blocks: map[uint64][]byte{
+				90:  newApricotBlock(t, 90),
+				100: newBanffBlock(t, 100, now),
+			},
+			targetHeight: 130,
+			expectedErr:  ErrMissingBlockTimestamp,
+		},
+		{
+			name:   "expiry too far in the future",
+			height: 100,
+			blocks: map[uint64][]byte{
+				90:  newBanffBlock(t, 90, now.Add(-expiryHeightSampleSize*time.Hour)),
+				100: newBanffBlock(t, 100, now),
+			},
+			targetHeight: 125,
+			expectedErr:  message.ErrDeadlineTooFar,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			c := &blockClient{
+				height: test.height,
+				blocks: test.blocks,
+			}
+			expiry, err := ExpiryFromHeight(c, context.Background(), test.targetHeight)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedExpiry, expiry)
+		})
+	}
+}
+
+// recordedRequester replies to every request with [result], which is a
+// recorded JSON result of an API call.
+type recordedRequester struct {
+	result string
+}
+
+func (r *recordedRequester) SendRequest(_ context.Context, _ string, _ interface{}, reply interface{}, _ ...rpc.Option) error {
+	return json.Unmarshal([]byte(r.result), reply)
+}
+
+// pagedRequester replies to each request with the next of [results] and
+// records the args of every request.
+type pagedRequester struct {
+	results []string
+	args    []interface{}
+}
+
+func (r *pagedRequester) SendRequest(_ context.Context, _ string, args interface{}, reply interface{}, _ ...rpc.Option) error {
+	r.args = append(r.args, args)
+	result := r.results[0]
+	r.results = r.results[1:]
+	return json.Unmarshal([]byte(result), reply)
+}
+
+func TestGetL1Validators(t *testing.T) {
+	tests := []struct {
+		name             string
+		result           string
+		expectedStatuses []L1ValidatorStatus
+	}{
+		{
+			name:             "no validators",
+			result:           `{"validators":[],"height":"7"}`,
+			expectedStatuses: []L1ValidatorStatus{},
+		},
+		{
+			name: "multiple validators",
+			result: `{
+				"validators": [
+					{
+						"validationID": "SYXsAycDPUu4z2ZksJD5fh5nTDcH3vCFHnpcVye5XuJ2jArg",
+						"nodeID": "NodeID-6HgC8KRBEhXYbF4riJyJFLSHt37UNuRt",
+						"publicKey": "0x900c9b119b5c82d781d4b49be78c3fc7ae65f2b435b7ed9e3a8b9a03e475edff86d8a64827fec8db23a6f236afbf127d",
+						"deactivationOwner": {
+							"locktime": "0",
+							"threshold": "1",
+							"addresses": ["P-local1qyqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqxrhj9t"]
+						},
+						"startTime": "1731445206",
+						"weight": "20",
+						"minNonce": "3",
+						"balance": "1000000000"
+					},
+					{
+						"validationID": "t64jLxDRmxo8y48WjbRALPAZuSDZ6qPVaaeDzxHA4oSojhLt",
+						"nodeID": "NodeID-BaMPFdqMUQ46BV8iRcwbVfsam55kMqcp",
+						"publicKey": "0x900c9b119b5c82d781d4b49be78c3fc7ae65f2b435b7ed9e3a8b9a03e475edff86d8a64827fec8db23a6f236afbf127d",
+						"deactivationOwner": {
+							"locktime": "0",
+							"threshold": "1",
+							"addresses": ["P-local1qgqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqye75yw"]
+						},
+						"startTime": "1731445207",
+						"weight": "10",
+						"minNonce": "0",
+						"balance": "0"
+					}
+				],
+				"height": "42"
+			}`,
+			expectedStatuses: []L1ValidatorStatus{
+				{
+					ValidationID: ids.ID{1},
+					NodeID:       ids.BuildTestNodeID([]byte{1}),
+					Registered:   true,
+					Active:       true,
+					Weight:       20,
+					Balance:      1_000_000_000,
+					DeactivationOwner: &secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{{1}},
+					},
+					Height: 42,
+				},
+				{
+					ValidationID: ids.ID{2},
+					NodeID:       ids.BuildTestNodeID([]byte{2}),
+					Registered:   true,
+					Weight:       10,
+					DeactivationOwner: &secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{{2}},
+					},
+					Height: 42,
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			c := &client{
+				requester: &recordedRequester{
+					result: test.result,
+				},
+			}
+			statuses, err := c.GetL1Validators(context.Background(), ids.GenerateTestID())
+			require.NoError(err)
+			require.Equal(test.expectedStatuses, statuses)
+		})
+	}
+}
+
+func TestGetMempoolTxs(t *testing.T) {
+	// recordedTx is a BaseTx signed by [secp256k1.TestKeys][0].
+	const recordedTx = "0x0000000000220000000a0000000000000000000000000000000000000000000000000000000000000000000000000000000101000000000000000000000000000000000000000000000000000000000000000000000061766178000000000000000000000000000000000000000000000000000000000000000500000000000003e8000000010000000000000000000000010000000900000001e5e676392ee40e38a171ebcaecc6a3ae85cf33786b159caac01ef96b9f9126fc718193d2436c4ef40de4dda78cddc1cfab5d04d5986fa1888cfa3668d9a35c0501516431dd"
+
+	recordedTxBytes, err := formatting.Decode(formatting.Hex, recordedTx)
+	require.NoError(t, err)
+	recordedTxID, err := ids.FromString("2t96TpENk8DTY4LfQSGy5zsKoYzy3zNvb8JCHvvxsKjUPZs2WP")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		result        string
+		expectedTxIDs []ids.ID
+		expectedTxs   [][]byte
+	}{
+		{
+			name:          "empty mempool",
+			result:        `{"txIDs":[],"txs":[],"encoding":"hex"}`,
+			expectedTxIDs: []ids.ID{},
+			expectedTxs:   [][]byte{},
+		},
+		{
+			name: "single tx",
+			result: `{
+				"txIDs": ["2t96TpENk8DTY4LfQSGy5zsKoYzy3zNvb8JCHvvxsKjUPZs2WP"],
+				"txs": ["` + recordedTx + `"],
+				"encoding": "hex"
+			}`,
+			expectedTxIDs: []ids.ID{recordedTxID},
+			expectedTxs:   [][]byte{recordedTxBytes},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			c := &client{
+				requester: &recordedRequester{
+					result: test.result,
+				},
+			}
+			txIDs, err := c.GetMempoolTxs(context.Background())
+			require.NoError(err)
+			require.Equal(test.expectedTxIDs, txIDs)
+
+			txsBytes, err := c.GetMempoolTxBytes(context.Background())
+			require.NoError(err)
+			require.Equal(test.expectedTxs, txsBytes)
+
+			for i, txBytes := range txsBytes {
+				tx, err := txs.Parse(txs.Codec, txBytes)
+				require.NoError(err)
+				require.Equal(txIDs[i], tx.ID())
+			}
+		})
+	}
+}
+
+func TestGetMempoolTxsPagination(t *testing.T) {
+	require := require.New(t)
+
+	txStr, err := formatting.Encode(formatting.Hex, []byte{0})
+	require.NoError(err)
+
+	var (
+		fullPageTxIDs = make([]ids.ID, maxMempoolTxsPageSize)
+		fullPageTxs   = make([]string, maxMempoolTxsPageSize)
+		lastTxID      = ids.GenerateTestID()
+	)
+	for i := range fullPageTxIDs {
+		fullPageTxIDs[i] = ids.GenerateTestID()
+		fullPageTxs[i] = txStr
+	}
+	fullPage, err := json.Marshal(&GetMempoolTxsReply{
+		TxIDs:    fullPageTxIDs,
+		Txs:      fullPageTxs,
+		Encoding: formatting.Hex,
+	})
+	require.NoError(err)
+	lastPage, err := json.Marshal(&GetMempoolTxsReply{
+		TxIDs:    []ids.ID{lastTxID},
+		Txs:      []string{txStr},
+		Encoding: formatting.Hex,
+	})
+	require.NoError(err)
+
+	requester := &pagedRequester{
+		results: []string{string(fullPage), string(lastPage)},
+	}
+	c := &client{
+		requester: requester,
+	}
+	txIDs, err := c.GetMempoolTxs(context.Background())
+	require.NoError(err)
+	require.Equal(append(fullPageTxIDs, lastTxID), txIDs)
+
+	// The second page starts after the last tx of the first page.
+	require.Len(requester.args, 2)
+	require.Equal(ids.Empty, requester.args[0].(*GetMempoolTxsArgs).StartTxID)
+	require.Equal(fullPageTxIDs[maxMempoolTxsPageSize-1], requester.args[1].(*GetMempoolTxsArgs).StartTxID)
+}
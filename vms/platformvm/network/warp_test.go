@@ -74,8 +74,7 @@ func TestSignatureRequestVerify(t *testing.T) {
 					ids.GenerateTestNodeID(),
 					[bls.PublicKeyLen]byte{},
 					rand.Uint64(),
-					message.PChainOwner{},
-					message.PChainOwner{},
+					message.L1ValidatorOwners{},
 					rand.Uint64(),
 				)).Bytes(),
 			)).Bytes(),
@@ -260,8 +259,7 @@ func TestSignatureRequestVerifyL1ValidatorRegistrationNotRegistered(t *testing.T
 		nodeID0,
 		[bls.PublicKeyLen]byte(bls.PublicKeyToCompressedBytes(pk)),
 		expiry,
-		message.PChainOwner{},
-		message.PChainOwner{},
+		message.L1ValidatorOwners{},
 		weight,
 	)
 	require.NoError(t, err)
@@ -271,8 +269,7 @@ func TestSignatureRequestVerifyL1ValidatorRegistrationNotRegistered(t *testing.T
 		nodeID1,
 		[bls.PublicKeyLen]byte(bls.PublicKeyToCompressedBytes(pk)),
 		expiry,
-		message.PChainOwner{},
-		message.PChainOwner{},
+		message.L1ValidatorOwners{},
 		weight,
 	)
 	require.NoError(t, err)
@@ -282,8 +279,7 @@ func TestSignatureRequestVerifyL1ValidatorRegistrationNotRegistered(t *testing.T
 		nodeID2,
 		[bls.PublicKeyLen]byte(bls.PublicKeyToCompressedBytes(pk)),
 		genesistest.DefaultValidatorStartTimeUnix,
-		message.PChainOwner{},
-		message.PChainOwner{},
+		message.L1ValidatorOwners{},
 		weight,
 	)
 	require.NoError(t, err)
@@ -293,8 +289,7 @@ func TestSignatureRequestVerifyL1ValidatorRegistrationNotRegistered(t *testing.T
 		nodeID3,
 		[bls.PublicKeyLen]byte(bls.PublicKeyToCompressedBytes(pk)),
 		expiry,
-		message.PChainOwner{},
-		message.PChainOwner{},
+		message.L1ValidatorOwners{},
 		weight,
 	)
 	require.NoError(t, err)
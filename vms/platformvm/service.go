@@ -11,6 +11,7 @@ import (
 	"maps"
 	"math"
 	"net/http"
+	"slices"
 	"time"
 
 	"go.uber.org/zap"
@@ -56,6 +57,10 @@ const (
 	// Max number of items allowed in a page
 	maxPageSize = 1024
 
+	// Max number of txs returned by a single GetMempoolTxs call. Every tx is
+	// returned in full, so this is much smaller than [maxPageSize].
+	maxMempoolTxsPageSize = 64
+
 	// Note: Staker attributes cache should be large enough so that no evictions
 	// happen when the API loops through all stakers.
 	stakerAttributesCacheSize = 100_000
@@ -66,6 +71,7 @@ var (
 	errPrimaryNetworkIsNotASubnet = errors.New("the primary network isn't a subnet")
 	errNoAddresses                = errors.New("no addresses provided")
 	errMissingBlockchainID        = errors.New("argument 'blockchainID' not given")
+	errStartTxNotInMempool        = errors.New("start tx is not in the mempool")
 )
 
 // Service defines the API calls that can be made to the platform chain
@@ -1072,6 +1078,97 @@ func (s *Service) GetL1Validator(r *http.Request, args *GetL1ValidatorArgs, repl
 	return nil
 }
 
+type GetL1ValidatorsArgs struct {
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// APIL1Validator is an L1 validator returned by GetL1Validators.
+type APIL1Validator struct {
+	ValidationID ids.ID     `json:"validationID"`
+	NodeID       ids.NodeID `json:"nodeID"`
+	// PublicKey is the compressed BLS public key of the validator
+	PublicKey         types.JSONByteSlice `json:"publicKey"`
+	DeactivationOwner platformapi.Owner   `json:"deactivationOwner"`
+	StartTime         avajson.Uint64      `json:"startTime"`
+	Weight            avajson.Uint64      `json:"weight"`
+	MinNonce          avajson.Uint64      `json:"minNonce"`
+	// Balance is the remaining amount of AVAX this L1 validator has for paying
+	// the continuous fee, according to the last accepted state. If the
+	// validator is inactive, the balance will be 0.
+	Balance avajson.Uint64 `json:"balance"`
+}
+
+type GetL1ValidatorsReply struct {
+	// Validators are sorted by their validationID.
+	Validators []APIL1Validator `json:"validators"`
+	// Height is the height of the last accepted block
+	Height avajson.Uint64 `json:"height"`
+}
+
+// GetL1Validators returns all of the current L1 validators of a subnet,
+// including inactive validators.
+func (s *Service) GetL1Validators(r *http.Request, args *GetL1ValidatorsArgs, reply *GetL1ValidatorsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getL1Validators"),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	vdrs, height, err := s.vm.state.GetCurrentValidatorSet(r.Context(), args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("fetching validators of %q failed: %w", args.SubnetID, err)
+	}
+
+	accruedFees := s.vm.state.GetAccruedFees()
+	reply.Validators = make([]APIL1Validator, 0, len(vdrs))
+	for validationID, vdr := range vdrs {
+		if !vdr.IsL1Validator {
+			continue
+		}
+
+		l1Validator, err := s.vm.state.GetL1Validator(validationID)
+		if err != nil {
+			return fmt.Errorf("fetching L1 validator %q failed: %w", validationID, err)
+		}
+
+		var deactivationOwner message.PChainOwner
+		if _, err := txs.Codec.Unmarshal(l1Validator.DeactivationOwner, &deactivationOwner); err != nil {
+			return fmt.Errorf("failed unmarshalling deactivation owner: %w", err)
+		}
+		deactivationAPIOwner, err := s.getAPIOwner(&secp256k1fx.OutputOwners{
+			Threshold: deactivationOwner.Threshold,
+			Addrs:     deactivationOwner.Addresses,
+		})
+		if err != nil {
+			return fmt.Errorf("failed formatting deactivation owner: %w", err)
+		}
+
+		apiL1Validator := APIL1Validator{
+			ValidationID: validationID,
+			NodeID:       l1Validator.NodeID,
+			PublicKey: bls.PublicKeyToCompressedBytes(
+				bls.PublicKeyFromValidUncompressedBytes(l1Validator.PublicKey),
+			),
+			DeactivationOwner: *deactivationAPIOwner,
+			StartTime:         avajson.Uint64(l1Validator.StartTime),
+			Weight:            avajson.Uint64(l1Validator.Weight),
+			MinNonce:          avajson.Uint64(l1Validator.MinNonce),
+		}
+		if l1Validator.EndAccumulatedFee != 0 {
+			apiL1Validator.Balance = avajson.Uint64(l1Validator.EndAccumulatedFee - accruedFees)
+		}
+		reply.Validators = append(reply.Validators, apiL1Validator)
+	}
+	slices.SortFunc(reply.Validators, func(a, b APIL1Validator) int {
+		return a.ValidationID.Compare(b.ValidationID)
+	})
+	reply.Height = avajson.Uint64(height)
+	return nil
+}
+
 // GetCurrentSupplyArgs are the arguments for calling GetCurrentSupply
 type GetCurrentSupplyArgs struct {
 	SubnetID ids.ID `json:"subnetID"`
@@ -1536,6 +1633,73 @@ func (s *Service) GetTxStatus(_ *http.Request, args *GetTxStatusArgs, response *
 	return nil
 }
 
+type GetMempoolTxsArgs struct {
+	// StartTxID is the last tx returned by the previous page. If empty, the
+	// txs are returned from the start of the mempool.
+	StartTxID ids.ID `json:"startTxID"`
+	// Limit is the maximum number of txs to return. If zero, or larger than
+	// the maximum page size, the maximum page size is used.
+	Limit    avajson.Uint32      `json:"limit"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+type GetMempoolTxsReply struct {
+	TxIDs []ids.ID `json:"txIDs"`
+	// Txs are the encoded txs, in the same order as [TxIDs].
+	Txs      []string            `json:"txs"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetMempoolTxs returns a page of the txs that are currently in the mempool of
+// the node, in the order that they were added to the mempool.
+func (s *Service) GetMempoolTxs(_ *http.Request, args *GetMempoolTxsArgs, reply *GetMempoolTxsReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getMempoolTxs"),
+	)
+
+	limit := int(args.Limit)
+	if limit <= 0 || maxMempoolTxsPageSize < limit {
+		limit = maxMempoolTxsPageSize
+	}
+
+	var (
+		txIDs      = []ids.ID{}
+		encodedTxs = []string{}
+		started    = args.StartTxID == ids.Empty
+		err        error
+	)
+	s.vm.Builder.Iterate(func(tx *txs.Tx) bool {
+		txID := tx.ID()
+		if !started {
+			started = txID == args.StartTxID
+			return true
+		}
+
+		var txStr string
+		txStr, err = formatting.Encode(args.Encoding, tx.Bytes())
+		if err != nil {
+			err = fmt.Errorf("couldn't encode tx as %s: %w", args.Encoding, err)
+			return false
+		}
+
+		txIDs = append(txIDs, txID)
+		encodedTxs = append(encodedTxs, txStr)
+		return len(txIDs) < limit
+	})
+	if err != nil {
+		return err
+	}
+	if !started {
+		return fmt.Errorf("%w: %s", errStartTxNotInMempool, args.StartTxID)
+	}
+
+	reply.TxIDs = txIDs
+	reply.Txs = encodedTxs
+	reply.Encoding = args.Encoding
+	return nil
+}
+
 type GetStakeArgs struct {
 	api.JSONAddresses
 	ValidatorsOnly bool                `json:"validatorsOnly"`
@@ -2008,6 +2172,33 @@ func (s *Service) GetFeeState(_ *http.Request, _ *struct{}, reply *GetFeeStateRe
 	return nil
 }
 
+type GetValidatorFeeStateReply struct {
+	Excess gas.Gas   `json:"excess"`
+	Price  gas.Price `json:"price"`
+	Time   time.Time `json:"timestamp"`
+}
+
+// GetValidatorFeeState returns the current continuous fee state of the L1
+// validators.
+func (s *Service) GetValidatorFeeState(_ *http.Request, _ *struct{}, reply *GetValidatorFeeStateReply) error {
+	s.vm.ctx.Log.Debug("API called",
+		zap.String("service", "platform"),
+		zap.String("method", "getValidatorFeeState"),
+	)
+
+	s.vm.ctx.Lock.Lock()
+	defer s.vm.ctx.Lock.Unlock()
+
+	reply.Excess = s.vm.state.GetL1ValidatorExcess()
+	reply.Price = gas.CalculatePrice(
+		s.vm.ValidatorFeeConfig.MinPrice,
+		reply.Excess,
+		s.vm.ValidatorFeeConfig.ExcessConversionConstant,
+	)
+	reply.Time = s.vm.state.GetTimestamp()
+	return nil
+}
+
 func (s *Service) getAPIOwner(owner *secp256k1fx.OutputOwners) (*platformapi.Owner, error) {
 	apiOwner := &platformapi.Owner{
 		Locktime:  avajson.Uint64(owner.Locktime),
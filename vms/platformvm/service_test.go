@@ -55,6 +55,7 @@ import (
 	blockbuilder "github.com/ava-labs/avalanchego/vms/platformvm/block/builder"
 	blockexecutor "github.com/ava-labs/avalanchego/vms/platformvm/block/executor"
 	txexecutor "github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+	validatorfee "github.com/ava-labs/avalanchego/vms/platformvm/validators/fee"
 )
 
 var (
@@ -187,6 +188,115 @@ func TestExportKey(t *testing.T) {
 }
 
 // Test issuing a tx and accepted
+func TestServiceGetMempoolTxs(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t, upgradetest.Latest)
+
+	args := &GetMempoolTxsArgs{Encoding: formatting.Hex}
+	reply := GetMempoolTxsReply{}
+	require.NoError(service.GetMempoolTxs(nil, args, &reply))
+	require.Empty(reply.TxIDs)
+	require.Empty(reply.Txs)
+
+	service.vm.ctx.Lock.Lock()
+	wallet := newWallet(t, service.vm, walletConfig{})
+	tx, err := wallet.IssueCreateSubnetTx(
+		&secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		},
+	)
+	require.NoError(err)
+	service.vm.ctx.Lock.Unlock()
+
+	require.NoError(service.vm.Network.IssueTxFromRPC(tx))
+
+	reply = GetMempoolTxsReply{}
+	require.NoError(service.GetMempoolTxs(nil, args, &reply))
+	require.Equal([]ids.ID{tx.ID()}, reply.TxIDs)
+
+	txBytes, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+	require.Equal([]string{txBytes}, reply.Txs)
+	require.Equal(formatting.Hex, reply.Encoding)
+}
+
+func TestServiceGetMempoolTxsPagination(t *testing.T) {
+	service, _ := defaultService(t, upgradetest.Latest)
+
+	// Txs are issued from different keys so that they don't conflict.
+	txIDs := make([]ids.ID, 2)
+	for i := range txIDs {
+		service.vm.ctx.Lock.Lock()
+		wallet := newWallet(t, service.vm, walletConfig{
+			keys: genesistest.DefaultFundedKeys[i : i+1],
+		})
+		tx, err := wallet.IssueCreateSubnetTx(
+			&secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+			},
+		)
+		require.NoError(t, err)
+		service.vm.ctx.Lock.Unlock()
+
+		require.NoError(t, service.vm.Network.IssueTxFromRPC(tx))
+		txIDs[i] = tx.ID()
+	}
+
+	tests := []struct {
+		name          string
+		startTxID     ids.ID
+		limit         avajson.Uint32
+		expectedTxIDs []ids.ID
+		expectedErr   error
+	}{
+		{
+			name:          "all txs",
+			expectedTxIDs: txIDs,
+		},
+		{
+			name:          "first page",
+			limit:         1,
+			expectedTxIDs: txIDs[:1],
+		},
+		{
+			name:          "second page",
+			startTxID:     txIDs[0],
+			limit:         1,
+			expectedTxIDs: txIDs[1:],
+		},
+		{
+			name:          "last page",
+			startTxID:     txIDs[1],
+			expectedTxIDs: []ids.ID{},
+		},
+		{
+			name:        "unknown start tx",
+			startTxID:   ids.GenerateTestID(),
+			expectedErr: errStartTxNotInMempool,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			reply := GetMempoolTxsReply{}
+			err := service.GetMempoolTxs(nil, &GetMempoolTxsArgs{
+				StartTxID: test.startTxID,
+				Limit:     test.limit,
+				Encoding:  formatting.Hex,
+			}, &reply)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.Equal(test.expectedTxIDs, reply.TxIDs)
+			require.Len(reply.Txs, len(test.expectedTxIDs))
+		})
+	}
+}
+
 func TestGetTxStatus(t *testing.T) {
 	require := require.New(t)
 	service, mutableSharedMemory := defaultService(t, upgradetest.Latest)
@@ -1365,6 +1475,43 @@ func TestGetFeeConfig(t *testing.T) {
 	}
 }
 
+func FuzzGetValidatorFeeState(f *testing.F) {
+	f.Fuzz(func(t *testing.T, excess uint64) {
+		require := require.New(t)
+
+		service, _ := defaultService(t, upgradetest.Latest)
+		service.vm.ValidatorFeeConfig = validatorfee.Config{
+			Capacity:                 100,
+			Target:                   50,
+			MinPrice:                 512,
+			ExcessConversionConstant: 1_000,
+		}
+
+		var (
+			expectedExcess = gas.Gas(excess)
+			expectedTime   = time.Now()
+			expectedReply  = GetValidatorFeeStateReply{
+				Excess: expectedExcess,
+				Price: gas.CalculatePrice(
+					service.vm.ValidatorFeeConfig.MinPrice,
+					expectedExcess,
+					service.vm.ValidatorFeeConfig.ExcessConversionConstant,
+				),
+				Time: expectedTime,
+			}
+		)
+
+		service.vm.ctx.Lock.Lock()
+		service.vm.state.SetL1ValidatorExcess(expectedExcess)
+		service.vm.state.SetTimestamp(expectedTime)
+		service.vm.ctx.Lock.Unlock()
+
+		var reply GetValidatorFeeStateReply
+		require.NoError(service.GetValidatorFeeState(nil, nil, &reply))
+		require.Equal(expectedReply, reply)
+	})
+}
+
 func FuzzGetFeeState(f *testing.F) {
 	f.Fuzz(func(t *testing.T, capacity, excess uint64) {
 		require := require.New(t)
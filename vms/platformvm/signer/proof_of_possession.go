@@ -28,17 +28,36 @@ type ProofOfPossession struct {
 	publicKey *bls.PublicKey
 }
 
+// NewProofOfPossession returns the proof of possession of [sk].
+//
+// [sk] must be able to sign. [SignProofOfPossession] should be used if [sk]
+// may have been cleared.
 func NewProofOfPossession(sk bls.Signer) *ProofOfPossession {
 	pk := sk.PublicKey()
 	pkBytes := bls.PublicKeyToCompressedBytes(pk)
 	sig := sk.SignProofOfPossession(pkBytes)
-	sigBytes := bls.SignatureToBytes(sig)
+	return proofOfPossessionFromSignature(pk, pkBytes, sig)
+}
+
+// SignProofOfPossession returns the proof of possession of [sk].
+//
+// Returns [bls.ErrSignerCleared] if [sk] is no longer able to sign.
+func SignProofOfPossession(sk bls.Signer) (*ProofOfPossession, error) {
+	pk := sk.PublicKey()
+	pkBytes := bls.PublicKeyToCompressedBytes(pk)
+	sig, err := bls.SignProofOfPossession(sk, pkBytes)
+	if err != nil {
+		return nil, err
+	}
+	return proofOfPossessionFromSignature(pk, pkBytes, sig), nil
+}
 
+func proofOfPossessionFromSignature(pk *bls.PublicKey, pkBytes []byte, sig *bls.Signature) *ProofOfPossession {
 	pop := &ProofOfPossession{
 		publicKey: pk,
 	}
 	copy(pop.PublicKey[:], pkBytes)
-	copy(pop.ProofOfPossession[:], sigBytes)
+	copy(pop.ProofOfPossession[:], bls.SignatureToBytes(sig))
 	return pop
 }
 
@@ -49,6 +49,22 @@ func TestNewProofOfPossessionDeterministic(t *testing.T) {
 	require.Equal(blsPOP0, blsPOP1)
 }
 
+func TestSignProofOfPossession(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSigner()
+	require.NoError(err)
+
+	pop, err := SignProofOfPossession(sk)
+	require.NoError(err)
+	require.Equal(NewProofOfPossession(sk), pop)
+	require.NoError(pop.Verify())
+
+	sk.Clear()
+	_, err = SignProofOfPossession(sk)
+	require.ErrorIs(err, bls.ErrSignerCleared)
+}
+
 func BenchmarkProofOfPossessionVerify(b *testing.B) {
 	pop, err := newProofOfPossession()
 	require.NoError(b, err)
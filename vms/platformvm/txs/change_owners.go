@@ -0,0 +1,45 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	ErrAmbiguousChange       = errors.New("change outputs can not be distinguished from requested outputs")
+	ErrUnexpectedOutputOwner = errors.New("unexpected output owner")
+)
+
+// ChangeOwners returns the owner of each output that was created to return
+// the excess of the inputs consumed by [tx], in the order of the outputs.
+//
+// The outputs of a BaseTx or an ImportTx include the outputs that were
+// requested by the issuer, so change can not be identified for those
+// transactions and [ErrAmbiguousChange] is returned.
+func ChangeOwners(tx *Tx) ([]*secp256k1fx.OutputOwners, error) {
+	switch tx.Unsigned.(type) {
+	case *BaseTx, *ImportTx:
+		return nil, fmt.Errorf("%w: %T", ErrAmbiguousChange, tx.Unsigned)
+	}
+
+	outs := tx.Unsigned.Outputs()
+	owners := make([]*secp256k1fx.OutputOwners, len(outs))
+	for i, out := range outs {
+		transferOut := out.Out
+		if lockedOut, ok := transferOut.(*stakeable.LockOut); ok {
+			transferOut = lockedOut.TransferableOut
+		}
+		secpOut, ok := transferOut.(*secp256k1fx.TransferOutput)
+		if !ok {
+			return nil, fmt.Errorf("%w: output %d is %T", ErrUnexpectedOutputOwner, i, transferOut)
+		}
+		owners[i] = &secpOut.OutputOwners
+	}
+	return owners, nil
+}
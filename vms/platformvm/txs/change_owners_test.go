@@ -0,0 +1,96 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestChangeOwners(t *testing.T) {
+	var (
+		assetID = ids.GenerateTestID()
+		owner   = secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		}
+		lockedOwner = secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		}
+		baseTx = BaseTx{
+			BaseTx: avax.BaseTx{
+				Outs: []*avax.TransferableOutput{
+					{
+						Asset: avax.Asset{ID: assetID},
+						Out: &secp256k1fx.TransferOutput{
+							Amt:          1,
+							OutputOwners: owner,
+						},
+					},
+					{
+						Asset: avax.Asset{ID: assetID},
+						Out: &stakeable.LockOut{
+							Locktime: 1,
+							TransferableOut: &secp256k1fx.TransferOutput{
+								Amt:          2,
+								OutputOwners: lockedOwner,
+							},
+						},
+					},
+				},
+			},
+		}
+	)
+
+	tests := []struct {
+		name           string
+		unsigned       UnsignedTx
+		expectedOwners []*secp256k1fx.OutputOwners
+		expectedErr    error
+	}{
+		{
+			name: "register L1 validator",
+			unsigned: &RegisterL1ValidatorTx{
+				BaseTx: baseTx,
+			},
+			expectedOwners: []*secp256k1fx.OutputOwners{
+				&owner,
+				&lockedOwner,
+			},
+		},
+		{
+			name: "no outputs",
+			unsigned: &DisableL1ValidatorTx{
+				BaseTx: BaseTx{},
+			},
+			expectedOwners: []*secp256k1fx.OutputOwners{},
+		},
+		{
+			name:        "base tx",
+			unsigned:    &baseTx,
+			expectedErr: ErrAmbiguousChange,
+		},
+		{
+			name: "import tx",
+			unsigned: &ImportTx{
+				BaseTx: baseTx,
+			},
+			expectedErr: ErrAmbiguousChange,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			owners, err := ChangeOwners(&Tx{Unsigned: test.unsigned})
+			require.ErrorIs(t, err, test.expectedErr)
+			require.Equal(t, test.expectedOwners, owners)
+		})
+	}
+}
@@ -6,11 +6,15 @@ package txs
 import (
 	"bytes"
 	"errors"
+	"fmt"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
@@ -29,6 +33,8 @@ var (
 	ErrConvertMustIncludeValidators        = errors.New("conversion must include at least one validator")
 	ErrConvertValidatorsNotSortedAndUnique = errors.New("conversion validators must be sorted and unique")
 	ErrZeroWeight                          = errors.New("validator weight must be non-zero")
+	ErrDuplicateNodeID                     = errors.New("duplicate nodeID")
+	ErrMissingProofOfPossession            = errors.New("missing proof of possession")
 )
 
 type ConvertSubnetToL1Tx struct {
@@ -79,6 +85,79 @@ func (tx *ConvertSubnetToL1Tx) SyntacticVerify(ctx *snow.Context) error {
 	return nil
 }
 
+// ValidateValidatorSet returns an error if [validators] can not be used as the
+// initial validators of a ConvertSubnetToL1Tx. Unlike SyntacticVerify, the
+// validators are not required to be sorted.
+func ValidateValidatorSet(validators []*ConvertSubnetToL1Validator) error {
+	if len(validators) == 0 {
+		return ErrConvertMustIncludeValidators
+	}
+
+	nodeIDs := set.NewSet[ids.NodeID](len(validators))
+	for i, vdr := range validators {
+		if vdr.Signer.PublicKey == [bls.PublicKeyLen]byte{} ||
+			vdr.Signer.ProofOfPossession == [bls.SignatureLen]byte{} {
+			return fmt.Errorf("%w for validator %d", ErrMissingProofOfPossession, i)
+		}
+		if err := vdr.Verify(); err != nil {
+			return fmt.Errorf("invalid validator %d: %w", i, err)
+		}
+
+		// Verify guarantees that the nodeID is well-formed.
+		nodeID, _ := ids.ToNodeID(vdr.NodeID)
+		if nodeIDs.Contains(nodeID) {
+			return fmt.Errorf("%w: %s", ErrDuplicateNodeID, nodeID)
+		}
+		nodeIDs.Add(nodeID)
+	}
+	return nil
+}
+
+// TotalWeight returns the sum of the weights of [validators]. An error is
+// returned if the sum overflows.
+func TotalWeight(validators []*ConvertSubnetToL1Validator) (uint64, error) {
+	var (
+		totalWeight uint64
+		err         error
+	)
+	for i, vdr := range validators {
+		totalWeight, err = math.Add(totalWeight, vdr.Weight)
+		if err != nil {
+			return 0, fmt.Errorf("weight of validator %d: %w", i, err)
+		}
+	}
+	return totalWeight, nil
+}
+
+// ChunkConvertValidators splits [validators] into chunks of at most [maxPerTx]
+// validators while preserving their order. The first chunk can be included in
+// a ConvertSubnetToL1Tx and the remaining validators must be registered after
+// the conversion with RegisterL1ValidatorTxs.
+//
+// [maxPerTx] is typically fee.MaxConvertSubnetToL1Validators, which is the
+// limit enforced when building a ConvertSubnetToL1Tx.
+//
+// If [maxPerTx] is not positive, all of the validators are returned as a single
+// chunk.
+func ChunkConvertValidators(
+	validators []*ConvertSubnetToL1Validator,
+	maxPerTx int,
+) [][]*ConvertSubnetToL1Validator {
+	if len(validators) == 0 {
+		return nil
+	}
+	if maxPerTx <= 0 || len(validators) <= maxPerTx {
+		return [][]*ConvertSubnetToL1Validator{validators}
+	}
+
+	chunks := make([][]*ConvertSubnetToL1Validator, 0, (len(validators)+maxPerTx-1)/maxPerTx)
+	for len(validators) > maxPerTx {
+		chunks = append(chunks, validators[:maxPerTx:maxPerTx])
+		validators = validators[maxPerTx:]
+	}
+	return append(chunks, validators)
+}
+
 func (tx *ConvertSubnetToL1Tx) Visit(visitor Visitor) error {
 	return visitor.ConvertSubnetToL1Tx(tx)
 }
@@ -129,3 +208,31 @@ func (v *ConvertSubnetToL1Validator) Verify() error {
 		},
 	)
 }
+
+// RegisterFromConvertValidator returns the RegisterL1Validator message that
+// registers [v] as a validator of [subnetID] until [expiry].
+//
+// The message is defined in terms of the fields of [v] so that the validators
+// included in a conversion and validators registered after a conversion are
+// described consistently.
+func RegisterFromConvertValidator(
+	subnetID ids.ID,
+	v *ConvertSubnetToL1Validator,
+	expiry uint64,
+) (*message.RegisterL1Validator, error) {
+	nodeID, err := ids.ToNodeID(v.NodeID)
+	if err != nil {
+		return nil, err
+	}
+	return message.NewRegisterL1Validator(
+		subnetID,
+		nodeID,
+		v.Signer.PublicKey,
+		expiry,
+		message.L1ValidatorOwners{
+			RemainingBalanceOwner: v.RemainingBalanceOwner,
+			DeactivationOwner:     v.DeactivationOwner,
+		},
+		v.Weight,
+	)
+}
@@ -6,6 +6,7 @@ package txs
 import (
 	"encoding/hex"
 	"encoding/json"
+	"math"
 	"strings"
 	"testing"
 
@@ -26,6 +27,8 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/ava-labs/avalanchego/vms/types"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
 
 var (
@@ -817,3 +820,239 @@ func TestConvertSubnetToL1TxSyntacticVerify(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateValidatorSet(t *testing.T) {
+	sk, err := bls.NewSigner()
+	require.NoError(t, err)
+
+	newValidator := func(nodeID ids.NodeID) *ConvertSubnetToL1Validator {
+		return &ConvertSubnetToL1Validator{
+			NodeID:  nodeID.Bytes(),
+			Weight:  1,
+			Balance: 1,
+			Signer:  *signer.NewProofOfPossession(sk),
+		}
+	}
+
+	var (
+		nodeID0 = ids.BuildTestNodeID([]byte{0x01})
+		nodeID1 = ids.BuildTestNodeID([]byte{0x02})
+	)
+	tests := []struct {
+		name        string
+		validators  func() []*ConvertSubnetToL1Validator
+		expectedErr error
+	}{
+		{
+			name: "valid unsorted",
+			validators: func() []*ConvertSubnetToL1Validator {
+				return []*ConvertSubnetToL1Validator{
+					newValidator(nodeID1),
+					newValidator(nodeID0),
+				}
+			},
+		},
+		{
+			name: "no validators",
+			validators: func() []*ConvertSubnetToL1Validator {
+				return nil
+			},
+			expectedErr: ErrConvertMustIncludeValidators,
+		},
+		{
+			name: "duplicate nodeID",
+			validators: func() []*ConvertSubnetToL1Validator {
+				return []*ConvertSubnetToL1Validator{
+					newValidator(nodeID0),
+					newValidator(nodeID1),
+					newValidator(nodeID0),
+				}
+			},
+			expectedErr: ErrDuplicateNodeID,
+		},
+		{
+			name: "zero weight",
+			validators: func() []*ConvertSubnetToL1Validator {
+				vdr := newValidator(nodeID0)
+				vdr.Weight = 0
+				return []*ConvertSubnetToL1Validator{vdr}
+			},
+			expectedErr: ErrZeroWeight,
+		},
+		{
+			name: "missing proof of possession",
+			validators: func() []*ConvertSubnetToL1Validator {
+				vdr := newValidator(nodeID0)
+				vdr.Signer = signer.ProofOfPossession{}
+				return []*ConvertSubnetToL1Validator{vdr}
+			},
+			expectedErr: ErrMissingProofOfPossession,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateValidatorSet(test.validators())
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestTotalWeight(t *testing.T) {
+	tests := []struct {
+		name        string
+		weights     []uint64
+		expected    uint64
+		expectedErr error
+	}{
+		{
+			name: "no validators",
+		},
+		{
+			name:     "multiple validators",
+			weights:  []uint64{1, 2, 3},
+			expected: 6,
+		},
+		{
+			name:        "overflow",
+			weights:     []uint64{math.MaxUint64, 1},
+			expectedErr: safemath.ErrOverflow,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			validators := make([]*ConvertSubnetToL1Validator, len(test.weights))
+			for i, weight := range test.weights {
+				validators[i] = &ConvertSubnetToL1Validator{
+					Weight: weight,
+				}
+			}
+
+			totalWeight, err := TotalWeight(validators)
+			require.ErrorIs(t, err, test.expectedErr)
+			require.Equal(t, test.expected, totalWeight)
+		})
+	}
+}
+
+func TestChunkConvertValidators(t *testing.T) {
+	validators := make([]*ConvertSubnetToL1Validator, 5)
+	for i := range validators {
+		validators[i] = &ConvertSubnetToL1Validator{
+			Weight: uint64(i + 1),
+		}
+	}
+
+	tests := []struct {
+		name       string
+		validators []*ConvertSubnetToL1Validator
+		maxPerTx   int
+		expected   [][]*ConvertSubnetToL1Validator
+	}{
+		{
+			name:     "no validators",
+			maxPerTx: 2,
+		},
+		{
+			name:       "below limit",
+			validators: validators,
+			maxPerTx:   10,
+			expected:   [][]*ConvertSubnetToL1Validator{validators},
+		},
+		{
+			name:       "at limit",
+			validators: validators,
+			maxPerTx:   5,
+			expected:   [][]*ConvertSubnetToL1Validator{validators},
+		},
+		{
+			name:       "above limit",
+			validators: validators,
+			maxPerTx:   2,
+			expected: [][]*ConvertSubnetToL1Validator{
+				validators[0:2],
+				validators[2:4],
+				validators[4:5],
+			},
+		},
+		{
+			name:       "one per tx",
+			validators: validators,
+			maxPerTx:   1,
+			expected: [][]*ConvertSubnetToL1Validator{
+				validators[0:1],
+				validators[1:2],
+				validators[2:3],
+				validators[3:4],
+				validators[4:5],
+			},
+		},
+		{
+			name:       "no limit",
+			validators: validators,
+			maxPerTx:   0,
+			expected:   [][]*ConvertSubnetToL1Validator{validators},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			chunks := ChunkConvertValidators(test.validators, test.maxPerTx)
+			require.Equal(test.expected, chunks)
+
+			// Appending to a chunk must not modify the following chunk.
+			if len(chunks) > 1 {
+				firstNext := chunks[1][0]
+				_ = append(chunks[0], &ConvertSubnetToL1Validator{})
+				require.Same(firstNext, chunks[1][0])
+			}
+		})
+	}
+}
+
+func TestRegisterFromConvertValidator(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSigner()
+	require.NoError(err)
+
+	var (
+		subnetID = ids.GenerateTestID()
+		nodeID   = ids.GenerateTestNodeID()
+		expiry   = uint64(1_700_000_000)
+		vdr      = &ConvertSubnetToL1Validator{
+			NodeID:  nodeID.Bytes(),
+			Weight:  12345,
+			Balance: units.Avax,
+			Signer:  *signer.NewProofOfPossession(sk),
+			RemainingBalanceOwner: message.PChainOwner{
+				Threshold: 1,
+				Addresses: []ids.ShortID{ids.GenerateTestShortID()},
+			},
+			DeactivationOwner: message.PChainOwner{
+				Threshold: 1,
+				Addresses: []ids.ShortID{ids.GenerateTestShortID()},
+			},
+		}
+	)
+	registerL1Validator, err := RegisterFromConvertValidator(subnetID, vdr, expiry)
+	require.NoError(err)
+	require.NoError(registerL1Validator.Verify())
+
+	require.Equal(subnetID, registerL1Validator.SubnetID)
+	require.Equal(vdr.NodeID, registerL1Validator.NodeID)
+	require.Equal(vdr.Signer.PublicKey, registerL1Validator.BLSPublicKey)
+	require.Equal(expiry, registerL1Validator.Expiry)
+	require.Equal(vdr.RemainingBalanceOwner, registerL1Validator.RemainingBalanceOwner)
+	require.Equal(vdr.DeactivationOwner, registerL1Validator.DisableOwner)
+	require.Equal(vdr.Weight, registerL1Validator.Weight)
+
+	// The message must be initialized so that it can be sent.
+	parsed, err := message.ParseRegisterL1Validator(registerL1Validator.Bytes())
+	require.NoError(err)
+	require.Equal(registerL1Validator, parsed)
+
+	vdr.NodeID = []byte{1, 2, 3}
+	_, err = RegisterFromConvertValidator(subnetID, vdr, expiry)
+	require.ErrorIs(err, hashing.ErrInvalidHashLen)
+}
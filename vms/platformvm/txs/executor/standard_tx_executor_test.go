@@ -2682,6 +2682,16 @@ func TestStandardExecutorConvertSubnetToL1Tx(t *testing.T) {
 	}
 }
 
+// The message package can't import the executor, so it defines its own copy of
+// the expiry window.
+func TestRegisterL1ValidatorExpiryWindowMatchesMessage(t *testing.T) {
+	require.Equal(
+		t,
+		RegisterL1ValidatorTxExpiryWindow*time.Second,
+		message.RegisterL1ValidatorExpiryWindow,
+	)
+}
+
 func TestStandardExecutorRegisterL1ValidatorTx(t *testing.T) {
 	var (
 		fx = &secp256k1fx.Fx{}
@@ -2820,8 +2830,10 @@ func TestStandardExecutorRegisterL1ValidatorTx(t *testing.T) {
 		nodeID,
 		pop.PublicKey,
 		expiry,
-		remainingBalanceOwner,
-		deactivationOwner,
+		message.L1ValidatorOwners{
+			RemainingBalanceOwner: remainingBalanceOwner,
+			DeactivationOwner:     deactivationOwner,
+		},
 		weight,
 	))
 	unsignedWarp := must[*warp.UnsignedMessage](t)(warp.NewUnsignedMessage(
@@ -2949,8 +2961,10 @@ func TestStandardExecutorRegisterL1ValidatorTx(t *testing.T) {
 							nodeID,
 							pop.PublicKey,
 							expiry,
-							remainingBalanceOwner,
-							deactivationOwner,
+							message.L1ValidatorOwners{
+								RemainingBalanceOwner: remainingBalanceOwner,
+								DeactivationOwner:     deactivationOwner,
+							},
 							0, // weight = 0 is invalid
 						)).Bytes(),
 					)).Bytes(),
@@ -2972,8 +2986,10 @@ func TestStandardExecutorRegisterL1ValidatorTx(t *testing.T) {
 							nodeID,
 							pop.PublicKey,
 							expiry,
-							remainingBalanceOwner,
-							deactivationOwner,
+							message.L1ValidatorOwners{
+								RemainingBalanceOwner: remainingBalanceOwner,
+								DeactivationOwner:     deactivationOwner,
+							},
 							weight,
 						)).Bytes(),
 					)).Bytes(),
@@ -3021,8 +3037,10 @@ func TestStandardExecutorRegisterL1ValidatorTx(t *testing.T) {
 							nodeID,
 							pop.PublicKey,
 							math.MaxUint64, // expiry too far in the future
-							remainingBalanceOwner,
-							deactivationOwner,
+							message.L1ValidatorOwners{
+								RemainingBalanceOwner: remainingBalanceOwner,
+								DeactivationOwner:     deactivationOwner,
+							},
 							weight,
 						)).Bytes(),
 					)).Bytes(),
@@ -3056,8 +3074,10 @@ func TestStandardExecutorRegisterL1ValidatorTx(t *testing.T) {
 							nodeID,
 							initialPoP.PublicKey, // Wrong public key
 							expiry,
-							remainingBalanceOwner,
-							deactivationOwner,
+							message.L1ValidatorOwners{
+								RemainingBalanceOwner: remainingBalanceOwner,
+								DeactivationOwner:     deactivationOwner,
+							},
 							weight,
 						)).Bytes(),
 					)).Bytes(),
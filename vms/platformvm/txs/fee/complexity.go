@@ -13,6 +13,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/components/gas"
@@ -23,6 +24,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/txs/mempool"
 )
 
 // Signature verification costs were conservatively based on benchmarks run on
@@ -92,6 +94,30 @@ const (
 	intrinsicInputDBWrite                      = 1
 	intrinsicOutputDBWrite                     = 1
 	intrinsicConvertSubnetToL1ValidatorDBWrite = 4 // weight diff + pub key diff + subnetID/nodeID + validationID
+
+	// maxConvertSubnetToL1ValidatorBandwidth is the bandwidth of a validator
+	// with an [ids.NodeIDLen] byte nodeID, a proof of possession, and owners
+	// with at most one address each.
+	maxConvertSubnetToL1ValidatorBandwidth = intrinsicConvertSubnetToL1ValidatorBandwidth +
+		ids.NodeIDLen + // nodeID
+		intrinsicPoPBandwidth + // signer
+		2*ids.ShortIDLen // owner addresses
+
+	// convertSubnetToL1TxReservedBandwidth is the bandwidth of a
+	// ConvertSubnetToL1Tx that is reserved for everything other than its
+	// validators, such as inputs, outputs, credentials, the memo, and the
+	// manager address.
+	convertSubnetToL1TxReservedBandwidth = 8 * units.KiB
+
+	// MaxConvertSubnetToL1Validators is the maximum number of validators that
+	// can be included in a single ConvertSubnetToL1Tx without exceeding
+	// [mempool.MaxTxSize]. It assumes that every validator has an
+	// [ids.NodeIDLen] byte nodeID, a proof of possession, and owners with at
+	// most one address each.
+	//
+	// Validators beyond this limit should be registered after the conversion,
+	// see [txs.ChunkConvertValidators].
+	MaxConvertSubnetToL1Validators = (mempool.MaxTxSize - convertSubnetToL1TxReservedBandwidth) / maxConvertSubnetToL1ValidatorBandwidth
 )
 
 var (
@@ -230,6 +256,12 @@ var (
 	errUnsupportedSigner = errors.New("unsupported signer type")
 )
 
+// TxComplexity returns the sum of the complexities of the provided
+// transactions. The complexity of a signed transaction is the complexity of its
+// unsigned transaction, as the credentials are accounted for by the inputs.
+//
+// Because only the unsigned transaction is needed, the complexity can be
+// calculated before the transaction is signed.
 func TxComplexity(txs ...txs.UnsignedTx) (gas.Dimensions, error) {
 	var (
 		c          complexityVisitor
@@ -250,6 +282,16 @@ func TxComplexity(txs ...txs.UnsignedTx) (gas.Dimensions, error) {
 	return complexity, nil
 }
 
+// Complexity returns the complexity of [tx] in each fee dimension. The dynamic
+// fee of [tx] is the sum, over all dimensions, of its complexity multiplied by
+// the weight of the dimension and the gas price.
+//
+// This is defined in the fee package, rather than in txs, because txs can't
+// depend on fee without introducing an import cycle.
+func Complexity(tx *txs.Tx) (gas.Dimensions, error) {
+	return TxComplexity(tx.Unsigned)
+}
+
 // OutputComplexity returns the complexity outputs add to a transaction.
 func OutputComplexity(outs ...*avax.TransferableOutput) (gas.Dimensions, error) {
 	var complexity gas.Dimensions
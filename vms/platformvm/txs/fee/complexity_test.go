@@ -126,6 +126,36 @@ func BenchmarkTxComplexity_Batch(b *testing.B) {
 	}
 }
 
+func TestComplexityRegisterL1ValidatorTx(t *testing.T) {
+	require := require.New(t)
+
+	var txHex string
+	for _, test := range txTests {
+		if test.name == "RegisterL1ValidatorTx" {
+			txHex = test.tx
+		}
+	}
+	require.NotEmpty(txHex)
+
+	txBytes, err := hex.DecodeString(txHex)
+	require.NoError(err)
+
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	require.NoError(err)
+
+	complexity, err := Complexity(tx)
+	require.NoError(err)
+	require.Equal(
+		gas.Dimensions{
+			gas.Bandwidth: 710,
+			gas.DBRead:    29,
+			gas.DBWrite:   8,
+			gas.Compute:   2255,
+		},
+		complexity,
+	)
+}
+
 func TestOutputComplexity(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -0,0 +1,70 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+var (
+	ErrMissingCredentials    = errors.New("missing credentials")
+	ErrUnsupportedCredential = errors.New("unsupported credential")
+)
+
+// SpendingAddresses returns the addresses that signed the inputs consumed by
+// [tx], in the order that they first signed an input. The addresses are
+// recovered from the signatures of the credentials of the inputs, so
+// credentials used to authorize the tx, such as subnet authorization, are not
+// included.
+func SpendingAddresses(tx *Tx) ([]ids.ShortID, error) {
+	// The first credentials of a tx are for the inputs of its BaseTx, which
+	// are followed by the credentials of the imported inputs of an ImportTx.
+	numInputs := 0
+	if utx, ok := tx.Unsigned.(interface{ NumCredentials() int }); ok {
+		numInputs = utx.NumCredentials()
+	}
+	if utx, ok := tx.Unsigned.(*ImportTx); ok {
+		numInputs += len(utx.ImportedInputs)
+	}
+	if len(tx.Creds) < numInputs {
+		return nil, fmt.Errorf("%w: %d inputs but only %d credentials",
+			ErrMissingCredentials,
+			numInputs,
+			len(tx.Creds),
+		)
+	}
+
+	var (
+		unsignedBytes = tx.Unsigned.Bytes()
+		seen          set.Set[ids.ShortID]
+		addrs         []ids.ShortID
+	)
+	for i, credIntf := range tx.Creds[:numInputs] {
+		cred, ok := credIntf.(*secp256k1fx.Credential)
+		if !ok {
+			return nil, fmt.Errorf("%w: %T at index %d", ErrUnsupportedCredential, credIntf, i)
+		}
+
+		for _, sig := range cred.Sigs {
+			pk, err := secp256k1.RecoverPublicKey(unsignedBytes, sig[:])
+			if err != nil {
+				return nil, fmt.Errorf("failed to recover signer of credential %d: %w", i, err)
+			}
+
+			addr := pk.Address()
+			if seen.Contains(addr) {
+				continue
+			}
+			seen.Add(addr)
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs, nil
+}
@@ -0,0 +1,141 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestSpendingAddresses(t *testing.T) {
+	keys := secp256k1.TestKeys()
+	newInput := func() *avax.TransferableInput {
+		return &avax.TransferableInput{
+			UTXOID: avax.UTXOID{
+				TxID: ids.GenerateTestID(),
+			},
+			Asset: avax.Asset{ID: ids.GenerateTestID()},
+			In: &secp256k1fx.TransferInput{
+				Amt: 1,
+				Input: secp256k1fx.Input{
+					SigIndices: []uint32{0},
+				},
+			},
+		}
+	}
+	newBaseTx := func(numInputs int) avax.BaseTx {
+		ins := make([]*avax.TransferableInput, numInputs)
+		for i := range ins {
+			ins[i] = newInput()
+		}
+		return avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: constants.PlatformChainID,
+			Ins:          ins,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		unsignedTx    UnsignedTx
+		signers       [][]*secp256k1.PrivateKey
+		expectedAddrs []ids.ShortID
+		expectedErr   error
+	}{
+		{
+			name: "single key",
+			unsignedTx: &BaseTx{
+				BaseTx: newBaseTx(1),
+			},
+			signers: [][]*secp256k1.PrivateKey{
+				{keys[0]},
+			},
+			expectedAddrs: []ids.ShortID{keys[0].Address()},
+		},
+		{
+			name: "multiple inputs and owners",
+			unsignedTx: &BaseTx{
+				BaseTx: newBaseTx(3),
+			},
+			signers: [][]*secp256k1.PrivateKey{
+				{keys[1]},
+				{keys[0], keys[2]},
+				{keys[1]},
+			},
+			expectedAddrs: []ids.ShortID{
+				keys[1].Address(),
+				keys[0].Address(),
+				keys[2].Address(),
+			},
+		},
+		{
+			name: "imported inputs",
+			unsignedTx: &ImportTx{
+				BaseTx:         BaseTx{BaseTx: newBaseTx(1)},
+				SourceChain:    ids.GenerateTestID(),
+				ImportedInputs: []*avax.TransferableInput{newInput()},
+			},
+			signers: [][]*secp256k1.PrivateKey{
+				{keys[0]},
+				{keys[1]},
+			},
+			expectedAddrs: []ids.ShortID{
+				keys[0].Address(),
+				keys[1].Address(),
+			},
+		},
+		{
+			name: "subnet authorization excluded",
+			unsignedTx: &CreateChainTx{
+				BaseTx:     BaseTx{BaseTx: newBaseTx(1)},
+				SubnetID:   ids.GenerateTestID(),
+				SubnetAuth: &secp256k1fx.Input{SigIndices: []uint32{0}},
+			},
+			signers: [][]*secp256k1.PrivateKey{
+				{keys[0]},
+				{keys[1]},
+			},
+			expectedAddrs: []ids.ShortID{keys[0].Address()},
+		},
+		{
+			name: "missing credentials",
+			unsignedTx: &BaseTx{
+				BaseTx: newBaseTx(2),
+			},
+			signers: [][]*secp256k1.PrivateKey{
+				{keys[0]},
+			},
+			expectedErr: ErrMissingCredentials,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			tx, err := NewSigned(test.unsignedTx, Codec, test.signers)
+			require.NoError(err)
+
+			addrs, err := SpendingAddresses(tx)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedAddrs, addrs)
+		})
+	}
+
+	t.Run("unsupported credential", func(t *testing.T) {
+		tx, err := NewSigned(&BaseTx{BaseTx: newBaseTx(1)}, Codec, nil)
+		require.NoError(t, err)
+		tx.Creds = []verify.Verifiable{nil}
+
+		_, err = SpendingAddresses(tx)
+		require.ErrorIs(t, err, ErrUnsupportedCredential)
+	})
+}
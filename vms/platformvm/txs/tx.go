@@ -11,7 +11,9 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network/p2p/gossip"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/cb58"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
@@ -93,6 +95,17 @@ func (tx *Tx) Bytes() []byte {
 	return tx.bytes
 }
 
+// HexString returns the signed bytes of the tx in the checksummed hex format
+// accepted by platform.issueTx.
+func (tx *Tx) HexString() (string, error) {
+	return formatting.Encode(formatting.Hex, tx.bytes)
+}
+
+// CB58String returns the signed bytes of the tx in the cb58 format.
+func (tx *Tx) CB58String() (string, error) {
+	return cb58.Encode(tx.bytes)
+}
+
 func (tx *Tx) Size() int {
 	return len(tx.bytes)
 }
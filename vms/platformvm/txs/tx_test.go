@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/cb58"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestTxStringEncodings(t *testing.T) {
+	tx := &Tx{
+		Unsigned: &BaseTx{
+			BaseTx: avax.BaseTx{
+				NetworkID:    constants.UnitTestID,
+				BlockchainID: constants.PlatformChainID,
+				Outs: []*avax.TransferableOutput{
+					{
+						Asset: avax.Asset{ID: ids.GenerateTestID()},
+						Out: &secp256k1fx.TransferOutput{
+							Amt: 1,
+							OutputOwners: secp256k1fx.OutputOwners{
+								Threshold: 1,
+								Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+							},
+						},
+					},
+				},
+				Ins:  []*avax.TransferableInput{},
+				Memo: []byte("memo"),
+			},
+		},
+		Creds: []verify.Verifiable{},
+	}
+	require.NoError(t, tx.Initialize(Codec))
+
+	tests := []struct {
+		name   string
+		encode func() (string, error)
+		decode func(string) ([]byte, error)
+	}{
+		{
+			name:   "hex",
+			encode: tx.HexString,
+			decode: func(s string) ([]byte, error) {
+				return formatting.Decode(formatting.Hex, s)
+			},
+		},
+		{
+			name:   "cb58",
+			encode: tx.CB58String,
+			decode: cb58.Decode,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			encoded, err := test.encode()
+			require.NoError(err)
+
+			txBytes, err := test.decode(encoded)
+			require.NoError(err)
+
+			parsedTx, err := Parse(Codec, txBytes)
+			require.NoError(err)
+			require.Equal(tx, parsedTx)
+		})
+	}
+}
@@ -31,6 +31,11 @@ func newContext(
 			state.GetFeeState().Excess,
 			config.DynamicFeeConfig.ExcessConversionConstant,
 		)
+		builderContext.ValidatorFeePrice = gas.CalculatePrice(
+			config.ValidatorFeeConfig.MinPrice,
+			state.GetL1ValidatorExcess(),
+			config.ValidatorFeeConfig.ExcessConversionConstant,
+		)
 	case config.UpgradeConfig.IsApricotPhase3Activated(timestamp):
 		builderContext.StaticFeeConfig = config.StaticFeeConfig
 	default:
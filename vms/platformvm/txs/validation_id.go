@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+)
+
+var ErrNoValidationID = errors.New("tx does not reference a validation ID")
+
+// ValidationID returns the validationID of the L1 validator that [tx] refers
+// to. For a RegisterL1ValidatorTx, the validationID is recomputed from the
+// included RegisterL1Validator message.
+//
+// ErrNoValidationID is returned if [tx] does not reference an L1 validator.
+func ValidationID(tx *Tx) (ids.ID, error) {
+	switch utx := tx.Unsigned.(type) {
+	case *RegisterL1ValidatorTx:
+		msg, err := parseAddressedCallPayload(tx)
+		if err != nil {
+			return ids.Empty, err
+		}
+		registerL1Validator, err := message.ParseRegisterL1Validator(msg)
+		if err != nil {
+			return ids.Empty, err
+		}
+		return registerL1Validator.ValidationID(), nil
+	case *SetL1ValidatorWeightTx:
+		msg, err := parseAddressedCallPayload(tx)
+		if err != nil {
+			return ids.Empty, err
+		}
+		l1ValidatorWeight, err := message.ParseL1ValidatorWeight(msg)
+		if err != nil {
+			return ids.Empty, err
+		}
+		return l1ValidatorWeight.ValidationID, nil
+	case *IncreaseL1ValidatorBalanceTx:
+		return utx.ValidationID, nil
+	case *DisableL1ValidatorTx:
+		return utx.ValidationID, nil
+	default:
+		return ids.Empty, fmt.Errorf("%w: %T", ErrNoValidationID, tx.Unsigned)
+	}
+}
+
+// parseAddressedCallPayload returns the payload of the AddressedCall included
+// in the Warp message of [tx].
+func parseAddressedCallPayload(tx *Tx) ([]byte, error) {
+	warpMessage, err := ExtractWarpMessage(tx)
+	if err != nil {
+		return nil, err
+	}
+	addressedCall, err := payload.ParseAddressedCall(warpMessage.Payload)
+	if err != nil {
+		return nil, err
+	}
+	return addressedCall.Payload, nil
+}
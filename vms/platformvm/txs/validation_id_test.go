@@ -0,0 +1,112 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestValidationID(t *testing.T) {
+	sk, err := bls.NewSigner()
+	require.NoError(t, err)
+	pop := signer.NewProofOfPossession(sk)
+
+	registerL1Validator, err := message.NewRegisterL1Validator(
+		ids.GenerateTestID(),
+		ids.GenerateTestNodeID(),
+		pop.PublicKey,
+		1,
+		message.L1ValidatorOwners{},
+		1,
+	)
+	require.NoError(t, err)
+	validationID := registerL1Validator.ValidationID()
+
+	l1ValidatorWeight, err := message.NewL1ValidatorWeight(
+		validationID,
+		1,
+		2,
+	)
+	require.NoError(t, err)
+
+	baseTx := BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: constants.PlatformChainID,
+			Ins:          []*avax.TransferableInput{},
+			Outs:         []*avax.TransferableOutput{},
+		},
+	}
+	tests := []struct {
+		name        string
+		unsignedTx  UnsignedTx
+		expectedErr error
+	}{
+		{
+			name: "RegisterL1ValidatorTx",
+			unsignedTx: &RegisterL1ValidatorTx{
+				BaseTx:            baseTx,
+				ProofOfPossession: pop.ProofOfPossession,
+				Message:           newTestWarpMessage(t, sk, registerL1Validator.Bytes()).Bytes(),
+			},
+		},
+		{
+			name: "SetL1ValidatorWeightTx",
+			unsignedTx: &SetL1ValidatorWeightTx{
+				BaseTx:  baseTx,
+				Message: newTestWarpMessage(t, sk, l1ValidatorWeight.Bytes()).Bytes(),
+			},
+		},
+		{
+			name: "IncreaseL1ValidatorBalanceTx",
+			unsignedTx: &IncreaseL1ValidatorBalanceTx{
+				BaseTx:       baseTx,
+				ValidationID: validationID,
+			},
+		},
+		{
+			name: "DisableL1ValidatorTx",
+			unsignedTx: &DisableL1ValidatorTx{
+				BaseTx:       baseTx,
+				ValidationID: validationID,
+				DisableAuth:  &secp256k1fx.Input{},
+			},
+		},
+		{
+			name: "BaseTx",
+			unsignedTx: &BaseTx{
+				BaseTx: baseTx.BaseTx,
+			},
+			expectedErr: ErrNoValidationID,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			tx, err := NewSigned(test.unsignedTx, Codec, nil)
+			require.NoError(err)
+
+			parsedTx, err := Parse(Codec, tx.Bytes())
+			require.NoError(err)
+
+			parsedValidationID, err := ValidationID(parsedTx)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.Equal(validationID, parsedValidationID)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+var ErrNoWarpMessage = errors.New("tx does not contain a warp message")
+
+// ExtractWarpMessage returns the signed Warp message included in [tx]. Issued
+// txs can be decoded with Parse(Codec, txBytes) prior to calling this
+// function.
+//
+// ErrNoWarpMessage is returned if [tx] is not a RegisterL1ValidatorTx or a
+// SetL1ValidatorWeightTx.
+func ExtractWarpMessage(tx *Tx) (*warp.Message, error) {
+	var msgBytes []byte
+	switch utx := tx.Unsigned.(type) {
+	case *RegisterL1ValidatorTx:
+		msgBytes = utx.Message
+	case *SetL1ValidatorWeightTx:
+		msgBytes = utx.Message
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrNoWarpMessage, tx.Unsigned)
+	}
+	return warp.ParseMessage(msgBytes)
+}
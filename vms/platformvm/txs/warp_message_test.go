@@ -0,0 +1,132 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+)
+
+// newTestWarpMessage returns a Warp message containing an AddressedCall of
+// [addressedCallPayload] signed by [sk].
+func newTestWarpMessage(
+	t *testing.T,
+	sk bls.Signer,
+	addressedCallPayload []byte,
+) *warp.Message {
+	addressedCall, err := payload.NewAddressedCall(nil, addressedCallPayload)
+	require.NoError(t, err)
+
+	unsignedWarp, err := warp.NewUnsignedMessage(
+		constants.UnitTestID,
+		ids.GenerateTestID(),
+		addressedCall.Bytes(),
+	)
+	require.NoError(t, err)
+
+	warpMessage, err := warp.NewMessage(
+		unsignedWarp,
+		&warp.BitSetSignature{
+			Signers: set.NewBits(0).Bytes(),
+			Signature: ([bls.SignatureLen]byte)(
+				bls.SignatureToBytes(sk.Sign(unsignedWarp.Bytes())),
+			),
+		},
+	)
+	require.NoError(t, err)
+	return warpMessage
+}
+
+func TestExtractWarpMessage(t *testing.T) {
+	sk, err := bls.NewSigner()
+	require.NoError(t, err)
+	pop := signer.NewProofOfPossession(sk)
+
+	registerL1Validator, err := message.NewRegisterL1Validator(
+		ids.GenerateTestID(),
+		ids.GenerateTestNodeID(),
+		pop.PublicKey,
+		1,
+		message.L1ValidatorOwners{},
+		1,
+	)
+	require.NoError(t, err)
+
+	warpMessage := newTestWarpMessage(t, sk, registerL1Validator.Bytes())
+
+	baseTx := BaseTx{
+		BaseTx: avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: constants.PlatformChainID,
+			Ins:          []*avax.TransferableInput{},
+			Outs:         []*avax.TransferableOutput{},
+		},
+	}
+	tests := []struct {
+		name        string
+		unsignedTx  UnsignedTx
+		expectedErr error
+	}{
+		{
+			name: "RegisterL1ValidatorTx",
+			unsignedTx: &RegisterL1ValidatorTx{
+				BaseTx:            baseTx,
+				ProofOfPossession: pop.ProofOfPossession,
+				Message:           warpMessage.Bytes(),
+			},
+		},
+		{
+			name: "SetL1ValidatorWeightTx",
+			unsignedTx: &SetL1ValidatorWeightTx{
+				BaseTx:  baseTx,
+				Message: warpMessage.Bytes(),
+			},
+		},
+		{
+			name: "IncreaseL1ValidatorBalanceTx",
+			unsignedTx: &IncreaseL1ValidatorBalanceTx{
+				BaseTx:       baseTx,
+				ValidationID: registerL1Validator.ValidationID(),
+			},
+			expectedErr: ErrNoWarpMessage,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			tx, err := NewSigned(test.unsignedTx, Codec, nil)
+			require.NoError(err)
+
+			parsedTx, err := Parse(Codec, tx.Bytes())
+			require.NoError(err)
+
+			parsedWarpMessage, err := ExtractWarpMessage(parsedTx)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.Equal(warpMessage.Bytes(), parsedWarpMessage.Bytes())
+
+			// Indexers are able to reconstruct the validationID from the
+			// on-chain data.
+			parsedAddressedCall, err := payload.ParseAddressedCall(parsedWarpMessage.Payload)
+			require.NoError(err)
+			parsedRegisterL1Validator, err := message.ParseRegisterL1Validator(parsedAddressedCall.Payload)
+			require.NoError(err)
+			require.Equal(registerL1Validator.ValidationID(), parsedRegisterL1Validator.ValidationID())
+		})
+	}
+}
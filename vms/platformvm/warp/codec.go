@@ -4,16 +4,43 @@
 package warp
 
 import (
+	"encoding/binary"
 	"errors"
+	"fmt"
 	"math"
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
 )
 
-const CodecVersion = 0
+const (
+	CodecVersion = 0
 
-var Codec codec.Manager
+	// MaxMessageSize is the default maximum size of a serialized Warp message
+	// that is built, or parsed with [ParseMessageWithMaxSize]. It matches the
+	// maximum size of a message sent over the network.
+	//
+	// [Codec] does not enforce this limit, so that the parsing of messages
+	// included in accepted transactions is not modified.
+	MaxMessageSize = constants.DefaultMaxMessageSize
+
+	// payloadLenOffset is the offset of the payload length header in both a
+	// serialized UnsignedMessage and a serialized Message.
+	payloadLenOffset = codec.VersionSize + wrappers.IntLen + ids.IDLen
+
+	// BitSetSignatureTypeID is the type ID of [BitSetSignature] in [Codec].
+	BitSetSignatureTypeID uint32 = 0
+)
+
+var (
+	ErrMessageTooLarge = payload.ErrMessageTooLarge
+
+	Codec codec.Manager
+)
 
 func init() {
 	Codec = codec.NewManager(math.MaxInt)
@@ -27,3 +54,21 @@ func init() {
 		panic(err)
 	}
 }
+
+// verifySize verifies that [b] and the payload length it claims to contain do
+// not exceed [maxSize]. This allows oversized messages to be rejected before
+// they are parsed.
+func verifySize(b []byte, maxSize int) error {
+	if len(b) > maxSize {
+		return fmt.Errorf("%w: %d > %d", ErrMessageTooLarge, len(b), maxSize)
+	}
+	if len(b) < payloadLenOffset+wrappers.IntLen {
+		// The codec will report the malformed message.
+		return nil
+	}
+	payloadLen := binary.BigEndian.Uint32(b[payloadLenOffset:])
+	if uint64(payloadLen) > uint64(maxSize) {
+		return fmt.Errorf("%w: payload length %d > %d", ErrMessageTooLarge, payloadLen, maxSize)
+	}
+	return nil
+}
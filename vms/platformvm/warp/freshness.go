@@ -0,0 +1,50 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+var ErrStaleSignature = errors.New("signature is stale")
+
+// HeightClient reports the current P-chain height. It is implemented by the
+// platformvm client.
+type HeightClient interface {
+	// GetHeight returns the current height of the P-chain.
+	GetHeight(ctx context.Context, options ...rpc.Option) (uint64, error)
+}
+
+// VerifyFreshness verifies that the signature of a message, which was
+// verified against the validator set at [pChainHeight], is at most
+// [maxAgeBlocks] blocks older than the current P-chain height reported by
+// [client].
+//
+// Warp messages do not include the height of the validator set that signed
+// them, so [pChainHeight] must be provided by the caller, typically as the
+// height that was used to aggregate or verify the signature.
+func VerifyFreshness(
+	ctx context.Context,
+	client HeightClient,
+	pChainHeight uint64,
+	maxAgeBlocks uint64,
+) error {
+	currentHeight, err := client.GetHeight(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch P-chain height: %w", err)
+	}
+	if currentHeight > pChainHeight && currentHeight-pChainHeight > maxAgeBlocks {
+		return fmt.Errorf("%w: signed at height %d but the current height is %d, exceeding the maximum age of %d blocks",
+			ErrStaleSignature,
+			pChainHeight,
+			currentHeight,
+			maxAgeBlocks,
+		)
+	}
+	return nil
+}
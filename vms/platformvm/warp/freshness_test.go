@@ -0,0 +1,76 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+type heightClient struct {
+	height uint64
+	err    error
+}
+
+func (c *heightClient) GetHeight(context.Context, ...rpc.Option) (uint64, error) {
+	return c.height, c.err
+}
+
+func TestVerifyFreshness(t *testing.T) {
+	tests := []struct {
+		name         string
+		client       *heightClient
+		pChainHeight uint64
+		maxAgeBlocks uint64
+		expectedErr  error
+	}{
+		{
+			name: "fresh",
+			client: &heightClient{
+				height: 110,
+			},
+			pChainHeight: 100,
+			maxAgeBlocks: 10,
+		},
+		{
+			name: "stale",
+			client: &heightClient{
+				height: 111,
+			},
+			pChainHeight: 100,
+			maxAgeBlocks: 10,
+			expectedErr:  ErrStaleSignature,
+		},
+		{
+			name: "height ahead of node",
+			client: &heightClient{
+				height: 90,
+			},
+			pChainHeight: 100,
+			maxAgeBlocks: 0,
+		},
+		{
+			name: "client error",
+			client: &heightClient{
+				err: errTest,
+			},
+			expectedErr: errTest,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifyFreshness(
+				context.Background(),
+				test.client,
+				test.pChainHeight,
+				test.maxAgeBlocks,
+			)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -3,7 +3,18 @@
 
 package warp
 
-import "fmt"
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/codec"
+)
+
+var (
+	ErrInvalidBase64 = errors.New("invalid base64 encoding")
+	ErrParseMessage  = errors.New("failed to parse message")
+)
 
 // Message defines the standard format for a Warp message.
 type Message struct {
@@ -22,21 +33,72 @@ func NewMessage(
 		UnsignedMessage: *unsignedMsg,
 		Signature:       signature,
 	}
-	return msg, msg.Initialize()
+	if err := msg.Initialize(); err != nil {
+		return nil, err
+	}
+	if size := len(msg.bytes); size > MaxMessageSize {
+		return nil, fmt.Errorf("%w: %d > %d", ErrMessageTooLarge, size, MaxMessageSize)
+	}
+	return msg, nil
+}
+
+// NewMessageUnsigned creates a new *Message for [unsignedMsg] with an empty
+// BitSetSignature. This is the standard format of a message that has not yet
+// been signed.
+func NewMessageUnsigned(unsignedMsg *UnsignedMessage) (*Message, error) {
+	return NewMessage(unsignedMsg, &BitSetSignature{
+		Signers: []byte{},
+	})
 }
 
 // ParseMessage converts a slice of bytes into an initialized *Message.
+//
+// If the signature has an unknown type ID, ErrUnsupportedSignature is
+// returned.
 func ParseMessage(b []byte) (*Message, error) {
 	msg := &Message{
 		bytes: b,
 	}
 	_, err := Codec.Unmarshal(b, msg)
+	if errors.Is(err, codec.ErrUnknownTypeID) {
+		// The signature is the only interface in a message, so an unknown type
+		// ID means that the signature is in an unsupported format.
+		return nil, fmt.Errorf("%w: %w", ErrUnsupportedSignature, err)
+	}
 	if err != nil {
 		return nil, err
 	}
 	return msg, msg.UnsignedMessage.Initialize()
 }
 
+// ParseMessageBase64 decodes [s] from standard base64 and converts the result
+// into an initialized *Message.
+//
+// Returns [ErrInvalidBase64] if [s] is not valid base64 and [ErrParseMessage]
+// if the decoded bytes are not a valid message.
+func ParseMessageBase64(s string) (*Message, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBase64, err)
+	}
+	msg, err := ParseMessageWithMaxSize(b, MaxMessageSize)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrParseMessage, err)
+	}
+	return msg, nil
+}
+
+// ParseMessageWithMaxSize converts a slice of bytes into an initialized
+// *Message. If [b] is larger than [maxSize], ErrMessageTooLarge is returned.
+// If the signature has an unknown type ID, ErrUnsupportedSignature is
+// returned.
+func ParseMessageWithMaxSize(b []byte, maxSize int) (*Message, error) {
+	if err := verifySize(b, maxSize); err != nil {
+		return nil, err
+	}
+	return ParseMessage(b)
+}
+
 // Initialize recalculates the result of Bytes(). It does not call Initialize()
 // on the UnsignedMessage.
 func (m *Message) Initialize() error {
@@ -52,6 +114,31 @@ func (m *Message) Bytes() []byte {
 	return m.bytes
 }
 
+// Base64 returns the standard base64 encoding of Bytes().
+func (m *Message) Base64() string {
+	return base64.StdEncoding.EncodeToString(m.bytes)
+}
+
 func (m *Message) String() string {
 	return fmt.Sprintf("WarpMessage(%s, %s)", &m.UnsignedMessage, m.Signature)
 }
+
+// VerifyNetworkID returns [ErrWrongNetworkID] if [msg] was not created for the
+// network with ID [expected]. This should be checked before paying to issue
+// [msg], as a message for another network will never be accepted.
+func VerifyNetworkID(msg *Message, expected uint32) error {
+	if msg.NetworkID != expected {
+		return fmt.Errorf("%w: expected %d but got %d",
+			ErrWrongNetworkID,
+			expected,
+			msg.NetworkID,
+		)
+	}
+	return nil
+}
+
+// IsSigned returns true if at least one validator has signed the message.
+func (m *Message) IsSigned() bool {
+	numSigners, err := m.Signature.NumSigners()
+	return err == nil && numSigners > 0
+}
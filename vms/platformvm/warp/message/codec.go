@@ -9,24 +9,72 @@ import (
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/utils/constants"
 )
 
-const CodecVersion = 0
+const (
+	CodecVersion = 0
 
-var Codec codec.Manager
+	// MaxMessageSize is the maximum size of a serialized payload that is built
+	// with [Initialize]. It matches the maximum size of a message sent over the
+	// network.
+	//
+	// [Codec] does not enforce this limit, so that the parsing of payloads
+	// included in accepted transactions is not modified.
+	MaxMessageSize = constants.DefaultMaxMessageSize
+)
+
+// Type IDs of the messages registered in [Codec]. A serialized message starts
+// with the codec version followed by its type ID.
+const (
+	SubnetToL1ConversionTypeID uint32 = iota
+	RegisterL1ValidatorTypeID
+	L1ValidatorRegistrationTypeID
+	L1ValidatorWeightTypeID
+)
+
+var (
+	Codec codec.Manager
+
+	// registeredTypes are the types registered in [Codec], in the order of
+	// their type IDs.
+	registeredTypes = []Payload{
+		&SubnetToL1Conversion{},
+		&RegisterL1Validator{},
+		&L1ValidatorRegistration{},
+		&L1ValidatorWeight{},
+	}
+)
 
 func init() {
 	Codec = codec.NewManager(math.MaxInt)
 	lc := linearcodec.NewDefault()
 
-	err := errors.Join(
-		lc.RegisterType(&SubnetToL1Conversion{}),
-		lc.RegisterType(&RegisterL1Validator{}),
-		lc.RegisterType(&L1ValidatorRegistration{}),
-		lc.RegisterType(&L1ValidatorWeight{}),
-		Codec.RegisterCodec(CodecVersion, lc),
-	)
-	if err != nil {
+	errs := make([]error, 0, len(registeredTypes)+1)
+	for _, t := range registeredTypes {
+		errs = append(errs, lc.RegisterType(t))
+	}
+	errs = append(errs, Codec.RegisterCodec(CodecVersion, lc))
+	if err := errors.Join(errs...); err != nil {
 		panic(err)
 	}
 }
+
+// RegisteredTypes returns the sorted type IDs of the messages that can be
+// parsed by [Parse].
+func RegisteredTypes() []uint32 {
+	typeIDs := make([]uint32, len(registeredTypes))
+	for i := range registeredTypes {
+		typeIDs[i] = uint32(i)
+	}
+	return typeIDs
+}
+
+// TypeName returns the name of the message registered with [typeID]. False is
+// returned if no message is registered with [typeID].
+func TypeName(typeID uint32) (string, bool) {
+	if typeID >= uint32(len(registeredTypes)) {
+		return "", false
+	}
+	return typeName(registeredTypes[typeID]), true
+}
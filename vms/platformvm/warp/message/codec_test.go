@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	warppayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+)
+
+func TestRegisteredTypes(t *testing.T) {
+	require := require.New(t)
+
+	typeIDs := RegisteredTypes()
+	require.IsIncreasing(typeIDs)
+
+	builtins := []Payload{
+		&SubnetToL1Conversion{},
+		&RegisterL1Validator{},
+		&L1ValidatorRegistration{},
+		&L1ValidatorWeight{},
+	}
+	require.Len(typeIDs, len(builtins))
+	for _, builtin := range builtins {
+		bytes, err := Codec.Marshal(CodecVersion, &builtin)
+		require.NoError(err)
+
+		typeID := binary.BigEndian.Uint32(bytes[wrappers.ShortLen:])
+		require.Contains(typeIDs, typeID)
+	}
+}
+
+func TestTypeIDs(t *testing.T) {
+	tests := []struct {
+		payload        Payload
+		expectedTypeID uint32
+	}{
+		{
+			payload:        &SubnetToL1Conversion{},
+			expectedTypeID: SubnetToL1ConversionTypeID,
+		},
+		{
+			payload:        &RegisterL1Validator{},
+			expectedTypeID: RegisterL1ValidatorTypeID,
+		},
+		{
+			payload:        &L1ValidatorRegistration{},
+			expectedTypeID: L1ValidatorRegistrationTypeID,
+		},
+		{
+			payload:        &L1ValidatorWeight{},
+			expectedTypeID: L1ValidatorWeightTypeID,
+		},
+	}
+	for _, test := range tests {
+		t.Run(typeName(test.payload), func(t *testing.T) {
+			require := require.New(t)
+
+			bytes, err := Codec.Marshal(CodecVersion, &test.payload)
+			require.NoError(err)
+
+			typeID := binary.BigEndian.Uint32(bytes[wrappers.ShortLen:])
+			require.Equal(test.expectedTypeID, typeID)
+		})
+	}
+}
+
+func TestTypeName(t *testing.T) {
+	tests := []struct {
+		payload      Payload
+		expectedName string
+	}{
+		{
+			payload:      &SubnetToL1Conversion{},
+			expectedName: "SubnetToL1Conversion",
+		},
+		{
+			payload:      &RegisterL1Validator{},
+			expectedName: "RegisterL1Validator",
+		},
+		{
+			payload:      &L1ValidatorRegistration{},
+			expectedName: "L1ValidatorRegistration",
+		},
+		{
+			payload:      &L1ValidatorWeight{},
+			expectedName: "L1ValidatorWeight",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.expectedName, func(t *testing.T) {
+			require := require.New(t)
+
+			bytes, err := Codec.Marshal(CodecVersion, &test.payload)
+			require.NoError(err)
+
+			addressedCall, err := warppayload.NewAddressedCall(nil, bytes)
+			require.NoError(err)
+
+			typeID, err := warppayload.PeekType(addressedCall)
+			require.NoError(err)
+
+			name, ok := TypeName(typeID)
+			require.True(ok)
+			require.Equal(test.expectedName, name)
+		})
+	}
+
+	_, ok := TypeName(uint32(len(RegisteredTypes())))
+	require.False(t, ok)
+}
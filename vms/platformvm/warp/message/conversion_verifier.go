@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+var ErrConversionDataMismatch = errors.New("conversion data does not match conversionID")
+
+// VerifyAgainstConversion verifies that [msg] was signed by at least [quorum]
+// of the initial validator set of an L1, without querying the P-chain.
+//
+// The validator set is provided as the full [data] of the conversion, which
+// must hash to the ID committed to by [conversion]. The signers are then
+// resolved against the canonical ordering of the validators in [data].
+//
+// Only [warp.BitSetSignature]s are supported.
+func VerifyAgainstConversion(
+	msg *warp.Message,
+	conversion *SubnetToL1Conversion,
+	data SubnetToL1ConversionData,
+	quorum warp.Quorum,
+) error {
+	conversionID, err := SubnetToL1ConversionID(data)
+	if err != nil {
+		return fmt.Errorf("failed to calculate conversionID: %w", err)
+	}
+	if conversionID != conversion.ID {
+		return fmt.Errorf("%w: data hashes to %s but expected %s",
+			ErrConversionDataMismatch,
+			conversionID,
+			conversion.ID,
+		)
+	}
+
+	vdrSet := make(map[ids.NodeID]*validators.GetValidatorOutput, len(data.Validators))
+	for i, vdr := range data.Validators {
+		nodeID, err := ids.ToNodeID(vdr.NodeID)
+		if err != nil {
+			return fmt.Errorf("invalid nodeID of validator %d: %w", i, err)
+		}
+		pk, err := bls.PublicKeyFromCompressedBytes(vdr.BLSPublicKey[:])
+		if err != nil {
+			return fmt.Errorf("invalid public key of validator %d: %w", i, err)
+		}
+		vdrSet[nodeID] = &validators.GetValidatorOutput{
+			NodeID:    nodeID,
+			PublicKey: pk,
+			Weight:    vdr.Weight,
+		}
+	}
+
+	vdrs, totalWeight, err := warp.FlattenValidatorSet(vdrSet)
+	if err != nil {
+		return err
+	}
+
+	signature, ok := msg.Signature.(*warp.BitSetSignature)
+	if !ok {
+		return fmt.Errorf("%w: %T", warp.ErrUnsupportedSignature, msg.Signature)
+	}
+	return signature.VerifyValidators(&msg.UnsignedMessage, vdrs, totalWeight, quorum)
+}
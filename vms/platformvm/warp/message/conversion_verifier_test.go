@@ -0,0 +1,154 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+)
+
+func TestVerifyAgainstConversion(t *testing.T) {
+	// The signers are sorted into their canonical ordering so that the index
+	// of a signer matches its index in the validator set.
+	sks := make([]bls.Signer, 3)
+	for i := range sks {
+		sk, err := bls.NewSigner()
+		require.NoError(t, err)
+		sks[i] = sk
+	}
+	slices.SortFunc(sks, func(a, b bls.Signer) int {
+		return bytes.Compare(
+			bls.PublicKeyToUncompressedBytes(a.PublicKey()),
+			bls.PublicKeyToUncompressedBytes(b.PublicKey()),
+		)
+	})
+
+	data := SubnetToL1ConversionData{
+		SubnetID:       ids.GenerateTestID(),
+		ManagerChainID: ids.GenerateTestID(),
+		ManagerAddress: []byte{0x01},
+	}
+	for i, sk := range sks {
+		data.Validators = append(data.Validators, SubnetToL1ConversionValidatorData{
+			NodeID:       ids.BuildTestNodeID([]byte{byte(i + 1)}).Bytes(),
+			BLSPublicKey: [bls.PublicKeyLen]byte(bls.PublicKeyToCompressedBytes(sk.PublicKey())),
+			Weight:       1,
+		})
+	}
+	conversionID, err := SubnetToL1ConversionID(data)
+	require.NoError(t, err)
+	conversion, err := NewSubnetToL1Conversion(conversionID)
+	require.NoError(t, err)
+
+	unsignedMsg, err := warp.NewUnsignedMessage(
+		constants.UnitTestID,
+		ids.GenerateTestID(),
+		[]byte("payload"),
+	)
+	require.NoError(t, err)
+
+	newMessage := func(t *testing.T, signers map[int]bls.Signer) *warp.Message {
+		signerIndices := set.NewBits()
+		sigs := make([]*bls.Signature, 0, len(signers))
+		for i, sk := range signers {
+			signerIndices.Add(i)
+			sigs = append(sigs, sk.Sign(unsignedMsg.Bytes()))
+		}
+		aggSig, err := bls.AggregateSignatures(sigs)
+		require.NoError(t, err)
+
+		msg, err := warp.NewMessage(
+			unsignedMsg,
+			&warp.BitSetSignature{
+				Signers:   signerIndices.Bytes(),
+				Signature: [bls.SignatureLen]byte(bls.SignatureToBytes(aggSig)),
+			},
+		)
+		require.NoError(t, err)
+		return msg
+	}
+
+	otherSK, err := bls.NewSigner()
+	require.NoError(t, err)
+
+	modifiedData := data
+	modifiedData.Validators = slices.Clone(data.Validators)
+	modifiedData.Validators[0].Weight = 10
+
+	tests := []struct {
+		name        string
+		signers     map[int]bls.Signer
+		data        SubnetToL1ConversionData
+		expectedErr error
+	}{
+		{
+			name: "all validators signed",
+			signers: map[int]bls.Signer{
+				0: sks[0],
+				1: sks[1],
+				2: sks[2],
+			},
+			data: data,
+		},
+		{
+			name: "data does not match conversion",
+			signers: map[int]bls.Signer{
+				0: sks[0],
+				1: sks[1],
+				2: sks[2],
+			},
+			data:        modifiedData,
+			expectedErr: ErrConversionDataMismatch,
+		},
+		{
+			name: "insufficient weight",
+			signers: map[int]bls.Signer{
+				0: sks[0],
+				2: sks[2],
+			},
+			data:        data,
+			expectedErr: warp.ErrInsufficientWeight,
+		},
+		{
+			name: "signer not in the validator set",
+			signers: map[int]bls.Signer{
+				0: sks[0],
+				1: otherSK,
+				2: sks[2],
+			},
+			data:        data,
+			expectedErr: warp.ErrInvalidSignature,
+		},
+		{
+			name: "unknown signer index",
+			signers: map[int]bls.Signer{
+				0: sks[0],
+				1: sks[1],
+				3: otherSK,
+			},
+			data:        data,
+			expectedErr: warp.ErrUnknownValidator,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifyAgainstConversion(
+				newMessage(t, test.signers),
+				conversion,
+				test.data,
+				warp.QuorumDefault,
+			)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
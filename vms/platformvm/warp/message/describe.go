@@ -0,0 +1,122 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warppayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/avalanchego/vms/types"
+)
+
+var ErrNotAddressedCall = errors.New("payload is not an AddressedCall")
+
+// Summary is a human readable representation of a signed Warp message.
+type Summary struct {
+	MessageID     ids.ID `json:"messageID"`
+	NetworkID     uint32 `json:"networkID"`
+	SourceChainID ids.ID `json:"sourceChainID"`
+	NumSigners    int    `json:"numSigners"`
+	PayloadType   string `json:"payloadType"`
+
+	// Populated if the payload is a Hash.
+	Hash *ids.ID `json:"hash,omitempty"`
+
+	// Populated if the payload is an AddressedCall.
+	SourceAddress types.JSONByteSlice `json:"sourceAddress,omitempty"`
+	// Populated if the payload is an AddressedCall whose payload is a known
+	// P-chain message.
+	MessageType string  `json:"messageType,omitempty"`
+	Message     Payload `json:"message,omitempty"`
+	// Populated if the payload is an AddressedCall whose payload is not a
+	// known P-chain message.
+	RawMessage types.JSONByteSlice `json:"rawMessage,omitempty"`
+}
+
+// Summarize parses the payload of [msg] and returns a summary of its key
+// fields.
+func Summarize(msg *warp.Message) (*Summary, error) {
+	numSigners, err := msg.Signature.NumSigners()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := warppayload.Parse(msg.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse payload: %w", err)
+	}
+
+	summary := &Summary{
+		MessageID:     msg.ID(),
+		NetworkID:     msg.NetworkID,
+		SourceChainID: msg.SourceChainID,
+		NumSigners:    numSigners,
+		PayloadType:   typeName(p),
+	}
+	switch p := p.(type) {
+	case *warppayload.Hash:
+		summary.Hash = &p.Hash
+	case *warppayload.AddressedCall:
+		summary.SourceAddress = p.SourceAddress
+		if m, err := Parse(p.Payload); err == nil {
+			summary.MessageType = typeName(m)
+			summary.Message = m
+		} else {
+			summary.RawMessage = p.Payload
+		}
+	}
+	return summary, nil
+}
+
+// Describe returns a human readable description of [msg]. This is intended to
+// be used when inspecting or logging messages.
+func Describe(msg *warp.Message) (string, error) {
+	summary, err := Summarize(msg)
+	if err != nil {
+		return "", err
+	}
+	summaryJSON, err := json.MarshalIndent(summary, "", "\t")
+	if err != nil {
+		return "", err
+	}
+	return string(summaryJSON), nil
+}
+
+// UnwrapAddressedCall parses the AddressedCall carried by [msg] and the P-chain
+// message carried by the AddressedCall. This is the inverse of wrapping a
+// P-chain message in an AddressedCall and a Warp message.
+//
+// Returns [ErrNotAddressedCall] if the payload of [msg] is not an
+// AddressedCall.
+func UnwrapAddressedCall(msg *warp.Message) (*warppayload.AddressedCall, Payload, error) {
+	p, err := warppayload.Parse(msg.Payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse payload: %w", err)
+	}
+
+	addressedCall, ok := p.(*warppayload.AddressedCall)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %T", ErrNotAddressedCall, p)
+	}
+
+	m, err := Parse(addressedCall.Payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse AddressedCall payload: %w", err)
+	}
+	return addressedCall, m, nil
+}
+
+// typeName returns the name of the type pointed to by [v].
+func typeName(v any) string {
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
@@ -0,0 +1,175 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	warppayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+)
+
+func newSignedTestMessage(t *testing.T, p []byte, signers set.Bits) *warp.Message {
+	unsignedMsg, err := warp.NewUnsignedMessage(
+		constants.UnitTestID,
+		ids.GenerateTestID(),
+		p,
+	)
+	require.NoError(t, err)
+
+	msg, err := warp.NewMessage(
+		unsignedMsg,
+		&warp.BitSetSignature{
+			Signers: signers.Bytes(),
+		},
+	)
+	require.NoError(t, err)
+	return msg
+}
+
+func TestSummarize(t *testing.T) {
+	l1ValidatorWeight, err := NewL1ValidatorWeight(ids.GenerateTestID(), 1, 2)
+	require.NoError(t, err)
+
+	knownAddressedCall, err := warppayload.NewAddressedCall(nil, l1ValidatorWeight.Bytes())
+	require.NoError(t, err)
+
+	unknownAddressedCall, err := warppayload.NewAddressedCall([]byte{1, 2, 3}, []byte{4, 5, 6})
+	require.NoError(t, err)
+
+	hashID := ids.GenerateTestID()
+	hash, err := warppayload.NewHash(hashID)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		payload  []byte
+		signers  set.Bits
+		expected func(msg *warp.Message) *Summary
+	}{
+		{
+			name:    "known addressed call",
+			payload: knownAddressedCall.Bytes(),
+			signers: set.NewBits(0, 2),
+			expected: func(msg *warp.Message) *Summary {
+				return &Summary{
+					MessageID:     msg.ID(),
+					NetworkID:     msg.NetworkID,
+					SourceChainID: msg.SourceChainID,
+					NumSigners:    2,
+					PayloadType:   "AddressedCall",
+					SourceAddress: []byte{},
+					MessageType:   "L1ValidatorWeight",
+					Message:       l1ValidatorWeight,
+				}
+			},
+		},
+		{
+			name:    "unknown addressed call",
+			payload: unknownAddressedCall.Bytes(),
+			signers: set.NewBits(1),
+			expected: func(msg *warp.Message) *Summary {
+				return &Summary{
+					MessageID:     msg.ID(),
+					NetworkID:     msg.NetworkID,
+					SourceChainID: msg.SourceChainID,
+					NumSigners:    1,
+					PayloadType:   "AddressedCall",
+					SourceAddress: []byte{1, 2, 3},
+					RawMessage:    []byte{4, 5, 6},
+				}
+			},
+		},
+		{
+			name:    "hash",
+			payload: hash.Bytes(),
+			signers: set.NewBits(),
+			expected: func(msg *warp.Message) *Summary {
+				return &Summary{
+					MessageID:     msg.ID(),
+					NetworkID:     msg.NetworkID,
+					SourceChainID: msg.SourceChainID,
+					NumSigners:    0,
+					PayloadType:   "Hash",
+					Hash:          &hashID,
+				}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			msg := newSignedTestMessage(t, test.payload, test.signers)
+			summary, err := Summarize(msg)
+			require.NoError(err)
+			require.Equal(test.expected(msg), summary)
+
+			description, err := Describe(msg)
+			require.NoError(err)
+			require.Contains(description, msg.ID().String())
+		})
+	}
+}
+
+func TestSummarizeInvalidPayload(t *testing.T) {
+	msg := newSignedTestMessage(t, []byte("not a payload"), set.NewBits())
+	_, err := Summarize(msg)
+	require.ErrorIs(t, err, codec.ErrUnknownVersion)
+}
+
+func TestUnwrapAddressedCall(t *testing.T) {
+	l1ValidatorWeight, err := NewL1ValidatorWeight(ids.GenerateTestID(), 1, 2)
+	require.NoError(t, err)
+
+	sourceAddress := []byte{1, 2, 3}
+	addressedCall, err := warppayload.NewAddressedCall(sourceAddress, l1ValidatorWeight.Bytes())
+	require.NoError(t, err)
+
+	unknownAddressedCall, err := warppayload.NewAddressedCall(sourceAddress, []byte("not a message"))
+	require.NoError(t, err)
+
+	hash, err := warppayload.NewHash(ids.GenerateTestID())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                  string
+		payload               []byte
+		expectedAddressedCall *warppayload.AddressedCall
+		expectedMessage       Payload
+		expectedErr           error
+	}{
+		{
+			name:                  "AddressedCall",
+			payload:               addressedCall.Bytes(),
+			expectedAddressedCall: addressedCall,
+			expectedMessage:       l1ValidatorWeight,
+		},
+		{
+			name:        "Hash",
+			payload:     hash.Bytes(),
+			expectedErr: ErrNotAddressedCall,
+		},
+		{
+			name:        "unknown message",
+			payload:     unknownAddressedCall.Bytes(),
+			expectedErr: codec.ErrUnknownVersion,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msg := newSignedTestMessage(t, test.payload, set.NewBits(0))
+			addressedCall, m, err := UnwrapAddressedCall(msg)
+			require.ErrorIs(t, err, test.expectedErr)
+			require.Equal(t, test.expectedAddressedCall, addressedCall)
+			require.Equal(t, test.expectedMessage, m)
+		})
+	}
+}
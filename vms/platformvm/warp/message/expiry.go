@@ -0,0 +1,96 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+// RegisterL1ValidatorExpiryWindow is the maximum amount of time that the
+// expiry of a RegisterL1Validator message can be ahead of the P-chain time
+// when the message is issued.
+//
+// This must match executor.RegisterL1ValidatorTxExpiryWindow, which is
+// enforced by consensus.
+const RegisterL1ValidatorExpiryWindow = 24 * time.Hour
+
+var (
+	ErrDeadlineNotInFuture = errors.New("deadline is not in the future")
+	ErrDeadlineTooFar      = errors.New("deadline is too far in the future")
+	ErrExpiryNotIncreasing = errors.New("expiry is not increasing")
+)
+
+// ExpiryFromDeadline returns the expiry to include in a RegisterL1Validator
+// message so that the message is no longer valid after [deadline].
+//
+// An error is returned if [deadline] is not in the future or if it is further
+// in the future than [RegisterL1ValidatorExpiryWindow].
+func ExpiryFromDeadline(deadline time.Time) (uint64, error) {
+	return expiryFromDeadline(time.Now(), deadline)
+}
+
+func expiryFromDeadline(now time.Time, deadline time.Time) (uint64, error) {
+	var (
+		nowUnix      = now.Unix()
+		deadlineUnix = deadline.Unix()
+	)
+	if deadlineUnix <= nowUnix {
+		return 0, fmt.Errorf("%w: %d <= %d", ErrDeadlineNotInFuture, deadlineUnix, nowUnix)
+	}
+
+	maxDeadlineUnix := nowUnix + int64(RegisterL1ValidatorExpiryWindow/time.Second)
+	if deadlineUnix > maxDeadlineUnix {
+		return 0, fmt.Errorf("%w: %d > %d", ErrDeadlineTooFar, deadlineUnix, maxDeadlineUnix)
+	}
+	return uint64(deadlineUnix), nil
+}
+
+// NodeClock reports the current time according to a node. It is implemented
+// by the info API client.
+type NodeClock interface {
+	NetworkTime(ctx context.Context, options ...rpc.Option) (time.Time, error)
+}
+
+// ExpiryFromNodeClock returns the expiry to include in a RegisterL1Validator
+// message so that the message is no longer valid [window] after the current
+// time reported by [clock]. This avoids relying on the local clock, which may
+// be skewed relative to the network.
+//
+// An error is returned if [window] is shorter than a second or if it is longer
+// than [RegisterL1ValidatorExpiryWindow].
+func ExpiryFromNodeClock(
+	ctx context.Context,
+	clock NodeClock,
+	window time.Duration,
+	options ...rpc.Option,
+) (uint64, error) {
+	now, err := clock.NetworkTime(ctx, options...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch node time: %w", err)
+	}
+	return expiryFromDeadline(now, now.Add(window))
+}
+
+// VerifyExpiryMonotonic verifies that the expiries of [msgs] are strictly
+// increasing. This can be used to detect replayed or reordered messages in a
+// sequence of registrations.
+func VerifyExpiryMonotonic(msgs []*RegisterL1Validator) error {
+	for i := 1; i < len(msgs); i++ {
+		prev, cur := msgs[i-1].Expiry, msgs[i].Expiry
+		if cur <= prev {
+			return fmt.Errorf("%w: message %d has expiry %d <= %d",
+				ErrExpiryNotIncreasing,
+				i,
+				cur,
+				prev,
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+var errTestClock = errors.New("test clock error")
+
+type testNodeClock struct {
+	now time.Time
+	err error
+}
+
+func (c *testNodeClock) NetworkTime(context.Context, ...rpc.Option) (time.Time, error) {
+	return c.now, c.err
+}
+
+func TestExpiryFromDeadline(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	tests := []struct {
+		name           string
+		deadline       time.Time
+		expectedExpiry uint64
+		expectedErr    error
+	}{
+		{
+			name:        "before 1970",
+			deadline:    time.Unix(-1, 0),
+			expectedErr: ErrDeadlineNotInFuture,
+		},
+		{
+			name:        "past",
+			deadline:    now.Add(-time.Second),
+			expectedErr: ErrDeadlineNotInFuture,
+		},
+		{
+			name:        "now",
+			deadline:    now,
+			expectedErr: ErrDeadlineNotInFuture,
+		},
+		{
+			name:        "less than a second in the future",
+			deadline:    now.Add(time.Second - time.Nanosecond),
+			expectedErr: ErrDeadlineNotInFuture,
+		},
+		{
+			name:           "one second in the future",
+			deadline:       now.Add(time.Second),
+			expectedExpiry: uint64(now.Unix()) + 1,
+		},
+		{
+			name:           "max window",
+			deadline:       now.Add(RegisterL1ValidatorExpiryWindow),
+			expectedExpiry: uint64(now.Add(RegisterL1ValidatorExpiryWindow).Unix()),
+		},
+		{
+			name:        "past max window",
+			deadline:    now.Add(RegisterL1ValidatorExpiryWindow + time.Second),
+			expectedErr: ErrDeadlineTooFar,
+		},
+		{
+			name:        "far future",
+			deadline:    time.Unix(1<<62, 0),
+			expectedErr: ErrDeadlineTooFar,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			expiry, err := expiryFromDeadline(now, test.deadline)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedExpiry, expiry)
+		})
+	}
+}
+
+func TestExpiryFromNodeClock(t *testing.T) {
+	// The node time is intentionally far from the local clock.
+	nodeTime := time.Unix(1_700_000_000, 0)
+	tests := []struct {
+		name           string
+		clock          *testNodeClock
+		window         time.Duration
+		expectedExpiry uint64
+		expectedErr    error
+	}{
+		{
+			name:           "relative to node time",
+			clock:          &testNodeClock{now: nodeTime},
+			window:         time.Hour,
+			expectedExpiry: uint64(nodeTime.Add(time.Hour).Unix()),
+		},
+		{
+			name:        "window too large",
+			clock:       &testNodeClock{now: nodeTime},
+			window:      RegisterL1ValidatorExpiryWindow + time.Second,
+			expectedErr: ErrDeadlineTooFar,
+		},
+		{
+			name:        "empty window",
+			clock:       &testNodeClock{now: nodeTime},
+			expectedErr: ErrDeadlineNotInFuture,
+		},
+		{
+			name:        "clock error",
+			clock:       &testNodeClock{err: errTestClock},
+			window:      time.Hour,
+			expectedErr: errTestClock,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			expiry, err := ExpiryFromNodeClock(context.Background(), test.clock, test.window)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedExpiry, expiry)
+		})
+	}
+}
+
+func TestVerifyExpiryMonotonic(t *testing.T) {
+	tests := []struct {
+		name        string
+		expiries    []uint64
+		expectedErr error
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name:     "single",
+			expiries: []uint64{1},
+		},
+		{
+			name:     "increasing",
+			expiries: []uint64{1, 2, 5},
+		},
+		{
+			name:        "repeated",
+			expiries:    []uint64{1, 2, 2},
+			expectedErr: ErrExpiryNotIncreasing,
+		},
+		{
+			name:        "decreasing",
+			expiries:    []uint64{1, 3, 2},
+			expectedErr: ErrExpiryNotIncreasing,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msgs := make([]*RegisterL1Validator, len(test.expiries))
+			for i, expiry := range test.expiries {
+				msgs[i] = &RegisterL1Validator{
+					Expiry: expiry,
+				}
+			}
+			err := VerifyExpiryMonotonic(msgs)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -4,11 +4,14 @@
 package message
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/ava-labs/avalanchego/ids"
 )
 
+var ErrValidationIDMismatch = errors.New("validationID mismatch")
+
 // L1ValidatorRegistration reports if a validator is registered on the P-chain.
 type L1ValidatorRegistration struct {
 	payload
@@ -48,3 +51,25 @@ func ParseL1ValidatorRegistration(b []byte) (*L1ValidatorRegistration, error) {
 	}
 	return payload, nil
 }
+
+func (l *L1ValidatorRegistration) String() string {
+	return fmt.Sprintf("L1ValidatorRegistration(ValidationID = %s, Registered = %t)", l.ValidationID, l.Registered)
+}
+
+// MatchesRegistration reports whether [ack] acknowledges that the validator
+// requested by [req] was registered.
+//
+// If [ack] is for a different validationID than [req],
+// [ErrValidationIDMismatch] is returned. Otherwise, false is returned if [ack]
+// reports that [req] can never be registered, for example because it expired.
+func MatchesRegistration(ack *L1ValidatorRegistration, req *RegisterL1Validator) (bool, error) {
+	validationID := req.ValidationID()
+	if ack.ValidationID != validationID {
+		return false, fmt.Errorf("%w: acknowledgment is for %s but request is for %s",
+			ErrValidationIDMismatch,
+			ack.ValidationID,
+			validationID,
+		)
+	}
+	return ack.Registered, nil
+}
@@ -30,3 +30,52 @@ func TestL1ValidatorRegistration(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchesRegistration(t *testing.T) {
+	req, err := NewRegisterL1Validator(
+		ids.GenerateTestID(),
+		ids.GenerateTestNodeID(),
+		newBLSPublicKey(t),
+		1,
+		L1ValidatorOwners{},
+		1,
+	)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		validationID       ids.ID
+		registered         bool
+		expectedRegistered bool
+		expectedErr        error
+	}{
+		{
+			name:               "registered",
+			validationID:       req.ValidationID(),
+			registered:         true,
+			expectedRegistered: true,
+		},
+		{
+			name:         "not registered",
+			validationID: req.ValidationID(),
+		},
+		{
+			name:         "mismatched validationID",
+			validationID: ids.GenerateTestID(),
+			registered:   true,
+			expectedErr:  ErrValidationIDMismatch,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ack, err := NewL1ValidatorRegistration(test.validationID, test.registered)
+			require.NoError(err)
+
+			registered, err := MatchesRegistration(ack, req)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedRegistered, registered)
+		})
+	}
+}
@@ -30,7 +30,11 @@ type L1ValidatorWeight struct {
 
 func (s *L1ValidatorWeight) Verify() error {
 	if s.Nonce == math.MaxUint64 && s.Weight != 0 {
-		return ErrNonceReservedForRemoval
+		return &ValidationError{
+			Field:  "Nonce",
+			Reason: "is reserved for removal and requires a weight of 0",
+			Err:    ErrNonceReservedForRemoval,
+		}
 	}
 	return nil
 }
@@ -61,3 +65,7 @@ func ParseL1ValidatorWeight(b []byte) (*L1ValidatorWeight, error) {
 	}
 	return payload, nil
 }
+
+func (s *L1ValidatorWeight) String() string {
+	return fmt.Sprintf("L1ValidatorWeight(ValidationID = %s, Nonce = %d, Weight = %d)", s.ValidationID, s.Nonce, s.Weight)
+}
@@ -34,9 +34,10 @@ func TestL1ValidatorWeight_Verify(t *testing.T) {
 		return msg
 	}
 	tests := []struct {
-		name     string
-		msg      *L1ValidatorWeight
-		expected error
+		name          string
+		msg           *L1ValidatorWeight
+		expected      error
+		expectedField string
 	}{
 		{
 			name: "Invalid Nonce",
@@ -45,7 +46,8 @@ func TestL1ValidatorWeight_Verify(t *testing.T) {
 				math.MaxUint64,
 				1,
 			)),
-			expected: ErrNonceReservedForRemoval,
+			expected:      ErrNonceReservedForRemoval,
+			expectedField: "Nonce",
 		},
 		{
 			name: "Valid",
@@ -61,6 +63,13 @@ func TestL1ValidatorWeight_Verify(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			err := test.msg.Verify()
 			require.ErrorIs(t, err, test.expected)
+			if test.expected == nil {
+				return
+			}
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			require.Equal(t, test.expectedField, validationErr.Field)
 		})
 	}
 }
@@ -6,6 +6,8 @@ package message
 import (
 	"errors"
 	"fmt"
+
+	warppayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
 )
 
 var ErrWrongType = errors.New("wrong payload type")
@@ -48,6 +50,9 @@ func Initialize(p Payload) error {
 	if err != nil {
 		return fmt.Errorf("couldn't marshal %T payload: %w", p, err)
 	}
+	if size := len(bytes); size > MaxMessageSize {
+		return fmt.Errorf("%w: %d > %d", warppayload.ErrMessageTooLarge, size, MaxMessageSize)
+	}
 	p.initialize(bytes)
 	return nil
 }
@@ -4,6 +4,7 @@
 package message
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -11,6 +12,8 @@ import (
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+
+	warppayload "github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
 )
 
 func TestParse(t *testing.T) {
@@ -118,23 +121,25 @@ func TestParse(t *testing.T) {
 					0x5d, 0x5e, 0x5f, 0x60, 0x61, 0x62, 0x63, 0x64,
 				},
 				0x65666768696a6b6c,
-				PChainOwner{
-					Threshold: 0x6d6e6f70,
-					Addresses: []ids.ShortID{
-						{
-							0x71, 0x72, 0x73, 0x74, 0x75, 0x76, 0x77, 0x78,
-							0x79, 0x7a, 0x7b, 0x7c, 0x7d, 0x7e, 0x7f, 0x80,
-							0x81, 0x82, 0x83, 0x84,
+				L1ValidatorOwners{
+					RemainingBalanceOwner: PChainOwner{
+						Threshold: 0x6d6e6f70,
+						Addresses: []ids.ShortID{
+							{
+								0x71, 0x72, 0x73, 0x74, 0x75, 0x76, 0x77, 0x78,
+								0x79, 0x7a, 0x7b, 0x7c, 0x7d, 0x7e, 0x7f, 0x80,
+								0x81, 0x82, 0x83, 0x84,
+							},
 						},
 					},
-				},
-				PChainOwner{
-					Threshold: 0x85868788,
-					Addresses: []ids.ShortID{
-						{
-							0x89, 0x8a, 0x8b, 0x8c, 0x8d, 0x8e, 0x8f, 0x90,
-							0x91, 0x92, 0x93, 0x94, 0x95, 0x96, 0x97, 0x98,
-							0x99, 0x9a, 0x9b, 0x9c,
+					DeactivationOwner: PChainOwner{
+						Threshold: 0x85868788,
+						Addresses: []ids.ShortID{
+							{
+								0x89, 0x8a, 0x8b, 0x8c, 0x8d, 0x8e, 0x8f, 0x90,
+								0x91, 0x92, 0x93, 0x94, 0x95, 0x96, 0x97, 0x98,
+								0x99, 0x9a, 0x9b, 0x9c,
+							},
 						},
 					},
 				},
@@ -208,3 +213,66 @@ func TestParse(t *testing.T) {
 		})
 	}
 }
+
+func TestInitializeTooLarge(t *testing.T) {
+	owner := PChainOwner{
+		Threshold: 1,
+		Addresses: make([]ids.ShortID, MaxMessageSize/ids.ShortIDLen+1),
+	}
+	_, err := NewRegisterL1Validator(
+		ids.GenerateTestID(),
+		ids.GenerateTestNodeID(),
+		[bls.PublicKeyLen]byte{},
+		0,
+		L1ValidatorOwners{
+			RemainingBalanceOwner: owner,
+			DeactivationOwner:     owner,
+		},
+		1,
+	)
+	require.ErrorIs(t, err, warppayload.ErrMessageTooLarge)
+}
+
+func TestPayloadString(t *testing.T) {
+	tests := []struct {
+		payload  fmt.Stringer
+		expected string
+	}{
+		{
+			payload: &SubnetToL1Conversion{
+				ID: ids.ID{1, 2, 3},
+			},
+			expected: "SubnetToL1Conversion(ID = SkB7qHwfMsyF2PgrjhMvtFxJKhuR5ZfVoW9VATWRV4P9jV7J)",
+		},
+		{
+			payload: &RegisterL1Validator{
+				SubnetID:     ids.ID{1, 2, 3},
+				NodeID:       []byte{4, 5, 6},
+				BLSPublicKey: [bls.PublicKeyLen]byte{7, 8, 9},
+				Expiry:       1_700_000_000,
+				Weight:       100,
+			},
+			expected: "RegisterL1Validator(SubnetID = SkB7qHwfMsyF2PgrjhMvtFxJKhuR5ZfVoW9VATWRV4P9jV7J, NodeID = 040506, BLSPublicKey = 070809000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000, Expiry = 1700000000, Weight = 100)",
+		},
+		{
+			payload: &L1ValidatorRegistration{
+				ValidationID: ids.ID{1, 2, 3},
+				Registered:   true,
+			},
+			expected: "L1ValidatorRegistration(ValidationID = SkB7qHwfMsyF2PgrjhMvtFxJKhuR5ZfVoW9VATWRV4P9jV7J, Registered = true)",
+		},
+		{
+			payload: &L1ValidatorWeight{
+				ValidationID: ids.ID{1, 2, 3},
+				Nonce:        4,
+				Weight:       5,
+			},
+			expected: "L1ValidatorWeight(ValidationID = SkB7qHwfMsyF2PgrjhMvtFxJKhuR5ZfVoW9VATWRV4P9jV7J, Nonce = 4, Weight = 5)",
+		},
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%T", test.payload), func(t *testing.T) {
+			require.Equal(t, test.expected, test.payload.String())
+		})
+	}
+}
@@ -6,12 +6,15 @@ package message
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
 	"github.com/ava-labs/avalanchego/utils/hashing"
-	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/ava-labs/avalanchego/vms/types"
 )
@@ -21,6 +24,7 @@ var (
 	ErrInvalidWeight   = errors.New("invalid weight")
 	ErrInvalidNodeID   = errors.New("invalid node ID")
 	ErrInvalidOwner    = errors.New("invalid owner")
+	ErrEmptyKeychain   = errors.New("empty keychain")
 )
 
 type PChainOwner struct {
@@ -31,6 +35,50 @@ type PChainOwner struct {
 	Addresses []ids.ShortID `serialize:"true" json:"addresses"`
 }
 
+// Canonicalize returns a copy of [o] whose addresses are sorted and unique, as
+// required by the P-chain. The addresses of [o] are not modified.
+//
+// Messages with non-canonical owners fail [RegisterL1Validator.Verify], so
+// owners provided by users should be canonicalized before being included in a
+// message.
+func (o PChainOwner) Canonicalize() PChainOwner {
+	if len(o.Addresses) == 0 {
+		return o
+	}
+
+	addrs := slices.Clone(o.Addresses)
+	utils.Sort(addrs)
+	return PChainOwner{
+		Threshold: o.Threshold,
+		Addresses: slices.Compact(addrs),
+	}
+}
+
+// L1ValidatorOwners are the P-chain owners of an L1 validator.
+type L1ValidatorOwners struct {
+	// RemainingBalanceOwner is issued the remaining balance of the validator
+	// once it is removed from the validator set.
+	RemainingBalanceOwner PChainOwner
+	// DeactivationOwner has the authority to manually deactivate the
+	// validator.
+	DeactivationOwner PChainOwner
+}
+
+// DefaultDisableOwner returns a 1-of-1 owner of the first address in [kc]. The
+// addresses are sorted so that the same owner is returned for the same
+// keychain.
+func DefaultDisableOwner(kc keychain.Keychain) (PChainOwner, error) {
+	addrs := kc.Addresses().List()
+	if len(addrs) == 0 {
+		return PChainOwner{}, ErrEmptyKeychain
+	}
+	utils.Sort(addrs)
+	return PChainOwner{
+		Threshold: 1,
+		Addresses: addrs[:1],
+	}, nil
+}
+
 // RegisterL1Validator adds a validator to the subnet.
 type RegisterL1Validator struct {
 	payload
@@ -46,48 +94,101 @@ type RegisterL1Validator struct {
 
 func (r *RegisterL1Validator) Verify() error {
 	if r.SubnetID == constants.PrimaryNetworkID {
-		return ErrInvalidSubnetID
+		return &ValidationError{
+			Field:  "SubnetID",
+			Reason: "must not be the primary network",
+			Err:    ErrInvalidSubnetID,
+		}
 	}
 	if r.Weight == 0 {
-		return ErrInvalidWeight
+		return &ValidationError{
+			Field:  "Weight",
+			Reason: "must be non-zero",
+			Err:    ErrInvalidWeight,
+		}
 	}
 
 	nodeID, err := ids.ToNodeID(r.NodeID)
 	if err != nil {
-		return fmt.Errorf("%w: %w", ErrInvalidNodeID, err)
+		return &ValidationError{
+			Field:  "NodeID",
+			Reason: fmt.Sprintf("must be %d bytes but is %d bytes", ids.NodeIDLen, len(r.NodeID)),
+			Err:    fmt.Errorf("%w: %w", ErrInvalidNodeID, err),
+		}
 	}
 	if nodeID == ids.EmptyNodeID {
-		return fmt.Errorf("%w: empty nodeID is disallowed", ErrInvalidNodeID)
+		return &ValidationError{
+			Field:  "NodeID",
+			Reason: "must not be empty",
+			Err:    ErrInvalidNodeID,
+		}
 	}
 
-	err = verify.All(
-		&secp256k1fx.OutputOwners{
-			Threshold: r.RemainingBalanceOwner.Threshold,
-			Addrs:     r.RemainingBalanceOwner.Addresses,
-		},
-		&secp256k1fx.OutputOwners{
-			Threshold: r.DisableOwner.Threshold,
-			Addrs:     r.DisableOwner.Addresses,
-		},
-	)
-	if err != nil {
-		return fmt.Errorf("%w: %w", ErrInvalidOwner, err)
+	if err := verifyOwner("RemainingBalanceOwner", r.RemainingBalanceOwner); err != nil {
+		return err
+	}
+	return verifyOwner("DisableOwner", r.DisableOwner)
+}
+
+// verifyOwner verifies that [owner] is well-formed. [field] is the path of
+// [owner] in the message and is used to report which field is invalid.
+func verifyOwner(field string, owner PChainOwner) error {
+	err := (&secp256k1fx.OutputOwners{
+		Threshold: owner.Threshold,
+		Addrs:     owner.Addresses,
+	}).Verify()
+	if err == nil {
+		return nil
+	}
+
+	validationErr := &ValidationError{
+		Field:  field,
+		Reason: err.Error(),
+		Err:    fmt.Errorf("%w: %w", ErrInvalidOwner, err),
 	}
-	return nil
+	switch {
+	case errors.Is(err, secp256k1fx.ErrOutputUnspendable):
+		validationErr.Field += ".Threshold"
+		validationErr.Reason = "exceeds address count"
+	case errors.Is(err, secp256k1fx.ErrOutputUnoptimized):
+		validationErr.Field += ".Threshold"
+		validationErr.Reason = "must be non-zero when addresses are provided"
+	case errors.Is(err, secp256k1fx.ErrAddrsNotSortedUnique):
+		validationErr.Field += ".Addresses"
+		validationErr.Reason = "must be sorted and unique"
+	}
+	return validationErr
 }
 
 func (r *RegisterL1Validator) ValidationID() ids.ID {
 	return hashing.ComputeHash256Array(r.Bytes())
 }
 
+// Active returns true if the message has not expired at [now].
+//
+// Once a message has expired, it can no longer be accepted on the P-chain. A
+// registration that was never issued is therefore inert after its expiry and
+// must be rebuilt with a new expiry to be issued.
+func (r *RegisterL1Validator) Active(now time.Time) bool {
+	nowUnix := now.Unix()
+	return nowUnix < 0 || uint64(nowUnix) < r.Expiry
+}
+
+// Owners returns the owners of the validator.
+func (r *RegisterL1Validator) Owners() L1ValidatorOwners {
+	return L1ValidatorOwners{
+		RemainingBalanceOwner: r.RemainingBalanceOwner,
+		DeactivationOwner:     r.DisableOwner,
+	}
+}
+
 // NewRegisterL1Validator creates a new initialized RegisterL1Validator.
 func NewRegisterL1Validator(
 	subnetID ids.ID,
 	nodeID ids.NodeID,
 	blsPublicKey [bls.PublicKeyLen]byte,
 	expiry uint64,
-	remainingBalanceOwner PChainOwner,
-	disableOwner PChainOwner,
+	owners L1ValidatorOwners,
 	weight uint64,
 ) (*RegisterL1Validator, error) {
 	msg := &RegisterL1Validator{
@@ -95,8 +196,8 @@ func NewRegisterL1Validator(
 		NodeID:                nodeID[:],
 		BLSPublicKey:          blsPublicKey,
 		Expiry:                expiry,
-		RemainingBalanceOwner: remainingBalanceOwner,
-		DisableOwner:          disableOwner,
+		RemainingBalanceOwner: owners.RemainingBalanceOwner,
+		DisableOwner:          owners.DeactivationOwner,
 		Weight:                weight,
 	}
 	return msg, Initialize(msg)
@@ -115,3 +216,14 @@ func ParseRegisterL1Validator(b []byte) (*RegisterL1Validator, error) {
 	}
 	return payload, nil
 }
+
+func (r *RegisterL1Validator) String() string {
+	return fmt.Sprintf(
+		"RegisterL1Validator(SubnetID = %s, NodeID = %x, BLSPublicKey = %x, Expiry = %d, Weight = %d)",
+		r.SubnetID,
+		[]byte(r.NodeID),
+		r.BLSPublicKey,
+		r.Expiry,
+		r.Weight,
+	)
+}
@@ -5,14 +5,19 @@ package message
 
 import (
 	"math/rand"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 )
 
 func newBLSPublicKey(t *testing.T) [bls.PublicKeyLen]byte {
@@ -32,16 +37,18 @@ func TestRegisterL1Validator(t *testing.T) {
 		ids.GenerateTestNodeID(),
 		newBLSPublicKey(t),
 		rand.Uint64(), //#nosec G404
-		PChainOwner{
-			Threshold: rand.Uint32(), //#nosec G404
-			Addresses: []ids.ShortID{
-				ids.GenerateTestShortID(),
+		L1ValidatorOwners{
+			RemainingBalanceOwner: PChainOwner{
+				Threshold: rand.Uint32(), //#nosec G404
+				Addresses: []ids.ShortID{
+					ids.GenerateTestShortID(),
+				},
 			},
-		},
-		PChainOwner{
-			Threshold: rand.Uint32(), //#nosec G404
-			Addresses: []ids.ShortID{
-				ids.GenerateTestShortID(),
+			DeactivationOwner: PChainOwner{
+				Threshold: rand.Uint32(), //#nosec G404
+				Addresses: []ids.ShortID{
+					ids.GenerateTestShortID(),
+				},
 			},
 		},
 		rand.Uint64(), //#nosec G404
@@ -63,9 +70,10 @@ func TestRegisterL1Validator_Verify(t *testing.T) {
 		return msg
 	}
 	tests := []struct {
-		name     string
-		msg      *RegisterL1Validator
-		expected error
+		name          string
+		msg           *RegisterL1Validator
+		expected      error
+		expectedField string
 	}{
 		{
 			name: "PrimaryNetworkID",
@@ -74,18 +82,21 @@ func TestRegisterL1Validator_Verify(t *testing.T) {
 				ids.GenerateTestNodeID(),
 				newBLSPublicKey(t),
 				rand.Uint64(), //#nosec G404
-				PChainOwner{
-					Threshold: 1,
-					Addresses: []ids.ShortID{
-						ids.GenerateTestShortID(),
+				L1ValidatorOwners{
+					RemainingBalanceOwner: PChainOwner{
+						Threshold: 1,
+						Addresses: []ids.ShortID{
+							ids.GenerateTestShortID(),
+						},
+					},
+					DeactivationOwner: PChainOwner{
+						Threshold: 0,
 					},
-				},
-				PChainOwner{
-					Threshold: 0,
 				},
 				1,
 			)),
-			expected: ErrInvalidSubnetID,
+			expected:      ErrInvalidSubnetID,
+			expectedField: "SubnetID",
 		},
 		{
 			name: "Weight = 0",
@@ -94,18 +105,21 @@ func TestRegisterL1Validator_Verify(t *testing.T) {
 				ids.GenerateTestNodeID(),
 				newBLSPublicKey(t),
 				rand.Uint64(), //#nosec G404
-				PChainOwner{
-					Threshold: 1,
-					Addresses: []ids.ShortID{
-						ids.GenerateTestShortID(),
+				L1ValidatorOwners{
+					RemainingBalanceOwner: PChainOwner{
+						Threshold: 1,
+						Addresses: []ids.ShortID{
+							ids.GenerateTestShortID(),
+						},
+					},
+					DeactivationOwner: PChainOwner{
+						Threshold: 0,
 					},
-				},
-				PChainOwner{
-					Threshold: 0,
 				},
 				0,
 			)),
-			expected: ErrInvalidWeight,
+			expected:      ErrInvalidWeight,
+			expectedField: "Weight",
 		},
 		{
 			name: "Invalid NodeID Length",
@@ -125,7 +139,8 @@ func TestRegisterL1Validator_Verify(t *testing.T) {
 				},
 				Weight: 1,
 			},
-			expected: ErrInvalidNodeID,
+			expected:      ErrInvalidNodeID,
+			expectedField: "NodeID",
 		},
 		{
 			name: "Invalid NodeID",
@@ -134,18 +149,21 @@ func TestRegisterL1Validator_Verify(t *testing.T) {
 				ids.EmptyNodeID,
 				newBLSPublicKey(t),
 				rand.Uint64(), //#nosec G404
-				PChainOwner{
-					Threshold: 1,
-					Addresses: []ids.ShortID{
-						ids.GenerateTestShortID(),
+				L1ValidatorOwners{
+					RemainingBalanceOwner: PChainOwner{
+						Threshold: 1,
+						Addresses: []ids.ShortID{
+							ids.GenerateTestShortID(),
+						},
+					},
+					DeactivationOwner: PChainOwner{
+						Threshold: 0,
 					},
-				},
-				PChainOwner{
-					Threshold: 0,
 				},
 				1,
 			)),
-			expected: ErrInvalidNodeID,
+			expected:      ErrInvalidNodeID,
+			expectedField: "NodeID",
 		},
 		{
 			name: "Invalid Owner",
@@ -154,34 +172,83 @@ func TestRegisterL1Validator_Verify(t *testing.T) {
 				ids.GenerateTestNodeID(),
 				newBLSPublicKey(t),
 				rand.Uint64(), //#nosec G404
-				PChainOwner{
-					Threshold: 0,
-					Addresses: []ids.ShortID{
-						ids.GenerateTestShortID(),
+				L1ValidatorOwners{
+					RemainingBalanceOwner: PChainOwner{
+						Threshold: 0,
+						Addresses: []ids.ShortID{
+							ids.GenerateTestShortID(),
+						},
+					},
+					DeactivationOwner: PChainOwner{
+						Threshold: 0,
 					},
 				},
-				PChainOwner{
-					Threshold: 0,
+				1,
+			)),
+			expected:      ErrInvalidOwner,
+			expectedField: "RemainingBalanceOwner.Threshold",
+		},
+		{
+			name: "Unspendable Disable Owner",
+			msg: mustCreate(NewRegisterL1Validator(
+				ids.GenerateTestID(),
+				ids.GenerateTestNodeID(),
+				newBLSPublicKey(t),
+				rand.Uint64(), //#nosec G404
+				L1ValidatorOwners{
+					RemainingBalanceOwner: PChainOwner{
+						Threshold: 0,
+					},
+					DeactivationOwner: PChainOwner{
+						Threshold: 1,
+					},
 				},
 				1,
 			)),
-			expected: ErrInvalidOwner,
+			expected:      ErrInvalidOwner,
+			expectedField: "DisableOwner.Threshold",
 		},
 		{
-			name: "Valid",
+			name: "Unsorted Disable Owner",
 			msg: mustCreate(NewRegisterL1Validator(
 				ids.GenerateTestID(),
 				ids.GenerateTestNodeID(),
 				newBLSPublicKey(t),
 				rand.Uint64(), //#nosec G404
-				PChainOwner{
-					Threshold: 1,
-					Addresses: []ids.ShortID{
-						ids.GenerateTestShortID(),
+				L1ValidatorOwners{
+					RemainingBalanceOwner: PChainOwner{
+						Threshold: 0,
+					},
+					DeactivationOwner: PChainOwner{
+						Threshold: 1,
+						Addresses: []ids.ShortID{
+							{1},
+							{0},
+						},
 					},
 				},
-				PChainOwner{
-					Threshold: 0,
+				1,
+			)),
+			expected:      ErrInvalidOwner,
+			expectedField: "DisableOwner.Addresses",
+		},
+		{
+			name: "Valid",
+			msg: mustCreate(NewRegisterL1Validator(
+				ids.GenerateTestID(),
+				ids.GenerateTestNodeID(),
+				newBLSPublicKey(t),
+				rand.Uint64(), //#nosec G404
+				L1ValidatorOwners{
+					RemainingBalanceOwner: PChainOwner{
+						Threshold: 1,
+						Addresses: []ids.ShortID{
+							ids.GenerateTestShortID(),
+						},
+					},
+					DeactivationOwner: PChainOwner{
+						Threshold: 0,
+					},
 				},
 				1,
 			)),
@@ -192,6 +259,214 @@ func TestRegisterL1Validator_Verify(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			err := test.msg.Verify()
 			require.ErrorIs(t, err, test.expected)
+			if test.expected == nil {
+				return
+			}
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			require.Equal(t, test.expectedField, validationErr.Field)
+		})
+	}
+}
+
+func TestDefaultDisableOwner(t *testing.T) {
+	keys := secp256k1.TestKeys()[:3]
+	addrs := make([]ids.ShortID, len(keys))
+	for i, key := range keys {
+		addrs[i] = key.Address()
+	}
+	utils.Sort(addrs)
+
+	tests := []struct {
+		name          string
+		keys          []*secp256k1.PrivateKey
+		expectedOwner PChainOwner
+		expectedErr   error
+	}{
+		{
+			name: "single key",
+			keys: keys[:1],
+			expectedOwner: PChainOwner{
+				Threshold: 1,
+				Addresses: []ids.ShortID{keys[0].Address()},
+			},
+		},
+		{
+			name: "multiple keys",
+			keys: keys,
+			expectedOwner: PChainOwner{
+				Threshold: 1,
+				Addresses: addrs[:1],
+			},
+		},
+		{
+			name:        "empty keychain",
+			expectedErr: ErrEmptyKeychain,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			owner, err := DefaultDisableOwner(secp256k1fx.NewKeychain(test.keys...))
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedOwner, owner)
 		})
 	}
 }
+
+func TestPChainOwnerCanonicalize(t *testing.T) {
+	addrs := []ids.ShortID{{1}, {2}, {3}}
+	tests := []struct {
+		name     string
+		owner    PChainOwner
+		expected PChainOwner
+	}{
+		{
+			name:     "empty",
+			owner:    PChainOwner{},
+			expected: PChainOwner{},
+		},
+		{
+			name: "already canonical",
+			owner: PChainOwner{
+				Threshold: 1,
+				Addresses: addrs,
+			},
+			expected: PChainOwner{
+				Threshold: 1,
+				Addresses: addrs,
+			},
+		},
+		{
+			name: "unsorted with duplicates",
+			owner: PChainOwner{
+				Threshold: 2,
+				Addresses: []ids.ShortID{addrs[2], addrs[0], addrs[2], addrs[1], addrs[0]},
+			},
+			expected: PChainOwner{
+				Threshold: 2,
+				Addresses: addrs,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			original := slices.Clone(test.owner.Addresses)
+			require.Equal(test.expected, test.owner.Canonicalize())
+			require.Equal(original, test.owner.Addresses)
+		})
+	}
+}
+
+func TestRegisterL1ValidatorCanonicalOwners(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		addrs = []ids.ShortID{{1}, {2}, {3}}
+		owner = PChainOwner{
+			Threshold: 1,
+			Addresses: []ids.ShortID{addrs[1], addrs[2], addrs[1], addrs[0]},
+		}
+		canonicalOwner = owner.Canonicalize()
+	)
+	msg, err := NewRegisterL1Validator(
+		ids.GenerateTestID(),
+		ids.GenerateTestNodeID(),
+		newBLSPublicKey(t),
+		rand.Uint64(), //#nosec G404
+		L1ValidatorOwners{
+			RemainingBalanceOwner: canonicalOwner,
+			DeactivationOwner:     canonicalOwner,
+		},
+		1,
+	)
+	require.NoError(err)
+	require.NoError(msg.Verify())
+
+	parsed, err := ParseRegisterL1Validator(msg.Bytes())
+	require.NoError(err)
+	require.Equal(addrs, parsed.RemainingBalanceOwner.Addresses)
+	require.Equal(addrs, parsed.DisableOwner.Addresses)
+}
+
+func TestRegisterL1ValidatorActive(t *testing.T) {
+	const expiry = 1_700_000_000
+	msg := &RegisterL1Validator{
+		Expiry: expiry,
+	}
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected bool
+	}{
+		{
+			name:     "before 1970",
+			now:      time.Unix(-1, 0),
+			expected: true,
+		},
+		{
+			name:     "before expiry",
+			now:      time.Unix(expiry-1, 0),
+			expected: true,
+		},
+		{
+			name:     "within the second before expiry",
+			now:      time.Unix(expiry-1, int64(time.Second-time.Nanosecond)),
+			expected: true,
+		},
+		{
+			name:     "at expiry",
+			now:      time.Unix(expiry, 0),
+			expected: false,
+		},
+		{
+			name:     "after expiry",
+			now:      time.Unix(expiry+1, 0),
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, msg.Active(test.now))
+		})
+	}
+}
+
+func TestRegisterL1ValidatorOwners(t *testing.T) {
+	require := require.New(t)
+
+	owners := L1ValidatorOwners{
+		RemainingBalanceOwner: PChainOwner{
+			Threshold: 1,
+			Addresses: []ids.ShortID{
+				ids.GenerateTestShortID(),
+			},
+		},
+		DeactivationOwner: PChainOwner{
+			Threshold: 1,
+			Addresses: []ids.ShortID{
+				ids.GenerateTestShortID(),
+			},
+		},
+	}
+	msg, err := NewRegisterL1Validator(
+		ids.GenerateTestID(),
+		ids.GenerateTestNodeID(),
+		newBLSPublicKey(t),
+		rand.Uint64(), //#nosec G404
+		owners,
+		1,
+	)
+	require.NoError(err)
+	require.Equal(owners.RemainingBalanceOwner, msg.RemainingBalanceOwner)
+	require.Equal(owners.DeactivationOwner, msg.DisableOwner)
+
+	parsed, err := ParseRegisterL1Validator(msg.Bytes())
+	require.NoError(err)
+	require.Equal(owners, parsed.Owners())
+}
@@ -66,3 +66,7 @@ func ParseSubnetToL1Conversion(b []byte) (*SubnetToL1Conversion, error) {
 	}
 	return payload, nil
 }
+
+func (s *SubnetToL1Conversion) String() string {
+	return fmt.Sprintf("SubnetToL1Conversion(ID = %s)", s.ID)
+}
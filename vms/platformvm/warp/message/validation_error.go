@@ -0,0 +1,28 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import "fmt"
+
+var _ error = (*ValidationError)(nil)
+
+// ValidationError is returned by Verify when a field of a message is invalid.
+type ValidationError struct {
+	// Field is the path of the invalid field, such as
+	// "RemainingBalanceOwner.Threshold".
+	Field string
+	// Reason describes why the field is invalid.
+	Reason string
+	// Err is the underlying error, which allows callers to continue to use
+	// errors.Is with the existing sentinel errors.
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s %s", e.Err, e.Field, e.Reason)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
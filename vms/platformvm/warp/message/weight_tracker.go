@@ -0,0 +1,104 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var (
+	ErrValidationAlreadyTracked = errors.New("validationID is already tracked")
+	ErrUnknownValidation        = errors.New("unknown validationID")
+	ErrStaleNonce               = errors.New("stale nonce")
+)
+
+// WeightTracker maintains the current weight of L1 validators as derived from
+// the messages that modify them.
+//
+// WeightTracker is safe for concurrent use.
+type WeightTracker struct {
+	lock sync.RWMutex
+	// validationID -> weight
+	weights map[ids.ID]uint64
+	// validationID -> lowest nonce that may still be applied
+	minNonces map[ids.ID]uint64
+}
+
+func NewWeightTracker() *WeightTracker {
+	return &WeightTracker{
+		weights:   make(map[ids.ID]uint64),
+		minNonces: make(map[ids.ID]uint64),
+	}
+}
+
+// Apply updates the tracked weights based on [msg]:
+//
+//   - RegisterL1Validator starts tracking the validator with its initial
+//     weight.
+//   - L1ValidatorWeight updates the weight of the validator. A weight of 0
+//     removes the validator. Messages with a nonce lower than the nonce
+//     following the last applied weight update are rejected.
+//   - L1ValidatorRegistration reporting that the validator is not registered
+//     removes the validator.
+//
+// Any other message type is rejected.
+func (w *WeightTracker) Apply(msg Payload) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	switch msg := msg.(type) {
+	case *RegisterL1Validator:
+		validationID := msg.ValidationID()
+		if _, ok := w.weights[validationID]; ok {
+			return fmt.Errorf("%w: %s", ErrValidationAlreadyTracked, validationID)
+		}
+		w.weights[validationID] = msg.Weight
+		w.minNonces[validationID] = 0
+	case *L1ValidatorWeight:
+		minNonce, ok := w.minNonces[msg.ValidationID]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownValidation, msg.ValidationID)
+		}
+		if msg.Nonce < minNonce {
+			return fmt.Errorf("%w: %d < %d", ErrStaleNonce, msg.Nonce, minNonce)
+		}
+		if msg.Weight == 0 {
+			w.remove(msg.ValidationID)
+			return nil
+		}
+		// Because a non-zero weight can not be paired with the maximum nonce,
+		// this can not overflow for a valid message.
+		w.weights[msg.ValidationID] = msg.Weight
+		w.minNonces[msg.ValidationID] = msg.Nonce + 1
+	case *L1ValidatorRegistration:
+		if _, ok := w.weights[msg.ValidationID]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownValidation, msg.ValidationID)
+		}
+		if !msg.Registered {
+			w.remove(msg.ValidationID)
+		}
+	default:
+		return fmt.Errorf("%w: %T", ErrWrongType, msg)
+	}
+	return nil
+}
+
+// Weight returns the current weight of [validationID] and whether it is
+// tracked.
+func (w *WeightTracker) Weight(validationID ids.ID) (uint64, bool) {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+
+	weight, ok := w.weights[validationID]
+	return weight, ok
+}
+
+func (w *WeightTracker) remove(validationID ids.ID) {
+	delete(w.weights, validationID)
+	delete(w.minNonces, validationID)
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestWeightTracker(t *testing.T) {
+	require := require.New(t)
+
+	register, err := NewRegisterL1Validator(
+		ids.GenerateTestID(),
+		ids.GenerateTestNodeID(),
+		newBLSPublicKey(t),
+		1,
+		L1ValidatorOwners{},
+		10,
+	)
+	require.NoError(err)
+	validationID := register.ValidationID()
+
+	tracker := NewWeightTracker()
+	_, ok := tracker.Weight(validationID)
+	require.False(ok)
+
+	// Register the validator.
+	require.NoError(tracker.Apply(register))
+	weight, ok := tracker.Weight(validationID)
+	require.True(ok)
+	require.Equal(uint64(10), weight)
+
+	err = tracker.Apply(register)
+	require.ErrorIs(err, ErrValidationAlreadyTracked)
+
+	// Change the weight of the validator.
+	weightChange, err := NewL1ValidatorWeight(validationID, 5, 20)
+	require.NoError(err)
+	require.NoError(tracker.Apply(weightChange))
+	weight, ok = tracker.Weight(validationID)
+	require.True(ok)
+	require.Equal(uint64(20), weight)
+
+	staleWeightChange, err := NewL1ValidatorWeight(validationID, 5, 30)
+	require.NoError(err)
+	err = tracker.Apply(staleWeightChange)
+	require.ErrorIs(err, ErrStaleNonce)
+	weight, ok = tracker.Weight(validationID)
+	require.True(ok)
+	require.Equal(uint64(20), weight)
+
+	// A registration report does not modify a registered validator.
+	registered, err := NewL1ValidatorRegistration(validationID, true)
+	require.NoError(err)
+	require.NoError(tracker.Apply(registered))
+	weight, ok = tracker.Weight(validationID)
+	require.True(ok)
+	require.Equal(uint64(20), weight)
+
+	// Disable the validator.
+	disabled, err := NewL1ValidatorRegistration(validationID, false)
+	require.NoError(err)
+	require.NoError(tracker.Apply(disabled))
+	_, ok = tracker.Weight(validationID)
+	require.False(ok)
+
+	err = tracker.Apply(weightChange)
+	require.ErrorIs(err, ErrUnknownValidation)
+}
+
+func TestWeightTrackerRemoveWithZeroWeight(t *testing.T) {
+	require := require.New(t)
+
+	register, err := NewRegisterL1Validator(
+		ids.GenerateTestID(),
+		ids.GenerateTestNodeID(),
+		newBLSPublicKey(t),
+		1,
+		L1ValidatorOwners{},
+		10,
+	)
+	require.NoError(err)
+	validationID := register.ValidationID()
+
+	tracker := NewWeightTracker()
+	require.NoError(tracker.Apply(register))
+
+	removal, err := NewL1ValidatorWeight(validationID, 0, 0)
+	require.NoError(err)
+	require.NoError(tracker.Apply(removal))
+	_, ok := tracker.Weight(validationID)
+	require.False(ok)
+}
+
+func TestWeightTrackerUnsupportedMessage(t *testing.T) {
+	conversion, err := NewSubnetToL1Conversion(ids.GenerateTestID())
+	require.NoError(t, err)
+
+	err = NewWeightTracker().Apply(conversion)
+	require.ErrorIs(t, err, ErrWrongType)
+}
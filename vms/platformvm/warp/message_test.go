@@ -4,6 +4,9 @@
 package warp
 
 import (
+	"encoding/base64"
+	"encoding/binary"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -12,6 +15,8 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
 func TestMessage(t *testing.T) {
@@ -46,3 +51,230 @@ func TestParseMessageJunk(t *testing.T) {
 	_, err := ParseMessage(bytes)
 	require.ErrorIs(err, codec.ErrUnknownVersion)
 }
+
+func TestParseMessageUnsupportedSignatureType(t *testing.T) {
+	require := require.New(t)
+
+	payload := []byte("payload")
+	unsignedMsg, err := NewUnsignedMessage(
+		constants.UnitTestID,
+		ids.GenerateTestID(),
+		payload,
+	)
+	require.NoError(err)
+
+	sk, err := bls.NewSigner()
+	require.NoError(err)
+
+	msg, err := NewMessage(
+		unsignedMsg,
+		NewBitSetSignature(set.NewBits(0), sk.Sign(unsignedMsg.Bytes())),
+	)
+	require.NoError(err)
+	typeID, ok := SignatureTypeID(msg.Signature)
+	require.True(ok)
+	require.Equal(BitSetSignatureTypeID, typeID)
+
+	// The signature type ID immediately follows the payload.
+	msgBytes := slices.Clone(msg.Bytes())
+	typeIDOffset := payloadLenOffset + wrappers.IntLen + len(payload)
+	require.Equal(BitSetSignatureTypeID, binary.BigEndian.Uint32(msgBytes[typeIDOffset:]))
+
+	binary.BigEndian.PutUint32(msgBytes[typeIDOffset:], BitSetSignatureTypeID+1)
+	_, err = ParseMessage(msgBytes)
+	require.ErrorIs(err, ErrUnsupportedSignature)
+}
+
+func TestMessageBase64(t *testing.T) {
+	require := require.New(t)
+
+	unsignedMsg, err := NewUnsignedMessage(
+		constants.UnitTestID,
+		ids.GenerateTestID(),
+		[]byte("payload"),
+	)
+	require.NoError(err)
+
+	msg, err := NewMessage(
+		unsignedMsg,
+		&BitSetSignature{
+			Signers:   []byte{1, 2, 3},
+			Signature: [bls.SignatureLen]byte{4, 5, 6},
+		},
+	)
+	require.NoError(err)
+
+	encoded := msg.Base64()
+	require.Equal(base64.StdEncoding.EncodeToString(msg.Bytes()), encoded)
+
+	parsedMsg, err := ParseMessageBase64(encoded)
+	require.NoError(err)
+	require.Equal(msg, parsedMsg)
+}
+
+func TestVerifyNetworkID(t *testing.T) {
+	unsignedMsg, err := NewUnsignedMessage(
+		constants.UnitTestID,
+		ids.GenerateTestID(),
+		[]byte("payload"),
+	)
+	require.NoError(t, err)
+	msg, err := NewMessageUnsigned(unsignedMsg)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		expected    uint32
+		expectedErr error
+	}{
+		{
+			name:     "matching network",
+			expected: constants.UnitTestID,
+		},
+		{
+			name:        "wrong network",
+			expected:    constants.MainnetID,
+			expectedErr: ErrWrongNetworkID,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifyNetworkID(msg, test.expected)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestParseMessageBase64Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expectedErr error
+	}{
+		{
+			name:        "invalid base64",
+			input:       "not base64!",
+			expectedErr: ErrInvalidBase64,
+		},
+		{
+			name:        "invalid message",
+			input:       base64.StdEncoding.EncodeToString([]byte{0, 1, 2, 3, 4, 5, 6, 7}),
+			expectedErr: ErrParseMessage,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseMessageBase64(test.input)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestParseMessageTooLarge(t *testing.T) {
+	require := require.New(t)
+
+	unsignedMsg, err := NewUnsignedMessage(
+		constants.UnitTestID,
+		ids.GenerateTestID(),
+		[]byte("payload"),
+	)
+	require.NoError(err)
+
+	msg, err := NewMessage(
+		unsignedMsg,
+		&BitSetSignature{
+			Signers:   []byte{1, 2, 3},
+			Signature: [bls.SignatureLen]byte{4, 5, 6},
+		},
+	)
+	require.NoError(err)
+
+	msgBytes := msg.Bytes()
+	_, err = ParseMessageWithMaxSize(msgBytes, len(msgBytes)-1)
+	require.ErrorIs(err, ErrMessageTooLarge)
+
+	// Overwrite the payload length header to claim a payload larger than the
+	// maximum message size.
+	msgBytes = slices.Clone(msgBytes)
+	binary.BigEndian.PutUint32(msgBytes[payloadLenOffset:], MaxMessageSize+1)
+	_, err = ParseMessageWithMaxSize(msgBytes, MaxMessageSize)
+	require.ErrorIs(err, ErrMessageTooLarge)
+
+	// ParseMessage does not limit the size of the message, so that the
+	// parsing of accepted transactions is not modified.
+	_, err = ParseMessage(msgBytes)
+	require.NotErrorIs(err, ErrMessageTooLarge)
+}
+
+func TestNewMessageTooLarge(t *testing.T) {
+	require := require.New(t)
+
+	// The largest possible unsigned message.
+	unsignedMsg, err := NewUnsignedMessage(
+		constants.UnitTestID,
+		ids.GenerateTestID(),
+		make([]byte, MaxMessageSize-payloadLenOffset-wrappers.IntLen),
+	)
+	require.NoError(err)
+	require.Len(unsignedMsg.Bytes(), MaxMessageSize)
+
+	_, err = NewMessage(
+		unsignedMsg,
+		&BitSetSignature{},
+	)
+	require.ErrorIs(err, ErrMessageTooLarge)
+}
+
+func TestMessageUnsigned(t *testing.T) {
+	require := require.New(t)
+
+	unsignedMsg, err := NewUnsignedMessage(
+		constants.UnitTestID,
+		ids.GenerateTestID(),
+		[]byte("payload"),
+	)
+	require.NoError(err)
+
+	msg, err := NewMessageUnsigned(unsignedMsg)
+	require.NoError(err)
+	require.False(msg.IsSigned())
+
+	msgBytes := msg.Bytes()
+	msg2, err := ParseMessage(msgBytes)
+	require.NoError(err)
+	require.Equal(msg, msg2)
+	require.False(msg2.IsSigned())
+
+	signedMsg, err := NewMessage(
+		unsignedMsg,
+		&BitSetSignature{
+			Signers: []byte{1},
+		},
+	)
+	require.NoError(err)
+	require.True(signedMsg.IsSigned())
+}
+
+func TestMessageString(t *testing.T) {
+	require := require.New(t)
+
+	unsignedMsg, err := NewUnsignedMessage(
+		constants.UnitTestID,
+		ids.ID{1, 2, 3},
+		[]byte("payload"),
+	)
+	require.NoError(err)
+
+	msg, err := NewMessage(
+		unsignedMsg,
+		&BitSetSignature{
+			Signers:   []byte{1, 2, 3},
+			Signature: [bls.SignatureLen]byte{4, 5, 6},
+		},
+	)
+	require.NoError(err)
+	require.Equal(
+		"WarpMessage(UnsignedMessage(NetworkID = 10, SourceChainID = SkB7qHwfMsyF2PgrjhMvtFxJKhuR5ZfVoW9VATWRV4P9jV7J, Payload = 7061796c6f6164), BitSetSignature(Signers = 010203, Signature = 040506000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000))",
+		msg.String(),
+	)
+}
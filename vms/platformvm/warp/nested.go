@@ -0,0 +1,60 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+)
+
+// MaxNestingDepth is the default maximum number of nested messages
+// that [UnwrapInnermost] will unwrap.
+const MaxNestingDepth = 8
+
+var (
+	ErrNotNested      = errors.New("payload is not a nested message")
+	ErrNestingTooDeep = errors.New("message nesting is too deep")
+)
+
+// UnwrapNested returns the signed message that is serialized as the payload
+// of the AddressedCall carried by [msg].
+//
+// Returns [ErrNotNested] if the payload of [msg] is not an AddressedCall or if
+// the AddressedCall payload is not a signed message.
+func UnwrapNested(msg *Message) (*Message, error) {
+	p, err := payload.Parse(msg.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotNested, err)
+	}
+	addressedCall, ok := p.(*payload.AddressedCall)
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected payload %T", ErrNotNested, p)
+	}
+	inner, err := ParseMessage(addressedCall.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotNested, err)
+	}
+	return inner, nil
+}
+
+// UnwrapInnermost repeatedly unwraps [msg] with [UnwrapNested] and returns the
+// innermost message along with the number of messages that were unwrapped. If
+// [msg] is not nested, it is returned with a depth of 0.
+//
+// Returns [ErrNestingTooDeep] if more than [maxDepth] messages are nested.
+func UnwrapInnermost(msg *Message, maxDepth int) (*Message, int, error) {
+	for depth := 0; ; depth++ {
+		inner, err := UnwrapNested(msg)
+		if err != nil {
+			// [msg] is the innermost message.
+			return msg, depth, nil
+		}
+		if depth >= maxDepth {
+			return nil, 0, fmt.Errorf("%w: exceeds %d", ErrNestingTooDeep, maxDepth)
+		}
+		msg = inner
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+)
+
+// newNestedMessage returns an unsigned message whose AddressedCall payload is
+// [inner].
+func newNestedMessage(t *testing.T, inner []byte) *Message {
+	require := require.New(t)
+
+	addressedCall, err := payload.NewAddressedCall([]byte{1, 2, 3}, inner)
+	require.NoError(err)
+	unsignedMsg, err := NewUnsignedMessage(
+		constants.UnitTestID,
+		ids.GenerateTestID(),
+		addressedCall.Bytes(),
+	)
+	require.NoError(err)
+	msg, err := NewMessageUnsigned(unsignedMsg)
+	require.NoError(err)
+	return msg
+}
+
+func TestUnwrapNested(t *testing.T) {
+	require := require.New(t)
+
+	innermost := newNestedMessage(t, []byte("payload"))
+	middle := newNestedMessage(t, innermost.Bytes())
+	outer := newNestedMessage(t, middle.Bytes())
+
+	unwrapped, err := UnwrapNested(outer)
+	require.NoError(err)
+	require.Equal(middle, unwrapped)
+
+	unwrapped, err = UnwrapNested(unwrapped)
+	require.NoError(err)
+	require.Equal(innermost, unwrapped)
+
+	_, err = UnwrapNested(innermost)
+	require.ErrorIs(err, ErrNotNested)
+
+	hash, err := payload.NewHash(ids.GenerateTestID())
+	require.NoError(err)
+	unsignedHashMsg, err := NewUnsignedMessage(
+		constants.UnitTestID,
+		ids.GenerateTestID(),
+		hash.Bytes(),
+	)
+	require.NoError(err)
+	hashMsg, err := NewMessageUnsigned(unsignedHashMsg)
+	require.NoError(err)
+	_, err = UnwrapNested(hashMsg)
+	require.ErrorIs(err, ErrNotNested)
+}
+
+func TestUnwrapInnermost(t *testing.T) {
+	innermost := newNestedMessage(t, []byte("payload"))
+	middle := newNestedMessage(t, innermost.Bytes())
+	outer := newNestedMessage(t, middle.Bytes())
+
+	tests := []struct {
+		name          string
+		msg           *Message
+		maxDepth      int
+		expectedMsg   *Message
+		expectedDepth int
+		expectedErr   error
+	}{
+		{
+			name:        "not nested",
+			msg:         innermost,
+			maxDepth:    MaxNestingDepth,
+			expectedMsg: innermost,
+		},
+		{
+			name:          "two levels",
+			msg:           outer,
+			maxDepth:      MaxNestingDepth,
+			expectedMsg:   innermost,
+			expectedDepth: 2,
+		},
+		{
+			name:          "exactly max depth",
+			msg:           outer,
+			maxDepth:      2,
+			expectedMsg:   innermost,
+			expectedDepth: 2,
+		},
+		{
+			name:        "exceeds max depth",
+			msg:         outer,
+			maxDepth:    1,
+			expectedErr: ErrNestingTooDeep,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			msg, depth, err := UnwrapInnermost(test.msg, test.maxDepth)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedMsg, msg)
+			require.Equal(test.expectedDepth, depth)
+		})
+	}
+}
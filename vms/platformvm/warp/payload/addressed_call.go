@@ -3,9 +3,23 @@
 
 package payload
 
-import "fmt"
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
 
-var _ Payload = (*AddressedCall)(nil)
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// innerTypeIDOffset is the offset of the type ID of the inner payload of an
+// AddressedCall, which is prefixed by its codec version.
+const innerTypeIDOffset = wrappers.ShortLen
+
+var (
+	_ Payload = (*AddressedCall)(nil)
+
+	ErrInnerPayloadTooShort = errors.New("inner payload too short")
+)
 
 // AddressedCall defines the format for delivering a call across VMs including a
 // source address and a payload.
@@ -51,3 +65,19 @@ func (a *AddressedCall) Bytes() []byte {
 func (a *AddressedCall) initialize(bytes []byte) {
 	a.bytes = bytes
 }
+
+// PeekType returns the type ID of the codec encoded payload carried by [ac]
+// without parsing the rest of the payload.
+//
+// The inner payload is assumed to be prefixed by a 2 byte codec version and a 4
+// byte type ID, as is the case for all payloads serialized by a linearcodec.
+func PeekType(ac *AddressedCall) (uint32, error) {
+	if size := len(ac.Payload); size < innerTypeIDOffset+wrappers.IntLen {
+		return 0, fmt.Errorf("%w: %d < %d",
+			ErrInnerPayloadTooShort,
+			size,
+			innerTypeIDOffset+wrappers.IntLen,
+		)
+	}
+	return binary.BigEndian.Uint32(ac.Payload[innerTypeIDOffset:]), nil
+}
@@ -44,3 +44,40 @@ func TestAddressedCallBytes(t *testing.T) {
 	require.NoError(err)
 	require.Equal(base64Payload, base64.StdEncoding.EncodeToString(addressedPayload.Bytes()))
 }
+
+func TestPeekType(t *testing.T) {
+	tests := []struct {
+		name           string
+		payload        []byte
+		expectedTypeID uint32
+		expectedErr    error
+	}{
+		{
+			name:           "type ID",
+			payload:        []byte{0x00, 0x00, 0x01, 0x02, 0x03, 0x04, 0xff},
+			expectedTypeID: 0x01020304,
+		},
+		{
+			name:        "empty",
+			payload:     nil,
+			expectedErr: ErrInnerPayloadTooShort,
+		},
+		{
+			name:        "truncated type ID",
+			payload:     []byte{0x00, 0x00, 0x01, 0x02, 0x03},
+			expectedErr: ErrInnerPayloadTooShort,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			addressedCall, err := NewAddressedCall(nil, test.payload)
+			require.NoError(err)
+
+			typeID, err := PeekType(addressedCall)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedTypeID, typeID)
+		})
+	}
+}
@@ -17,6 +17,13 @@ const (
 	MaxMessageSize = 24 * units.KiB
 )
 
+// Type IDs of the payloads registered in [Codec]. A serialized payload starts
+// with the codec version followed by its type ID.
+const (
+	HashTypeID uint32 = iota
+	AddressedCallTypeID
+)
+
 var Codec codec.Manager
 
 func init() {
@@ -6,9 +6,18 @@ package payload
 import (
 	"errors"
 	"fmt"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
-var ErrWrongType = errors.New("wrong payload type")
+var (
+	ErrWrongType = errors.New("wrong payload type")
+
+	// ErrMessageTooLarge is returned when a Warp message, or any payload
+	// included in one, exceeds its maximum size.
+	ErrMessageTooLarge = errors.New("message too large")
+)
 
 // Payload provides a common interface for all payloads implemented by this
 // package.
@@ -20,9 +29,19 @@ type Payload interface {
 	initialize(b []byte)
 }
 
+// Parse converts a slice of bytes into an initialized Payload. The concrete
+// type of the returned Payload, either *AddressedCall or *Hash, is determined
+// by the type ID encoded in [bytes].
+//
+// If the type ID is not registered, an error wrapping
+// [codec.ErrUnknownTypeID] is returned.
 func Parse(bytes []byte) (Payload, error) {
 	var payload Payload
-	if _, err := Codec.Unmarshal(bytes, &payload); err != nil {
+	_, err := Codec.Unmarshal(bytes, &payload)
+	if errors.Is(err, codec.ErrUnmarshalTooBig) {
+		return nil, fmt.Errorf("%w: %w", ErrMessageTooLarge, err)
+	}
+	if err != nil {
 		return nil, err
 	}
 	payload.initialize(bytes)
@@ -31,6 +50,10 @@ func Parse(bytes []byte) (Payload, error) {
 
 func initialize(p Payload) error {
 	bytes, err := Codec.Marshal(CodecVersion, &p)
+	if errors.Is(err, wrappers.ErrInsufficientLength) {
+		// [Codec] refuses to marshal more than [MaxMessageSize] bytes.
+		return fmt.Errorf("%w: %w", ErrMessageTooLarge, err)
+	}
 	if err != nil {
 		return fmt.Errorf("couldn't marshal %T payload: %w", p, err)
 	}
@@ -4,12 +4,14 @@
 package payload
 
 import (
+	"encoding/binary"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
 var junkBytes = []byte{0x11, 0x22, 0x33, 0x44, 0x55, 0x66, 0x77, 0x88}
@@ -20,6 +22,17 @@ func TestParseJunk(t *testing.T) {
 	require.ErrorIs(err, codec.ErrUnknownVersion)
 }
 
+func TestParseUnknownType(t *testing.T) {
+	unknownTypeBytes := []byte{
+		// Codec version:
+		0x00, 0x00,
+		// Payload type ID:
+		0xff, 0xff, 0xff, 0xff,
+	}
+	_, err := Parse(unknownTypeBytes)
+	require.ErrorIs(t, err, codec.ErrUnknownTypeID)
+}
+
 func TestParseWrongPayloadType(t *testing.T) {
 	require := require.New(t)
 	hashPayload, err := NewHash(ids.GenerateTestID())
@@ -46,6 +59,7 @@ func TestParse(t *testing.T) {
 
 	parsedHashPayload, err := Parse(hashPayload.Bytes())
 	require.NoError(err)
+	require.IsType(&Hash{}, parsedHashPayload)
 	require.Equal(hashPayload, parsedHashPayload)
 
 	addressedPayload, err := NewAddressedCall(
@@ -56,5 +70,49 @@ func TestParse(t *testing.T) {
 
 	parsedAddressedPayload, err := Parse(addressedPayload.Bytes())
 	require.NoError(err)
+	require.IsType(&AddressedCall{}, parsedAddressedPayload)
 	require.Equal(addressedPayload, parsedAddressedPayload)
 }
+
+func TestPayloadTooLarge(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewAddressedCall(
+		nil,
+		make([]byte, MaxMessageSize),
+	)
+	require.ErrorIs(err, ErrMessageTooLarge)
+
+	_, err = Parse(make([]byte, MaxMessageSize+1))
+	require.ErrorIs(err, ErrMessageTooLarge)
+}
+
+func TestTypeIDs(t *testing.T) {
+	tests := []struct {
+		name           string
+		payload        Payload
+		expectedTypeID uint32
+	}{
+		{
+			name:           "Hash",
+			payload:        &Hash{},
+			expectedTypeID: HashTypeID,
+		},
+		{
+			name:           "AddressedCall",
+			payload:        &AddressedCall{},
+			expectedTypeID: AddressedCallTypeID,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			bytes, err := Codec.Marshal(CodecVersion, &test.payload)
+			require.NoError(err)
+
+			typeID := binary.BigEndian.Uint32(bytes[wrappers.ShortLen:])
+			require.Equal(test.expectedTypeID, typeID)
+		})
+	}
+}
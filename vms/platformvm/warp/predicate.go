@@ -0,0 +1,39 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import "errors"
+
+const (
+	// PredicateEndByte is appended to the bytes of a signed message to mark
+	// the end of the message within its padded predicate.
+	PredicateEndByte = byte(0xff)
+
+	// predicateAlignment is the number of bytes that a predicate is padded to
+	// a multiple of, which is the size of an EVM storage slot.
+	predicateAlignment = 32
+)
+
+var ErrUninitializedMessage = errors.New("message is not initialized")
+
+// PrecompilePredicateBytes returns [msg] encoded as the predicate that is
+// expected by the Warp precompile of EVM chains.
+//
+// The predicate is the signed message followed by [PredicateEndByte],
+// right-padded with zeros to a multiple of 32 bytes so that it can be included
+// in the storage slots of an access list.
+func PrecompilePredicateBytes(msg *Message) ([]byte, error) {
+	msgBytes := msg.Bytes()
+	if len(msgBytes) == 0 {
+		return nil, ErrUninitializedMessage
+	}
+
+	size := len(msgBytes) + 1
+	size += (predicateAlignment - size%predicateAlignment) % predicateAlignment
+
+	predicate := make([]byte, size)
+	copy(predicate, msgBytes)
+	predicate[len(msgBytes)] = PredicateEndByte
+	return predicate, nil
+}
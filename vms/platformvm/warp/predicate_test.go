@@ -0,0 +1,101 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+)
+
+func TestPrecompilePredicateBytes(t *testing.T) {
+	require := require.New(t)
+
+	unsignedMsg, err := NewUnsignedMessage(
+		constants.UnitTestID,
+		ids.ID{1, 2, 3},
+		[]byte("payload"),
+	)
+	require.NoError(err)
+
+	msg, err := NewMessage(
+		unsignedMsg,
+		&BitSetSignature{
+			Signers:   []byte{0x07},
+			Signature: [bls.SignatureLen]byte{4, 5, 6},
+		},
+	)
+	require.NoError(err)
+
+	predicate, err := PrecompilePredicateBytes(msg)
+	require.NoError(err)
+
+	// The signed message is 154 bytes, so the end byte is followed by 5 bytes
+	// of padding to fill 5 words.
+	expected := []byte{
+		// Codec version:
+		0x00, 0x00,
+		// NetworkID:
+		0x00, 0x00, 0x00, 0x0a,
+		// SourceChainID:
+		0x01, 0x02, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		// Payload length:
+		0x00, 0x00, 0x00, 0x07,
+		// Payload:
+		'p', 'a', 'y', 'l', 'o', 'a', 'd',
+		// Signature type ID:
+		0x00, 0x00, 0x00, 0x00,
+		// Signers length:
+		0x00, 0x00, 0x00, 0x01,
+		// Signers:
+		0x07,
+	}
+	// Signature:
+	expected = append(expected, 0x04, 0x05, 0x06)
+	expected = append(expected, bytes.Repeat([]byte{0x00}, bls.SignatureLen-3)...)
+	// End byte:
+	expected = append(expected, PredicateEndByte)
+	// Padding:
+	expected = append(expected, 0x00, 0x00, 0x00, 0x00, 0x00)
+
+	require.Equal(expected, predicate)
+	require.Len(predicate, 5*predicateAlignment)
+	require.Equal(msg.Bytes(), predicate[:len(msg.Bytes())])
+}
+
+func TestPrecompilePredicateBytesAlignment(t *testing.T) {
+	for payloadLen := 0; payloadLen <= predicateAlignment; payloadLen++ {
+		unsignedMsg, err := NewUnsignedMessage(
+			constants.UnitTestID,
+			ids.Empty,
+			make([]byte, payloadLen),
+		)
+		require.NoError(t, err)
+
+		msg, err := NewMessage(unsignedMsg, &BitSetSignature{})
+		require.NoError(t, err)
+
+		predicate, err := PrecompilePredicateBytes(msg)
+		require.NoError(t, err)
+
+		msgLen := len(msg.Bytes())
+		require.Zero(t, len(predicate)%predicateAlignment)
+		require.Less(t, len(predicate)-msgLen-1, predicateAlignment)
+		require.Equal(t, PredicateEndByte, predicate[msgLen])
+		require.Equal(t, make([]byte, len(predicate)-msgLen-1), predicate[msgLen+1:])
+	}
+}
+
+func TestPrecompilePredicateBytesUninitialized(t *testing.T) {
+	_, err := PrecompilePredicateBytes(&Message{})
+	require.ErrorIs(t, err, ErrUninitializedMessage)
+}
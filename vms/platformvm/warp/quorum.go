@@ -0,0 +1,150 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ava-labs/avalanchego/snow/validators"
+)
+
+var (
+	// QuorumDefault is the quorum required by the P-chain when verifying Warp
+	// messages.
+	QuorumDefault = Quorum{
+		Numerator:   67,
+		Denominator: 100,
+	}
+	// QuorumStrict is a stricter quorum for use cases that want additional
+	// security margin over [QuorumDefault].
+	QuorumStrict = Quorum{
+		Numerator:   80,
+		Denominator: 100,
+	}
+)
+
+// Quorum is the fraction of the total validator weight that must have signed a
+// message for it to be considered valid.
+type Quorum struct {
+	Numerator   uint64
+	Denominator uint64
+}
+
+// VerifyWeight returns [nil] if [sigWeight] is at least [q] of [totalWeight].
+func (q Quorum) VerifyWeight(sigWeight uint64, totalWeight uint64) error {
+	return VerifyWeight(sigWeight, totalWeight, q.Numerator, q.Denominator)
+}
+
+// RequiredWeight returns the minimum signature weight that satisfies [q] of
+// [totalWeight]. If the required weight can not be represented, or [q] has a
+// zero denominator, [math.MaxUint64] is returned.
+func (q Quorum) RequiredWeight(totalWeight uint64) uint64 {
+	if q.Denominator == 0 {
+		return math.MaxUint64
+	}
+
+	// ceil(totalWeight * numerator / denominator)
+	required := new(big.Int).SetUint64(totalWeight)
+	required.Mul(required, new(big.Int).SetUint64(q.Numerator))
+	required.Add(required, new(big.Int).SetUint64(q.Denominator-1))
+	required.Div(required, new(big.Int).SetUint64(q.Denominator))
+	if !required.IsUint64() {
+		return math.MaxUint64
+	}
+	return required.Uint64()
+}
+
+// WeightToQuorum returns the additional signature weight, on top of
+// [signedSoFar], that is required to satisfy [quorum] of [totalWeight]. If
+// [signedSoFar] already satisfies [quorum], zero is returned.
+func WeightToQuorum(signedSoFar, totalWeight uint64, quorum Quorum) uint64 {
+	required := quorum.RequiredWeight(totalWeight)
+	if signedSoFar >= required {
+		return 0
+	}
+	return required - signedSoFar
+}
+
+// QuorumResult describes how close a signature is to reaching a quorum.
+type QuorumResult struct {
+	// SignedWeight is the weight of the validators that signed the message.
+	SignedWeight uint64
+	// RequiredWeight is the minimum weight required to reach the quorum.
+	RequiredWeight uint64
+	// MissingIndices are the indices, in the canonical validator set, of the
+	// validators that did not sign the message.
+	MissingIndices []int
+}
+
+// VerifyQuorum verifies that the signature of [m] was signed by at least
+// [quorum] of the validators of [m.SourceChainID] at [pChainHeight].
+func (m *Message) VerifyQuorum(
+	ctx context.Context,
+	networkID uint32,
+	pChainState validators.State,
+	pChainHeight uint64,
+	quorum Quorum,
+) error {
+	return m.Signature.Verify(
+		ctx,
+		&m.UnsignedMessage,
+		networkID,
+		pChainState,
+		pChainHeight,
+		quorum.Numerator,
+		quorum.Denominator,
+	)
+}
+
+// VerifyQuorumWithResult verifies the signature of [m] in the same way as
+// [Message.VerifyQuorum]. Additionally, if the signers of [m] could be
+// determined, a [QuorumResult] is returned even if verification failed. This
+// allows callers to re-request signatures from the missing validators when the
+// signature has insufficient weight.
+//
+// Only [BitSetSignature]s are supported.
+func (m *Message) VerifyQuorumWithResult(
+	ctx context.Context,
+	networkID uint32,
+	pChainState validators.State,
+	pChainHeight uint64,
+	quorum Quorum,
+) (*QuorumResult, error) {
+	signature, ok := m.Signature.(*BitSetSignature)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedSignature, m.Signature)
+	}
+	vdrs, totalWeight, err := getSourceValidatorSet(ctx, &m.UnsignedMessage, networkID, pChainState, pChainHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	signerIndices, signers, err := signature.getSigners(vdrs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Because [signers] is a subset of [vdrs], this can never error.
+	signedWeight, _ := SumWeight(signers)
+	result := &QuorumResult{
+		SignedWeight:   signedWeight,
+		RequiredWeight: quorum.RequiredWeight(totalWeight),
+	}
+	for i := range vdrs {
+		if !signerIndices.Contains(i) {
+			result.MissingIndices = append(result.MissingIndices, i)
+		}
+	}
+
+	return result, signature.verify(
+		&m.UnsignedMessage,
+		vdrs,
+		totalWeight,
+		quorum.Numerator,
+		quorum.Denominator,
+	)
+}
@@ -0,0 +1,256 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/snow/validators/validatorsmock"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+func TestQuorumVerifyWeight(t *testing.T) {
+	tests := []struct {
+		name            string
+		quorum          Quorum
+		totalWeight     uint64
+		thresholdWeight uint64
+	}{
+		{
+			name:            "default",
+			quorum:          QuorumDefault,
+			totalWeight:     100,
+			thresholdWeight: 67,
+		},
+		{
+			name:            "default rounds up",
+			quorum:          QuorumDefault,
+			totalWeight:     9,
+			thresholdWeight: 7, // 6.03
+		},
+		{
+			name:            "strict",
+			quorum:          QuorumStrict,
+			totalWeight:     100,
+			thresholdWeight: 80,
+		},
+		{
+			name:            "strict rounds up",
+			quorum:          QuorumStrict,
+			totalWeight:     9,
+			thresholdWeight: 8, // 7.2
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			require.Equal(test.thresholdWeight, test.quorum.RequiredWeight(test.totalWeight))
+			require.NoError(test.quorum.VerifyWeight(test.thresholdWeight, test.totalWeight))
+			err := test.quorum.VerifyWeight(test.thresholdWeight-1, test.totalWeight)
+			require.ErrorIs(err, ErrInsufficientWeight)
+		})
+	}
+}
+
+func TestWeightToQuorum(t *testing.T) {
+	tests := []struct {
+		name        string
+		signedSoFar uint64
+		totalWeight uint64
+		quorum      Quorum
+		expected    uint64
+	}{
+		{
+			name:        "nothing signed",
+			totalWeight: 100,
+			quorum:      QuorumDefault,
+			expected:    67,
+		},
+		{
+			name:        "one below quorum",
+			signedSoFar: 66,
+			totalWeight: 100,
+			quorum:      QuorumDefault,
+			expected:    1,
+		},
+		{
+			name:        "exactly quorum",
+			signedSoFar: 67,
+			totalWeight: 100,
+			quorum:      QuorumDefault,
+			expected:    0,
+		},
+		{
+			name:        "above quorum",
+			signedSoFar: 100,
+			totalWeight: 100,
+			quorum:      QuorumDefault,
+			expected:    0,
+		},
+		{
+			name:        "rounds up",
+			signedSoFar: 6,
+			totalWeight: 9,
+			quorum:      QuorumDefault,
+			expected:    1, // 6.03
+		},
+		{
+			name:        "zero total weight",
+			totalWeight: 0,
+			quorum:      QuorumDefault,
+			expected:    0,
+		},
+		{
+			name:        "large total weight",
+			signedSoFar: 1,
+			totalWeight: math.MaxUint64,
+			quorum:      Quorum{Numerator: 1, Denominator: 1},
+			expected:    math.MaxUint64 - 1,
+		},
+		{
+			name:        "unreachable quorum",
+			signedSoFar: 5,
+			totalWeight: 10,
+			quorum:      Quorum{Numerator: 1},
+			expected:    math.MaxUint64 - 5,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			remaining := WeightToQuorum(test.signedSoFar, test.totalWeight, test.quorum)
+			require.Equal(test.expected, remaining)
+			if remaining == 0 {
+				require.NoError(test.quorum.VerifyWeight(test.signedSoFar, test.totalWeight))
+			}
+		})
+	}
+}
+
+func TestMessageVerifyQuorum(t *testing.T) {
+	tests := []struct {
+		name    string
+		quorum  Quorum
+		signers []int
+		err     error
+	}{
+		{
+			name:    "default with 2 of 3 signers",
+			quorum:  QuorumDefault,
+			signers: []int{0, 1},
+			err:     ErrInsufficientWeight,
+		},
+		{
+			name:    "default with 3 of 3 signers",
+			quorum:  QuorumDefault,
+			signers: []int{0, 1, 2},
+		},
+		{
+			name:    "strict with 3 of 3 signers",
+			quorum:  QuorumStrict,
+			signers: []int{0, 1, 2},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			state := validatorsmock.NewState(ctrl)
+			state.EXPECT().GetSubnetID(gomock.Any(), sourceChainID).Return(subnetID, nil)
+			state.EXPECT().GetValidatorSet(gomock.Any(), pChainHeight, subnetID).Return(testValidatorSet(), nil)
+
+			msg := newTestMessage(require, nil, test.signers...)
+			err := msg.VerifyQuorum(
+				context.Background(),
+				constants.UnitTestID,
+				state,
+				pChainHeight,
+				test.quorum,
+			)
+			require.ErrorIs(err, test.err)
+		})
+	}
+}
+
+func TestMessageVerifyQuorumWithResult(t *testing.T) {
+	tests := []struct {
+		name           string
+		signers        []int
+		expectedResult *QuorumResult
+		expectedErr    error
+	}{
+		{
+			name:    "1 of 3 signers",
+			signers: []int{1},
+			expectedResult: &QuorumResult{
+				SignedWeight:   3,
+				RequiredWeight: 7,
+				MissingIndices: []int{0, 2},
+			},
+			expectedErr: ErrInsufficientWeight,
+		},
+		{
+			name:    "2 of 3 signers",
+			signers: []int{0, 2},
+			expectedResult: &QuorumResult{
+				SignedWeight:   6,
+				RequiredWeight: 7,
+				MissingIndices: []int{1},
+			},
+			expectedErr: ErrInsufficientWeight,
+		},
+		{
+			name:    "3 of 3 signers",
+			signers: []int{0, 1, 2},
+			expectedResult: &QuorumResult{
+				SignedWeight:   9,
+				RequiredWeight: 7,
+			},
+		},
+		{
+			name:        "unknown signer",
+			signers:     []int{0, 1, 2, 3},
+			expectedErr: ErrUnknownValidator,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			ctrl := gomock.NewController(t)
+
+			state := validatorsmock.NewState(ctrl)
+			state.EXPECT().GetSubnetID(gomock.Any(), sourceChainID).Return(subnetID, nil)
+			state.EXPECT().GetValidatorSet(gomock.Any(), pChainHeight, subnetID).Return(testValidatorSet(), nil)
+
+			var msg *Message
+			if len(test.signers) > len(testVdrs) {
+				// Not every signer exists, so the bit set is replaced after
+				// signing.
+				msg = newTestMessage(require, nil, 0)
+				msg.Signature.(*BitSetSignature).Signers = set.NewBits(test.signers...).Bytes()
+			} else {
+				msg = newTestMessage(require, nil, test.signers...)
+			}
+
+			result, err := msg.VerifyQuorumWithResult(
+				context.Background(),
+				constants.UnitTestID,
+				state,
+				pChainHeight,
+				QuorumDefault,
+			)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedResult, result)
+		})
+	}
+}
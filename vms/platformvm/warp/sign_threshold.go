@@ -0,0 +1,124 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var ErrMismatchedWeights = errors.New("number of keys and weights differ")
+
+// thresholdSigner is a canonical validator along with the key that signs on
+// its behalf.
+type thresholdSigner struct {
+	vdr    *Validator
+	signer bls.Signer
+}
+
+// SignThreshold signs [unsignedMsg] with a minimal number of [keys] whose
+// combined weight reaches [quorum] of the total weight, where [weights][i] is
+// the weight of [keys][i]. The signers are indexed according to the canonical
+// ordering of the validator set formed by [keys] and [weights], so the
+// returned message verifies against that validator set.
+//
+// Keys that share a public key are treated as a single validator, matching
+// [FlattenValidatorSet].
+//
+// This is intended to produce quorum-meeting messages in tests.
+func SignThreshold(
+	unsignedMsg *UnsignedMessage,
+	keys []bls.Signer,
+	weights []uint64,
+	quorum Quorum,
+) (*Message, error) {
+	if len(keys) != len(weights) {
+		return nil, fmt.Errorf("%w: %d != %d", ErrMismatchedWeights, len(keys), len(weights))
+	}
+
+	var (
+		signersByPK = make(map[string]*thresholdSigner, len(keys))
+		totalWeight uint64
+		err         error
+	)
+	for i, key := range keys {
+		totalWeight, err = math.Add(totalWeight, weights[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrWeightOverflow, err)
+		}
+
+		pk := key.PublicKey()
+		pkBytes := bls.PublicKeyToUncompressedBytes(pk)
+		s, ok := signersByPK[string(pkBytes)]
+		if !ok {
+			s = &thresholdSigner{
+				vdr: &Validator{
+					PublicKey:      pk,
+					PublicKeyBytes: pkBytes,
+				},
+				signer: key,
+			}
+			signersByPK[string(pkBytes)] = s
+		}
+		s.vdr.Weight += weights[i] // Impossible to overflow here
+	}
+
+	signers := make([]*thresholdSigner, 0, len(signersByPK))
+	for _, s := range signersByPK {
+		signers = append(signers, s)
+	}
+	slices.SortFunc(signers, func(a, b *thresholdSigner) int {
+		return a.vdr.Compare(b.vdr)
+	})
+
+	// Signing with the heaviest validators first minimizes the number of
+	// signers required to reach the quorum.
+	byWeight := make([]int, len(signers))
+	for i := range byWeight {
+		byWeight[i] = i
+	}
+	slices.SortStableFunc(byWeight, func(a, b int) int {
+		return cmp.Compare(signers[b].vdr.Weight, signers[a].vdr.Weight)
+	})
+
+	var (
+		requiredWeight = quorum.RequiredWeight(totalWeight)
+		signedWeight   uint64
+		signerIndices  = set.NewBits()
+		sigs           []*bls.Signature
+		msgBytes       = unsignedMsg.Bytes()
+	)
+	for _, index := range byWeight {
+		// At least one signature is required to form a valid message, even if
+		// the required weight is zero.
+		if signedWeight >= requiredWeight && len(sigs) > 0 {
+			break
+		}
+
+		s := signers[index]
+		sig, err := bls.Sign(s.signer, msgBytes)
+		if err != nil {
+			return nil, err
+		}
+		signedWeight += s.vdr.Weight // Impossible to overflow here
+		signerIndices.Add(index)
+		sigs = append(sigs, sig)
+	}
+	if signedWeight < requiredWeight || len(sigs) == 0 {
+		return nil, fmt.Errorf("%w: %d < %d", ErrInsufficientWeight, signedWeight, requiredWeight)
+	}
+
+	aggSig, err := bls.AggregateSignatures(sigs)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMessage(unsignedMsg, NewBitSetSignature(signerIndices, aggSig))
+}
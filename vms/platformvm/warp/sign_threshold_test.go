@@ -0,0 +1,132 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+)
+
+func TestSignThreshold(t *testing.T) {
+	unsignedMsg, err := NewUnsignedMessage(
+		constants.UnitTestID,
+		ids.GenerateTestID(),
+		[]byte("payload"),
+	)
+	require.NoError(t, err)
+
+	keys := make([]bls.Signer, 3)
+	for i := range keys {
+		keys[i], err = bls.NewSigner()
+		require.NoError(t, err)
+	}
+	weights := []uint64{10, 50, 40}
+
+	vdrSet := make(map[ids.NodeID]*validators.GetValidatorOutput, len(keys))
+	for i, key := range keys {
+		nodeID := ids.GenerateTestNodeID()
+		vdrSet[nodeID] = &validators.GetValidatorOutput{
+			NodeID:    nodeID,
+			PublicKey: key.PublicKey(),
+			Weight:    weights[i],
+		}
+	}
+	vdrs, totalWeight, err := FlattenValidatorSet(vdrSet)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name               string
+		keys               []bls.Signer
+		weights            []uint64
+		quorum             Quorum
+		expectedErr        error
+		expectedNumSigners int
+		expectedVerifyErr  error
+	}{
+		{
+			name:               "default quorum",
+			keys:               keys,
+			weights:            weights,
+			quorum:             QuorumDefault,
+			expectedNumSigners: 2,
+		},
+		{
+			name:               "strict quorum",
+			keys:               keys,
+			weights:            weights,
+			quorum:             QuorumStrict,
+			expectedNumSigners: 2,
+		},
+		{
+			name:    "half quorum",
+			keys:    keys,
+			weights: weights,
+			quorum: Quorum{
+				Numerator:   1,
+				Denominator: 2,
+			},
+			expectedNumSigners: 1,
+			expectedVerifyErr:  ErrInsufficientWeight,
+		},
+		{
+			name:    "unreachable quorum",
+			keys:    keys,
+			weights: weights,
+			quorum: Quorum{
+				Numerator:   2,
+				Denominator: 1,
+			},
+			expectedErr: ErrInsufficientWeight,
+		},
+		{
+			name:        "mismatched weights",
+			keys:        keys,
+			weights:     weights[:2],
+			quorum:      QuorumDefault,
+			expectedErr: ErrMismatchedWeights,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			msg, err := SignThreshold(unsignedMsg, test.keys, test.weights, test.quorum)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			numSigners, err := msg.Signature.NumSigners()
+			require.NoError(err)
+			require.Equal(test.expectedNumSigners, numSigners)
+
+			signature, ok := msg.Signature.(*BitSetSignature)
+			require.True(ok)
+			require.NoError(signature.verify(
+				&msg.UnsignedMessage,
+				vdrs,
+				totalWeight,
+				test.quorum.Numerator,
+				test.quorum.Denominator,
+			))
+
+			// The message is only signed by enough weight to reach the
+			// requested quorum.
+			err = signature.verify(
+				&msg.UnsignedMessage,
+				vdrs,
+				totalWeight,
+				QuorumDefault.Numerator,
+				QuorumDefault.Denominator,
+			)
+			require.ErrorIs(err, test.expectedVerifyErr)
+		})
+	}
+}
@@ -9,18 +9,23 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/set"
 )
 
 var (
-	_ Signature = (*BitSetSignature)(nil)
+	_ Signature      = (*BitSetSignature)(nil)
+	_ TypedSignature = (*BitSetSignature)(nil)
 
 	ErrInvalidBitSet      = errors.New("bitset is invalid")
 	ErrInsufficientWeight = errors.New("signature weight is insufficient")
 	ErrInvalidSignature   = errors.New("signature is invalid")
 	ErrParseSignature     = errors.New("failed to parse signature")
+	ErrMissingSigner      = errors.New("required signer did not sign")
+
+	ErrUnsupportedSignature = errors.New("unsupported signature type")
 )
 
 type Signature interface {
@@ -47,6 +52,27 @@ type Signature interface {
 	) error
 }
 
+// TypedSignature is optionally implemented by a [Signature] that reports the
+// format that it is serialized with.
+type TypedSignature interface {
+	Signature
+
+	// TypeID is the ID that identifies the format of this [Signature] when it
+	// is serialized with [Codec].
+	TypeID() uint32
+}
+
+// SignatureTypeID returns the ID that identifies the format of [sig] when it
+// is serialized with [Codec]. Returns false if [sig] does not implement
+// [TypedSignature].
+func SignatureTypeID(sig Signature) (uint32, bool) {
+	typed, ok := sig.(TypedSignature)
+	if !ok {
+		return 0, false
+	}
+	return typed.TypeID(), true
+}
+
 type BitSetSignature struct {
 	// Signers is a big-endian byte slice encoding which validators signed this
 	// message.
@@ -54,6 +80,19 @@ type BitSetSignature struct {
 	Signature [bls.SignatureLen]byte `serialize:"true"`
 }
 
+// NewBitSetSignature returns a signature of the validators in [signers] whose
+// aggregate signature is [signature].
+func NewBitSetSignature(signers set.Bits, signature *bls.Signature) *BitSetSignature {
+	return &BitSetSignature{
+		Signers:   signers.Bytes(),
+		Signature: [bls.SignatureLen]byte(bls.SignatureToBytes(signature)),
+	}
+}
+
+func (*BitSetSignature) TypeID() uint32 {
+	return BitSetSignatureTypeID
+}
+
 func (s *BitSetSignature) NumSigners() (int, error) {
 	// Parse signer bit vector
 	//
@@ -67,6 +106,19 @@ func (s *BitSetSignature) NumSigners() (int, error) {
 	return signerIndices.Len(), nil
 }
 
+// SignedWeight returns the total weight of the validators in [vdrs] that are
+// marked as signers by [s]. [vdrs] must be in their canonical ordering.
+//
+// Returns an error if [s] references a validator that is not in [vdrs] or if
+// the weight overflows.
+func SignedWeight(s *BitSetSignature, vdrs []*Validator) (uint64, error) {
+	_, signers, err := s.getSigners(vdrs)
+	if err != nil {
+		return 0, err
+	}
+	return SumWeight(signers)
+}
+
 func (s *BitSetSignature) Verify(
 	ctx context.Context,
 	msg *UnsignedMessage,
@@ -76,32 +128,58 @@ func (s *BitSetSignature) Verify(
 	quorumNum uint64,
 	quorumDen uint64,
 ) error {
-	if msg.NetworkID != networkID {
-		return ErrWrongNetworkID
-	}
-
-	subnetID, err := pChainState.GetSubnetID(ctx, msg.SourceChainID)
+	vdrs, totalWeight, err := getSourceValidatorSet(ctx, msg, networkID, pChainState, pChainHeight)
 	if err != nil {
 		return err
 	}
 
-	vdrs, totalWeight, err := GetCanonicalValidatorSet(ctx, pChainState, pChainHeight, subnetID)
-	if err != nil {
-		return err
+	return s.verify(msg, vdrs, totalWeight, quorumNum, quorumDen)
+}
+
+// VerifyValidators verifies that this signature of [msg] was signed by at
+// least [quorum] of [totalWeight] by [vdrs], which must be in their canonical
+// ordering. This allows a message to be verified against a validator set that
+// is known without querying the P-chain.
+func (s *BitSetSignature) VerifyValidators(
+	msg *UnsignedMessage,
+	vdrs []*Validator,
+	totalWeight uint64,
+	quorum Quorum,
+) error {
+	return s.verify(msg, vdrs, totalWeight, quorum.Numerator, quorum.Denominator)
+}
+
+// getSourceValidatorSet returns the canonical validator set, at
+// [pChainHeight], of the subnet that validates the source chain of [msg]. An
+// error is returned if [msg] was not sent on [networkID].
+func getSourceValidatorSet(
+	ctx context.Context,
+	msg *UnsignedMessage,
+	networkID uint32,
+	pChainState validators.State,
+	pChainHeight uint64,
+) ([]*Validator, uint64, error) {
+	if msg.NetworkID != networkID {
+		return nil, 0, ErrWrongNetworkID
 	}
 
-	// Parse signer bit vector
-	//
-	// We assert that the length of [signerIndices.Bytes()] is equal
-	// to [len(s.Signers)] to ensure that [s.Signers] does not have
-	// any unnecessary zero-padding to represent the [set.Bits].
-	signerIndices := set.BitsFromBytes(s.Signers)
-	if len(signerIndices.Bytes()) != len(s.Signers) {
-		return ErrInvalidBitSet
+	subnetID, err := pChainState.GetSubnetID(ctx, msg.SourceChainID)
+	if err != nil {
+		return nil, 0, err
 	}
+	return GetCanonicalValidatorSet(ctx, pChainState, pChainHeight, subnetID)
+}
 
-	// Get the validators that (allegedly) signed the message.
-	signers, err := FilterValidators(signerIndices, vdrs)
+// verify that this signature was signed by at least [quorumNum]/[quorumDen]
+// of [totalWeight] by [vdrs], which must be in their canonical ordering.
+func (s *BitSetSignature) verify(
+	msg *UnsignedMessage,
+	vdrs []*Validator,
+	totalWeight uint64,
+	quorumNum uint64,
+	quorumDen uint64,
+) error {
+	_, signers, err := s.getSigners(vdrs)
 	if err != nil {
 		return err
 	}
@@ -140,6 +218,23 @@ func (s *BitSetSignature) Verify(
 	return nil
 }
 
+// getSigners returns the indices of the validators in [vdrs] that
+// (allegedly) signed the message, along with the validators themselves.
+func (s *BitSetSignature) getSigners(vdrs []*Validator) (set.Bits, []*Validator, error) {
+	// Parse signer bit vector
+	//
+	// We assert that the length of [signerIndices.Bytes()] is equal
+	// to [len(s.Signers)] to ensure that [s.Signers] does not have
+	// any unnecessary zero-padding to represent the [set.Bits].
+	signerIndices := set.BitsFromBytes(s.Signers)
+	if len(signerIndices.Bytes()) != len(s.Signers) {
+		return set.Bits{}, nil, ErrInvalidBitSet
+	}
+
+	signers, err := FilterValidators(signerIndices, vdrs)
+	return signerIndices, signers, err
+}
+
 func (s *BitSetSignature) String() string {
 	return fmt.Sprintf("BitSetSignature(Signers = %x, Signature = %x)", s.Signers, s.Signature)
 }
@@ -170,3 +265,74 @@ func VerifyWeight(
 	}
 	return nil
 }
+
+// VerifyPrecomputed verifies that [sig] is a signature of [msg] by [aggPubKey]
+// and that [totalSignedWeight] is at least [quorum] of [totalWeight].
+//
+// [aggPubKey] and [totalSignedWeight] are expected to be the aggregate public
+// key and the summed weight of the signers. Callers that repeatedly verify
+// messages signed by the same signers can cache these values rather than
+// re-aggregating them for every message.
+func VerifyPrecomputed(
+	msg *UnsignedMessage,
+	networkID uint32,
+	aggPubKey *bls.PublicKey,
+	totalSignedWeight uint64,
+	quorum Quorum,
+	totalWeight uint64,
+	sig *bls.Signature,
+) error {
+	if msg.NetworkID != networkID {
+		return ErrWrongNetworkID
+	}
+
+	if err := quorum.VerifyWeight(totalSignedWeight, totalWeight); err != nil {
+		return err
+	}
+
+	if !bls.Verify(aggPubKey, sig, msg.Bytes()) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// ValidatorIndices returns the index of each nodeID of [vdrs] in the canonical
+// ordering. Validators that share a public key are merged into a single
+// canonical validator, so multiple nodeIDs may map to the same index.
+func ValidatorIndices(vdrs []*Validator) map[ids.NodeID]int {
+	indices := make(map[ids.NodeID]int, len(vdrs))
+	for i, vdr := range vdrs {
+		for _, nodeID := range vdr.NodeIDs {
+			indices[nodeID] = i
+		}
+	}
+	return indices
+}
+
+// VerifyRequiredSigners verifies that every node in [required] is included in
+// the signers of [s], where [indexOf] maps each nodeID to its index in the
+// canonical validator set. See [ValidatorIndices].
+//
+// The signature and the weight of the signers are not verified, so this should
+// be used in addition to verifying the signature.
+func VerifyRequiredSigners(
+	s *BitSetSignature,
+	required []ids.NodeID,
+	indexOf map[ids.NodeID]int,
+) error {
+	signerIndices := set.BitsFromBytes(s.Signers)
+	if len(signerIndices.Bytes()) != len(s.Signers) {
+		return ErrInvalidBitSet
+	}
+
+	for _, nodeID := range required {
+		index, ok := indexOf[nodeID]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownValidator, nodeID)
+		}
+		if !signerIndices.Contains(index) {
+			return fmt.Errorf("%w: %s at index %d", ErrMissingSigner, nodeID, index)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var (
+	ErrDuplicateSigner = errors.New("duplicate signer")
+	ErrNoSignatures    = errors.New("no signatures")
+)
+
+// SignatureAggregator collects signatures of an unsigned message from the
+// validators of its source chain and tracks the weight that has signed the
+// message.
+//
+// SignatureAggregator is safe for concurrent use.
+type SignatureAggregator struct {
+	msg         *UnsignedMessage
+	vdrs        []*Validator
+	totalWeight uint64
+	quorumNum   uint64
+	quorumDen   uint64
+
+	lock       sync.Mutex
+	signers    set.Bits
+	signatures []*bls.Signature
+	weight     uint64
+}
+
+// NewSignatureAggregator returns an aggregator of signatures of [msg] from
+// [vdrs], which must be in their canonical ordering. Quorum is reached once
+// [quorumNum]/[quorumDen] of [totalWeight] has signed [msg].
+func NewSignatureAggregator(
+	msg *UnsignedMessage,
+	vdrs []*Validator,
+	totalWeight uint64,
+	quorumNum uint64,
+	quorumDen uint64,
+) *SignatureAggregator {
+	return &SignatureAggregator{
+		msg:         msg,
+		vdrs:        vdrs,
+		totalWeight: totalWeight,
+		quorumNum:   quorumNum,
+		quorumDen:   quorumDen,
+		signers:     set.NewBits(),
+	}
+}
+
+// AddSignature verifies that [sig] is a signature of the message by the
+// validator at [index] in the canonical ordering and adds it to the aggregate
+// signature. Signatures may be added after quorum has been reached.
+func (a *SignatureAggregator) AddSignature(index int, sig *bls.Signature) error {
+	if index < 0 || index >= len(a.vdrs) {
+		return fmt.Errorf("%w: index %d is out of range [0, %d)",
+			ErrUnknownValidator,
+			index,
+			len(a.vdrs),
+		)
+	}
+
+	vdr := a.vdrs[index]
+	if !bls.Verify(vdr.PublicKey, sig, a.msg.Bytes()) {
+		return fmt.Errorf("%w: from validator %d", ErrInvalidSignature, index)
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if a.signers.Contains(index) {
+		return fmt.Errorf("%w: %d", ErrDuplicateSigner, index)
+	}
+
+	weight, err := math.Add(a.weight, vdr.Weight)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrWeightOverflow, err)
+	}
+
+	a.signers.Add(index)
+	a.signatures = append(a.signatures, sig)
+	a.weight = weight
+	return nil
+}
+
+// WeightSoFar returns the weight of the validators whose signatures have been
+// added.
+func (a *SignatureAggregator) WeightSoFar() uint64 {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.weight
+}
+
+// QuorumReached returns true if the weight of the validators whose signatures
+// have been added is at least the configured quorum.
+func (a *SignatureAggregator) QuorumReached() bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return VerifyWeight(a.weight, a.totalWeight, a.quorumNum, a.quorumDen) == nil
+}
+
+// Message returns the signed message using all the signatures that have been
+// added. An error is returned if quorum has not been reached.
+func (a *SignatureAggregator) Message() (*Message, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if len(a.signatures) == 0 {
+		return nil, ErrNoSignatures
+	}
+	if err := VerifyWeight(a.weight, a.totalWeight, a.quorumNum, a.quorumDen); err != nil {
+		return nil, err
+	}
+
+	signature, err := a.signature()
+	if err != nil {
+		return nil, err
+	}
+	return NewMessage(a.msg, signature)
+}
+
+// Collect requests signatures from the validators at [indices] in the canonical
+// ordering concurrently, and adds them to the aggregator. Collect returns the
+// aggregate signature as soon as quorum is reached.
+//
+// A failed request, or an invalid signature, does not stop the collection. If
+// every request finishes, or [ctx] is cancelled, before quorum is reached, an
+// error reporting the weight that was collected is returned. The context passed
+// to [request] is cancelled once Collect returns.
+func (a *SignatureAggregator) Collect(
+	ctx context.Context,
+	request func(ctx context.Context, index int) (*bls.Signature, error),
+	indices []int,
+) (*BitSetSignature, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// [results] is buffered so that requests finishing after Collect returns
+	// don't block.
+	results := make(chan error, len(indices))
+	for _, index := range indices {
+		go func(index int) {
+			sig, err := request(ctx, index)
+			if err != nil {
+				results <- fmt.Errorf("failed to request signature from validator %d: %w", index, err)
+				return
+			}
+			results <- a.AddSignature(index, sig)
+		}(index)
+	}
+
+	var errs []error
+	for remaining := len(indices); !a.QuorumReached(); remaining-- {
+		if remaining == 0 {
+			return nil, a.insufficientWeightError(errors.Join(errs...))
+		}
+
+		select {
+		case err := <-results:
+			if err != nil {
+				errs = append(errs, err)
+			}
+		case <-ctx.Done():
+			return nil, a.insufficientWeightError(errors.Join(append(errs, ctx.Err())...))
+		}
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	return a.signature()
+}
+
+// insufficientWeightError reports the weight collected so far, along with the
+// [cause], if any, of quorum not being reached.
+func (a *SignatureAggregator) insufficientWeightError(cause error) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	quorum := Quorum{
+		Numerator:   a.quorumNum,
+		Denominator: a.quorumDen,
+	}
+	err := fmt.Errorf("%w: collected %d of the required %d weight from %d signers",
+		ErrInsufficientWeight,
+		a.weight,
+		quorum.RequiredWeight(a.totalWeight),
+		len(a.signatures),
+	)
+	if cause == nil {
+		return err
+	}
+	return fmt.Errorf("%w: %w", err, cause)
+}
+
+// signature returns the aggregate signature of all the signatures that have
+// been added.
+//
+// Assumes [a.lock] is held.
+func (a *SignatureAggregator) signature() (*BitSetSignature, error) {
+	aggSig, err := bls.AggregateSignatures(a.signatures)
+	if err != nil {
+		return nil, err
+	}
+	return NewBitSetSignature(a.signers, aggSig), nil
+}
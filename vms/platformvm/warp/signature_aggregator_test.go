@@ -0,0 +1,255 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+func TestSignatureAggregatorQuorum(t *testing.T) {
+	require := require.New(t)
+
+	testVdrs := []*testValidator{
+		newTestValidator(),
+		newTestValidator(),
+		newTestValidator(),
+	}
+	utils.Sort(testVdrs)
+
+	weights := []uint64{10, 20, 30}
+	vdrs := make([]*Validator, len(testVdrs))
+	for i, testVdr := range testVdrs {
+		vdr := *testVdr.vdr
+		vdr.Weight = weights[i]
+		vdrs[i] = &vdr
+	}
+
+	msg, err := NewUnsignedMessage(
+		constants.UnitTestID,
+		sourceChainID,
+		[]byte("payload"),
+	)
+	require.NoError(err)
+
+	// Quorum requires 67% of the total weight of 60, which is 40.2.
+	aggregator := NewSignatureAggregator(msg, vdrs, 60, 67, 100)
+	require.Zero(aggregator.WeightSoFar())
+	require.False(aggregator.QuorumReached())
+
+	_, err = aggregator.Message()
+	require.ErrorIs(err, ErrNoSignatures)
+
+	require.NoError(aggregator.AddSignature(2, testVdrs[2].sk.Sign(msg.Bytes())))
+	require.Equal(uint64(30), aggregator.WeightSoFar())
+	require.False(aggregator.QuorumReached())
+
+	require.NoError(aggregator.AddSignature(0, testVdrs[0].sk.Sign(msg.Bytes())))
+	require.Equal(uint64(40), aggregator.WeightSoFar())
+	require.False(aggregator.QuorumReached())
+
+	_, err = aggregator.Message()
+	require.ErrorIs(err, ErrInsufficientWeight)
+
+	require.NoError(aggregator.AddSignature(1, testVdrs[1].sk.Sign(msg.Bytes())))
+	require.Equal(uint64(60), aggregator.WeightSoFar())
+	require.True(aggregator.QuorumReached())
+
+	signedMsg, err := aggregator.Message()
+	require.NoError(err)
+
+	sig, ok := signedMsg.Signature.(*BitSetSignature)
+	require.True(ok)
+	require.Equal(set.NewBits(0, 1, 2).Bytes(), sig.Signers)
+
+	aggSig, err := bls.SignatureFromBytes(sig.Signature[:])
+	require.NoError(err)
+	aggPK, err := AggregatePublicKeys(vdrs)
+	require.NoError(err)
+	require.True(bls.Verify(aggPK, aggSig, msg.Bytes()))
+}
+
+func TestSignatureAggregatorQuorumExactlyAtThreshold(t *testing.T) {
+	require := require.New(t)
+
+	testVdrs := []*testValidator{
+		newTestValidator(),
+		newTestValidator(),
+	}
+	utils.Sort(testVdrs)
+
+	vdrs := make([]*Validator, len(testVdrs))
+	for i, testVdr := range testVdrs {
+		vdr := *testVdr.vdr
+		vdr.Weight = 1
+		vdrs[i] = &vdr
+	}
+
+	msg, err := NewUnsignedMessage(
+		constants.UnitTestID,
+		sourceChainID,
+		[]byte("payload"),
+	)
+	require.NoError(err)
+
+	// Quorum requires exactly half of the total weight of 2.
+	aggregator := NewSignatureAggregator(msg, vdrs, 2, 1, 2)
+	require.False(aggregator.QuorumReached())
+
+	require.NoError(aggregator.AddSignature(0, testVdrs[0].sk.Sign(msg.Bytes())))
+	require.True(aggregator.QuorumReached())
+
+	// Adding a signer past quorum is allowed.
+	require.NoError(aggregator.AddSignature(1, testVdrs[1].sk.Sign(msg.Bytes())))
+	require.True(aggregator.QuorumReached())
+	require.Equal(uint64(2), aggregator.WeightSoFar())
+
+	signedMsg, err := aggregator.Message()
+	require.NoError(err)
+
+	sig, ok := signedMsg.Signature.(*BitSetSignature)
+	require.True(ok)
+	require.Equal(set.NewBits(0, 1).Bytes(), sig.Signers)
+}
+
+func TestSignatureAggregatorAddSignatureErrors(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := make([]*Validator, len(testVdrs))
+	for i, testVdr := range testVdrs {
+		vdrs[i] = testVdr.vdr
+	}
+
+	msg, err := NewUnsignedMessage(
+		constants.UnitTestID,
+		sourceChainID,
+		[]byte("payload"),
+	)
+	require.NoError(err)
+
+	aggregator := NewSignatureAggregator(msg, vdrs, 9, 2, 3)
+
+	err = aggregator.AddSignature(len(vdrs), testVdrs[0].sk.Sign(msg.Bytes()))
+	require.ErrorIs(err, ErrUnknownValidator)
+
+	err = aggregator.AddSignature(0, testVdrs[1].sk.Sign(msg.Bytes()))
+	require.ErrorIs(err, ErrInvalidSignature)
+
+	require.NoError(aggregator.AddSignature(0, testVdrs[0].sk.Sign(msg.Bytes())))
+	err = aggregator.AddSignature(0, testVdrs[0].sk.Sign(msg.Bytes()))
+	require.ErrorIs(err, ErrDuplicateSigner)
+	require.Equal(uint64(3), aggregator.WeightSoFar())
+}
+
+func TestSignatureAggregatorCollect(t *testing.T) {
+	testVdrs := []*testValidator{
+		newTestValidator(),
+		newTestValidator(),
+		newTestValidator(),
+		newTestValidator(),
+	}
+	utils.Sort(testVdrs)
+
+	vdrs := make([]*Validator, len(testVdrs))
+	for i, testVdr := range testVdrs {
+		vdrs[i] = testVdr.vdr
+	}
+	totalWeight, err := SumWeight(vdrs)
+	require.NoError(t, err)
+
+	msg, err := NewUnsignedMessage(
+		constants.UnitTestID,
+		sourceChainID,
+		[]byte("payload"),
+	)
+	require.NoError(t, err)
+
+	sign := func(_ context.Context, index int) (*bls.Signature, error) {
+		return testVdrs[index].sk.Sign(msg.Bytes()), nil
+	}
+	fail := func(context.Context, int) (*bls.Signature, error) {
+		return nil, errTest
+	}
+	hang := func(ctx context.Context, _ int) (*bls.Signature, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	tests := []struct {
+		name            string
+		requests        []func(context.Context, int) (*bls.Signature, error)
+		timeout         time.Duration
+		expectedSigners set.Bits
+		expectedErr     error
+		expectedCause   error
+	}{
+		{
+			name:            "quorum with a failed request",
+			requests:        []func(context.Context, int) (*bls.Signature, error){sign, fail, sign, sign},
+			expectedSigners: set.NewBits(0, 2, 3),
+		},
+		{
+			name:            "quorum with a hanging request",
+			requests:        []func(context.Context, int) (*bls.Signature, error){sign, hang, sign, sign},
+			expectedSigners: set.NewBits(0, 2, 3),
+		},
+		{
+			name:          "timeout before quorum",
+			requests:      []func(context.Context, int) (*bls.Signature, error){sign, hang, hang, sign},
+			timeout:       10 * time.Millisecond,
+			expectedErr:   ErrInsufficientWeight,
+			expectedCause: context.DeadlineExceeded,
+		},
+		{
+			name:          "all requests finished before quorum",
+			requests:      []func(context.Context, int) (*bls.Signature, error){sign, fail, fail, sign},
+			expectedErr:   ErrInsufficientWeight,
+			expectedCause: errTest,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctx := context.Background()
+			if test.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, test.timeout)
+				defer cancel()
+			}
+
+			aggregator := NewSignatureAggregator(msg, vdrs, totalWeight, QuorumDefault.Numerator, QuorumDefault.Denominator)
+			sig, err := aggregator.Collect(
+				ctx,
+				func(ctx context.Context, index int) (*bls.Signature, error) {
+					return test.requests[index](ctx, index)
+				},
+				[]int{0, 1, 2, 3},
+			)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				require.ErrorIs(err, test.expectedCause)
+				return
+			}
+
+			require.Equal(test.expectedSigners.Bytes(), sig.Signers)
+
+			aggSig, err := bls.SignatureFromBytes(sig.Signature[:])
+			require.NoError(err)
+			signers, err := FilterValidators(test.expectedSigners, vdrs)
+			require.NoError(err)
+			aggPK, err := AggregatePublicKeys(signers)
+			require.NoError(err)
+			require.True(bls.Verify(aggPK, aggSig, msg.Bytes()))
+		})
+	}
+}
@@ -5,8 +5,10 @@ package warp
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"math"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -19,6 +21,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
 const pChainHeight uint64 = 1337
@@ -135,6 +138,69 @@ func TestNumSigners(t *testing.T) {
 	}
 }
 
+func TestSignedWeight(t *testing.T) {
+	vdrs := []*Validator{
+		{Weight: 1},
+		{Weight: 2},
+		{Weight: 4},
+		{Weight: math.MaxUint64},
+	}
+	tests := []struct {
+		name           string
+		signers        []int
+		signersBytes   []byte
+		expectedWeight uint64
+		expectedErr    error
+	}{
+		{
+			name: "no signers",
+		},
+		{
+			name:           "some signers",
+			signers:        []int{0, 2},
+			expectedWeight: 5,
+		},
+		{
+			name:           "all but the heaviest",
+			signers:        []int{0, 1, 2},
+			expectedWeight: 7,
+		},
+		{
+			name:         "padded bitset",
+			signersBytes: []byte{0x00, 0x01},
+			expectedErr:  ErrInvalidBitSet,
+		},
+		{
+			name:        "unknown validator",
+			signers:     []int{4},
+			expectedErr: ErrUnknownValidator,
+		},
+		{
+			name:        "overflow",
+			signers:     []int{0, 3},
+			expectedErr: ErrWeightOverflow,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			signersBytes := test.signersBytes
+			if signersBytes == nil {
+				signersBytes = set.NewBits(test.signers...).Bytes()
+			}
+			weight, err := SignedWeight(
+				&BitSetSignature{
+					Signers: signersBytes,
+				},
+				vdrs,
+			)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedWeight, weight)
+		})
+	}
+}
+
 func TestSignatureVerification(t *testing.T) {
 	vdrs := map[ids.NodeID]*validators.GetValidatorOutput{
 		testVdrs[0].nodeID: {
@@ -844,3 +910,245 @@ func TestSignatureVerification(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyPrecomputed(t *testing.T) {
+	msg := newTestMessage(require.New(t), []byte{1, 2, 3}, 0, 1, 2)
+	bitSetSig, ok := msg.Signature.(*BitSetSignature)
+	require.True(t, ok)
+	sig, err := bls.SignatureFromBytes(bitSetSig.Signature[:])
+	require.NoError(t, err)
+
+	vdrs := []*Validator{
+		testVdrs[0].vdr,
+		testVdrs[1].vdr,
+		testVdrs[2].vdr,
+	}
+	aggPubKey, err := AggregatePublicKeys(vdrs)
+	require.NoError(t, err)
+	totalWeight, err := SumWeight(vdrs)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name              string
+		networkID         uint32
+		aggPubKey         *bls.PublicKey
+		totalSignedWeight uint64
+		expectedErr       error
+	}{
+		{
+			name:              "valid",
+			networkID:         constants.UnitTestID,
+			aggPubKey:         aggPubKey,
+			totalSignedWeight: totalWeight,
+		},
+		{
+			name:              "wrong network ID",
+			networkID:         constants.UnitTestID + 1,
+			aggPubKey:         aggPubKey,
+			totalSignedWeight: totalWeight,
+			expectedErr:       ErrWrongNetworkID,
+		},
+		{
+			name:              "insufficient weight",
+			networkID:         constants.UnitTestID,
+			aggPubKey:         aggPubKey,
+			totalSignedWeight: totalWeight / 2,
+			expectedErr:       ErrInsufficientWeight,
+		},
+		{
+			name:              "wrong public key",
+			networkID:         constants.UnitTestID,
+			aggPubKey:         testVdrs[0].vdr.PublicKey,
+			totalSignedWeight: totalWeight,
+			expectedErr:       ErrInvalidSignature,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			err := VerifyPrecomputed(
+				&msg.UnsignedMessage,
+				test.networkID,
+				test.aggPubKey,
+				test.totalSignedWeight,
+				QuorumDefault,
+				totalWeight,
+				sig,
+			)
+			require.ErrorIs(err, test.expectedErr)
+		})
+	}
+}
+
+func BenchmarkVerifyPrecomputed(b *testing.B) {
+	for _, size := range []int{1, 10, 100, 1_000} {
+		vdrs := make([]*Validator, size)
+		sks := make([]bls.Signer, size)
+		for i := range vdrs {
+			sk, err := bls.NewSigner()
+			require.NoError(b, err)
+
+			pk := sk.PublicKey()
+			sks[i] = sk
+			vdrs[i] = &Validator{
+				PublicKey:      pk,
+				PublicKeyBytes: bls.PublicKeyToUncompressedBytes(pk),
+				Weight:         1,
+				NodeIDs:        []ids.NodeID{ids.GenerateTestNodeID()},
+			}
+		}
+		utils.Sort(vdrs)
+
+		unsignedMsg, err := NewUnsignedMessage(
+			constants.UnitTestID,
+			ids.GenerateTestID(),
+			[]byte{1, 2, 3},
+		)
+		require.NoError(b, err)
+
+		var (
+			unsignedBytes = unsignedMsg.Bytes()
+			signers       = set.NewBits()
+			sigs          = make([]*bls.Signature, size)
+		)
+		for i, sk := range sks {
+			signers.Add(i)
+			sigs[i] = sk.Sign(unsignedBytes)
+		}
+		aggSig, err := bls.AggregateSignatures(sigs)
+		require.NoError(b, err)
+
+		bitSetSig := &BitSetSignature{
+			Signers:   signers.Bytes(),
+			Signature: [bls.SignatureLen]byte(bls.SignatureToBytes(aggSig)),
+		}
+		totalWeight := uint64(size)
+
+		b.Run("full/"+strconv.Itoa(size), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				require.NoError(b, bitSetSig.verify(
+					unsignedMsg,
+					vdrs,
+					totalWeight,
+					QuorumDefault.Numerator,
+					QuorumDefault.Denominator,
+				))
+			}
+		})
+
+		aggPubKey, err := AggregatePublicKeys(vdrs)
+		require.NoError(b, err)
+
+		b.Run("precomputed/"+strconv.Itoa(size), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				require.NoError(b, VerifyPrecomputed(
+					unsignedMsg,
+					constants.UnitTestID,
+					aggPubKey,
+					totalWeight,
+					QuorumDefault,
+					totalWeight,
+					aggSig,
+				))
+			}
+		})
+	}
+}
+
+func TestVerifyRequiredSigners(t *testing.T) {
+	vdrs := []*Validator{
+		testVdrs[0].vdr,
+		testVdrs[1].vdr,
+		testVdrs[2].vdr,
+	}
+	indexOf := ValidatorIndices(vdrs)
+
+	tests := []struct {
+		name        string
+		signers     set.Bits
+		required    []ids.NodeID
+		expectedErr error
+	}{
+		{
+			name:    "no required signers",
+			signers: set.NewBits(0),
+		},
+		{
+			name:    "required signers signed",
+			signers: set.NewBits(0, 2),
+			required: []ids.NodeID{
+				testVdrs[0].nodeID,
+				testVdrs[2].nodeID,
+			},
+		},
+		{
+			name:    "required signer absent",
+			signers: set.NewBits(0, 2),
+			required: []ids.NodeID{
+				testVdrs[0].nodeID,
+				testVdrs[1].nodeID,
+			},
+			expectedErr: ErrMissingSigner,
+		},
+		{
+			name:    "unknown required signer",
+			signers: set.NewBits(0, 1, 2),
+			required: []ids.NodeID{
+				ids.GenerateTestNodeID(),
+			},
+			expectedErr: ErrUnknownValidator,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sig := &BitSetSignature{
+				Signers: test.signers.Bytes(),
+			}
+			err := VerifyRequiredSigners(sig, test.required, indexOf)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+
+	t.Run("padded bitset", func(t *testing.T) {
+		sig := &BitSetSignature{
+			Signers: []byte{0x00, 0x01},
+		}
+		err := VerifyRequiredSigners(sig, nil, indexOf)
+		require.ErrorIs(t, err, ErrInvalidBitSet)
+	})
+}
+
+func TestValidatorIndices(t *testing.T) {
+	require := require.New(t)
+
+	nodeID := ids.GenerateTestNodeID()
+	vdrs := []*Validator{
+		testVdrs[0].vdr,
+		{
+			PublicKey:      testVdrs[1].vdr.PublicKey,
+			PublicKeyBytes: testVdrs[1].vdr.PublicKeyBytes,
+			Weight:         testVdrs[1].vdr.Weight,
+			NodeIDs:        []ids.NodeID{testVdrs[1].nodeID, nodeID},
+		},
+	}
+	require.Equal(
+		map[ids.NodeID]int{
+			testVdrs[0].nodeID: 0,
+			testVdrs[1].nodeID: 1,
+			nodeID:             1,
+		},
+		ValidatorIndices(vdrs),
+	)
+}
+
+func TestBitSetSignatureTypeID(t *testing.T) {
+	require := require.New(t)
+
+	var signature Signature = &BitSetSignature{}
+	bytes, err := Codec.Marshal(CodecVersion, &signature)
+	require.NoError(err)
+
+	typeID := binary.BigEndian.Uint32(bytes[wrappers.ShortLen:])
+	require.Equal(BitSetSignatureTypeID, typeID)
+}
@@ -28,3 +28,20 @@ func TestSigner(t *testing.T) {
 		})
 	}
 }
+
+func TestSignerCleared(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSigner()
+	require.NoError(err)
+
+	chainID := ids.GenerateTestID()
+	s := warp.NewSigner(sk, constants.UnitTestID, chainID)
+
+	msg, err := warp.NewUnsignedMessage(constants.UnitTestID, chainID, []byte("payload"))
+	require.NoError(err)
+
+	sk.Clear()
+	_, err = s.Sign(msg)
+	require.ErrorIs(err, bls.ErrSignerCleared)
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+var ErrWrongSubnetID = errors.New("wrong subnetID")
+
+// SubnetClient reports which subnet validates a chain. It is implemented by
+// the platformvm client.
+type SubnetClient interface {
+	// ValidatedBy returns the ID of the subnet that validates [blockchainID].
+	ValidatedBy(ctx context.Context, blockchainID ids.ID, options ...rpc.Option) (ids.ID, error)
+}
+
+// VerifySourceChainInSubnet verifies that the source chain of [msg] is
+// validated by [subnetID], according to [client]. This prevents accepting a
+// message from an expected chain ID that is validated by an unexpected subnet.
+func VerifySourceChainInSubnet(
+	ctx context.Context,
+	client SubnetClient,
+	msg *Message,
+	subnetID ids.ID,
+) error {
+	sourceSubnetID, err := client.ValidatedBy(ctx, msg.SourceChainID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch subnet of chain %s: %w", msg.SourceChainID, err)
+	}
+	if sourceSubnetID != subnetID {
+		return fmt.Errorf("%w: chain %s is validated by %s, expected %s",
+			ErrWrongSubnetID,
+			msg.SourceChainID,
+			sourceSubnetID,
+			subnetID,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+type subnetClient struct {
+	subnetID ids.ID
+	err      error
+}
+
+func (c *subnetClient) ValidatedBy(context.Context, ids.ID, ...rpc.Option) (ids.ID, error) {
+	return c.subnetID, c.err
+}
+
+func TestVerifySourceChainInSubnet(t *testing.T) {
+	tests := []struct {
+		name        string
+		client      *subnetClient
+		expectedErr error
+	}{
+		{
+			name: "expected subnet",
+			client: &subnetClient{
+				subnetID: subnetID,
+			},
+		},
+		{
+			name: "other subnet",
+			client: &subnetClient{
+				subnetID: ids.GenerateTestID(),
+			},
+			expectedErr: ErrWrongSubnetID,
+		},
+		{
+			name: "lookup fails",
+			client: &subnetClient{
+				err: errTest,
+			},
+			expectedErr: errTest,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			msg := newTestMessage(require.New(t), nil, 0)
+			err := VerifySourceChainInSubnet(
+				context.Background(),
+				test.client,
+				msg,
+				subnetID,
+			)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+// streamVerifierCacheSize is the number of validator sets cached by a
+// StreamVerifier. Validator sets are keyed by height, so the sets at previous
+// heights are evicted as the height is refreshed.
+const streamVerifierCacheSize = 256
+
+// StreamVerifier verifies a sequence of Warp messages against the canonical
+// validator sets of their source subnets.
+//
+// The canonical validator sets are fetched at the current P-chain height and
+// reused across calls to Verify until [refreshInterval] has elapsed, at which
+// point the P-chain height is refreshed.
+//
+// StreamVerifier is safe for concurrent use.
+type StreamVerifier struct {
+	networkID       uint32
+	pChainState     validators.State
+	quorum          Quorum
+	refreshInterval time.Duration
+	clock           mockable.Clock
+	// vdrSets caches the canonical validator sets of subnets by height.
+	vdrSets *ValidatorSetCache
+
+	lock sync.Mutex
+	// refreshed is the time that [height] was last fetched.
+	refreshed time.Time
+	// height is the P-chain height that validator sets are fetched at.
+	height uint64
+	// subnetIDs maps chainID to the subnetID that validates the chain.
+	subnetIDs map[ids.ID]ids.ID
+}
+
+// NewStreamVerifier returns a verifier of messages sent on [networkID] that
+// requires [quorum] of the validators of the source subnet to have signed each
+// message. Cached validator sets are refreshed every [refreshInterval].
+func NewStreamVerifier(
+	networkID uint32,
+	pChainState validators.State,
+	quorum Quorum,
+	refreshInterval time.Duration,
+) *StreamVerifier {
+	return &StreamVerifier{
+		networkID:       networkID,
+		pChainState:     pChainState,
+		quorum:          quorum,
+		refreshInterval: refreshInterval,
+		// Validator sets at a height never change, so they don't expire.
+		vdrSets:   NewValidatorSetCache(pChainState, streamVerifierCacheSize, 0),
+		subnetIDs: make(map[ids.ID]ids.ID),
+	}
+}
+
+// Verify that [msg] was signed by at least the configured quorum of the
+// validators of its source subnet at [Height].
+func (v *StreamVerifier) Verify(ctx context.Context, msg *Message) error {
+	if msg.NetworkID != v.networkID {
+		return ErrWrongNetworkID
+	}
+
+	signature, ok := msg.Signature.(*BitSetSignature)
+	if !ok {
+		return fmt.Errorf("%w: %T", ErrUnsupportedSignature, msg.Signature)
+	}
+
+	height, subnetID, err := v.getSubnetID(ctx, msg.SourceChainID)
+	if err != nil {
+		return err
+	}
+	vdrs, totalWeight, err := v.vdrSets.Get(ctx, subnetID, height)
+	if err != nil {
+		return err
+	}
+
+	return signature.verify(
+		&msg.UnsignedMessage,
+		vdrs,
+		totalWeight,
+		v.quorum.Numerator,
+		v.quorum.Denominator,
+	)
+}
+
+// Height returns the P-chain height of the validator sets that messages are
+// currently verified against. If no message has been verified yet, 0 is
+// returned.
+func (v *StreamVerifier) Height() uint64 {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	return v.height
+}
+
+// getSubnetID returns the P-chain height that validator sets are currently
+// fetched at, refreshing it if needed, along with the subnetID that validates
+// [chainID].
+//
+// The lock is not held while the P-chain is queried, so that a slow query
+// does not block other callers.
+func (v *StreamVerifier) getSubnetID(
+	ctx context.Context,
+	chainID ids.ID,
+) (uint64, ids.ID, error) {
+	v.lock.Lock()
+	var (
+		now             = v.clock.Time()
+		refreshHeight   = v.refreshed.IsZero() || now.Sub(v.refreshed) >= v.refreshInterval
+		height          = v.height
+		subnetID, known = v.subnetIDs[chainID]
+	)
+	v.lock.Unlock()
+
+	if refreshHeight {
+		var err error
+		height, err = v.pChainState.GetCurrentHeight(ctx)
+		if err != nil {
+			return 0, ids.Empty, err
+		}
+	}
+	if !known {
+		var err error
+		subnetID, err = v.pChainState.GetSubnetID(ctx, chainID)
+		if err != nil {
+			return 0, ids.Empty, err
+		}
+	}
+
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	// A concurrent caller may have refreshed the height more recently.
+	if refreshHeight && !now.Before(v.refreshed) {
+		v.refreshed = now
+		v.height = height
+	}
+	v.subnetIDs[chainID] = subnetID
+	return height, subnetID, nil
+}
@@ -0,0 +1,172 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/snow/validators/validatorsmock"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+// newTestMessage returns a message from [sourceChainID] signed by the
+// [testVdrs] at [signers].
+func newTestMessage(require *require.Assertions, payload []byte, signers ...int) *Message {
+	unsignedMsg, err := NewUnsignedMessage(
+		constants.UnitTestID,
+		sourceChainID,
+		payload,
+	)
+	require.NoError(err)
+
+	var (
+		unsignedBytes = unsignedMsg.Bytes()
+		signerIndices = set.NewBits()
+		sigs          = make([]*bls.Signature, 0, len(signers))
+	)
+	for _, i := range signers {
+		signerIndices.Add(i)
+		sigs = append(sigs, testVdrs[i].sk.Sign(unsignedBytes))
+	}
+	aggSig, err := bls.AggregateSignatures(sigs)
+	require.NoError(err)
+
+	msg, err := NewMessage(
+		unsignedMsg,
+		&BitSetSignature{
+			Signers:   signerIndices.Bytes(),
+			Signature: [bls.SignatureLen]byte(bls.SignatureToBytes(aggSig)),
+		},
+	)
+	require.NoError(err)
+	return msg
+}
+
+func testValidatorSet() map[ids.NodeID]*validators.GetValidatorOutput {
+	vdrs := make(map[ids.NodeID]*validators.GetValidatorOutput, len(testVdrs))
+	for _, vdr := range testVdrs {
+		vdrs[vdr.nodeID] = &validators.GetValidatorOutput{
+			NodeID:    vdr.nodeID,
+			PublicKey: vdr.vdr.PublicKey,
+			Weight:    vdr.vdr.Weight,
+		}
+	}
+	return vdrs
+}
+
+func TestStreamVerifier(t *testing.T) {
+	const refreshInterval = time.Minute
+
+	var (
+		require = require.New(t)
+		ctrl    = gomock.NewController(t)
+		ctx     = context.Background()
+		state   = validatorsmock.NewState(ctrl)
+		vdrs    = testValidatorSet()
+	)
+
+	verifier := NewStreamVerifier(
+		constants.UnitTestID,
+		state,
+		QuorumDefault,
+		refreshInterval,
+	)
+	now := time.Unix(1_700_000_000, 0)
+	verifier.clock.Set(now)
+	require.Zero(verifier.Height())
+
+	// The validator set is fetched once and reused for every message.
+	gomock.InOrder(
+		state.EXPECT().GetCurrentHeight(gomock.Any()).Return(pChainHeight, nil),
+		state.EXPECT().GetSubnetID(gomock.Any(), sourceChainID).Return(subnetID, nil),
+		state.EXPECT().GetValidatorSet(gomock.Any(), pChainHeight, subnetID).Return(vdrs, nil),
+	)
+	require.NoError(verifier.Verify(ctx, newTestMessage(require, []byte{0}, 0, 1, 2)))
+	require.NoError(verifier.Verify(ctx, newTestMessage(require, []byte{1}, 0, 1, 2)))
+	err := verifier.Verify(ctx, newTestMessage(require, []byte{2}, 0, 1))
+	require.ErrorIs(err, ErrInsufficientWeight)
+	require.Equal(pChainHeight, verifier.Height())
+
+	// Once the refresh interval has elapsed, the validator set is fetched at
+	// the new height.
+	now = now.Add(refreshInterval)
+	verifier.clock.Set(now)
+	gomock.InOrder(
+		state.EXPECT().GetCurrentHeight(gomock.Any()).Return(pChainHeight+1, nil),
+		state.EXPECT().GetValidatorSet(gomock.Any(), pChainHeight+1, subnetID).Return(vdrs, nil),
+	)
+	require.NoError(verifier.Verify(ctx, newTestMessage(require, []byte{3}, 0, 1, 2)))
+	require.Equal(pChainHeight+1, verifier.Height())
+
+	// If the height hasn't changed, the cached validator set is kept.
+	now = now.Add(refreshInterval)
+	verifier.clock.Set(now)
+	state.EXPECT().GetCurrentHeight(gomock.Any()).Return(pChainHeight+1, nil)
+	require.NoError(verifier.Verify(ctx, newTestMessage(require, []byte{4}, 0, 1, 2)))
+}
+
+func TestStreamVerifierRefreshDoesNotHoldLock(t *testing.T) {
+	var (
+		require = require.New(t)
+		ctrl    = gomock.NewController(t)
+		state   = validatorsmock.NewState(ctrl)
+		started = make(chan struct{})
+		release = make(chan struct{})
+	)
+
+	verifier := NewStreamVerifier(
+		constants.UnitTestID,
+		state,
+		QuorumDefault,
+		time.Minute,
+	)
+
+	gomock.InOrder(
+		state.EXPECT().GetCurrentHeight(gomock.Any()).DoAndReturn(func(context.Context) (uint64, error) {
+			close(started)
+			<-release
+			return pChainHeight, nil
+		}),
+		state.EXPECT().GetSubnetID(gomock.Any(), sourceChainID).Return(subnetID, nil),
+		state.EXPECT().GetValidatorSet(gomock.Any(), pChainHeight, subnetID).Return(testValidatorSet(), nil),
+	)
+
+	var (
+		msg      = newTestMessage(require, nil, 0, 1, 2)
+		verified = make(chan error)
+	)
+	go func() {
+		verified <- verifier.Verify(context.Background(), msg)
+	}()
+
+	// The height can be read while it is being refreshed.
+	<-started
+	require.Zero(verifier.Height())
+
+	close(release)
+	require.NoError(<-verified)
+	require.Equal(pChainHeight, verifier.Height())
+}
+
+func TestStreamVerifierWrongNetworkID(t *testing.T) {
+	require := require.New(t)
+
+	verifier := NewStreamVerifier(
+		constants.UnitTestID+1,
+		nil,
+		QuorumDefault,
+		time.Minute,
+	)
+	err := verifier.Verify(context.Background(), newTestMessage(require, nil, 0))
+	require.ErrorIs(err, ErrWrongNetworkID)
+}
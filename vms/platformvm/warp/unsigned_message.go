@@ -4,10 +4,13 @@
 package warp
 
 import (
+	"encoding/binary"
 	"fmt"
 
+	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
 // UnsignedMessage defines the standard format for an unsigned Warp message.
@@ -20,12 +23,31 @@ type UnsignedMessage struct {
 	id    ids.ID
 }
 
+// SigningDomain returns the prefix of the bytes of every UnsignedMessage sent
+// on [networkID].
+//
+// Warp signatures are over the bytes of the UnsignedMessage, so the network ID
+// is always bound into the signed bytes. A signature of a message on one
+// network will never verify as a signature of a message on another network.
+func SigningDomain(networkID uint32) []byte {
+	domain := make([]byte, codec.VersionSize+wrappers.IntLen)
+	binary.BigEndian.PutUint16(domain, CodecVersion)
+	binary.BigEndian.PutUint32(domain[codec.VersionSize:], networkID)
+	return domain
+}
+
 // NewUnsignedMessage creates a new *UnsignedMessage and initializes it.
 func NewUnsignedMessage(
 	networkID uint32,
 	sourceChainID ids.ID,
 	payload []byte,
 ) (*UnsignedMessage, error) {
+	// The payload is the only variable length field, so the size of the
+	// message is known before it is marshalled.
+	if size := payloadLenOffset + wrappers.IntLen + len(payload); size > MaxMessageSize {
+		return nil, fmt.Errorf("%w: %d > %d", ErrMessageTooLarge, size, MaxMessageSize)
+	}
+
 	msg := &UnsignedMessage{
 		NetworkID:     networkID,
 		SourceChainID: sourceChainID,
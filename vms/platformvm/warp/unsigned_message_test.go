@@ -4,6 +4,7 @@
 package warp
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -11,6 +12,7 @@ import (
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 )
 
 func TestUnsignedMessage(t *testing.T) {
@@ -36,3 +38,44 @@ func TestParseUnsignedMessageJunk(t *testing.T) {
 	_, err := ParseUnsignedMessage(bytes)
 	require.ErrorIs(err, codec.ErrUnknownVersion)
 }
+
+func TestUnsignedMessageTooLarge(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewUnsignedMessage(
+		constants.UnitTestID,
+		ids.GenerateTestID(),
+		make([]byte, MaxMessageSize+1),
+	)
+	require.ErrorIs(err, ErrMessageTooLarge)
+}
+
+func TestSigningDomain(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		chainID = ids.GenerateTestID()
+		payload = []byte("payload")
+	)
+	msgA, err := NewUnsignedMessage(constants.MainnetID, chainID, payload)
+	require.NoError(err)
+	msgB, err := NewUnsignedMessage(constants.FujiID, chainID, payload)
+	require.NoError(err)
+
+	require.True(bytes.HasPrefix(msgA.Bytes(), SigningDomain(constants.MainnetID)))
+	require.True(bytes.HasPrefix(msgB.Bytes(), SigningDomain(constants.FujiID)))
+	require.False(bytes.HasPrefix(msgA.Bytes(), SigningDomain(constants.FujiID)))
+
+	// A signature of the message on network A must not verify as a signature
+	// of the same message on network B.
+	sk, err := bls.NewSigner()
+	require.NoError(err)
+
+	sigBytes, err := NewSigner(sk, constants.MainnetID, chainID).Sign(msgA)
+	require.NoError(err)
+	sig, err := bls.SignatureFromBytes(sigBytes)
+	require.NoError(err)
+
+	require.True(bls.Verify(sk.PublicKey(), sig, msgA.Bytes()))
+	require.False(bls.Verify(sk.PublicKey(), sig, msgB.Bytes()))
+}
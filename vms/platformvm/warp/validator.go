@@ -6,8 +6,10 @@ package warp
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"slices"
 
 	"golang.org/x/exp/maps"
 
@@ -15,6 +17,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/set"
 )
@@ -154,3 +157,49 @@ func AggregatePublicKeys(vdrs []*Validator) (*bls.PublicKey, error) {
 	}
 	return bls.AggregatePublicKeys(pks)
 }
+
+// AggregateForBits returns the aggregate public key and the total weight of
+// the validators in [vdrs] whose bit is set to 1 in [indices]. The result only
+// depends on [indices] and [vdrs], so it can be cached by callers that
+// repeatedly verify signatures from the same signers.
+//
+// Returns an error if [indices] references an unknown validator, if no
+// validators are selected, or if the weight overflows.
+//
+// Invariant: All of the public keys in [vdrs] are valid.
+func AggregateForBits(
+	indices set.Bits,
+	vdrs []*Validator,
+) (*bls.PublicKey, uint64, error) {
+	signers, err := FilterValidators(indices, vdrs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	weight, err := SumWeight(signers)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	aggPK, err := AggregatePublicKeys(signers)
+	if err != nil {
+		return nil, 0, err
+	}
+	return aggPK, weight, nil
+}
+
+// ValidatorSetHash returns a hash of the public keys and weights of [vdrs].
+//
+// The validators are hashed in the canonical ordering, so the hash does not
+// depend on the order of [vdrs]. NodeIDs are not included in the hash.
+func ValidatorSetHash(vdrs []*Validator) ids.ID {
+	sortedVdrs := slices.Clone(vdrs)
+	utils.Sort(sortedVdrs)
+
+	var b []byte
+	for _, vdr := range sortedVdrs {
+		b = append(b, vdr.PublicKeyBytes...)
+		b = binary.BigEndian.AppendUint64(b, vdr.Weight)
+	}
+	return hashing.ComputeHash256Array(b)
+}
@@ -0,0 +1,166 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/cache"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+type validatorSetKey struct {
+	subnetID ids.ID
+	height   uint64
+}
+
+type validatorSet struct {
+	vdrs        []*Validator
+	totalWeight uint64
+	err         error
+	// expiry is the time after which the validator set must be re-fetched.
+	expiry time.Time
+}
+
+// validatorSetFetch is an in-progress fetch of a validator set. [done] is
+// closed once [set] is populated.
+type validatorSetFetch struct {
+	done chan struct{}
+	set  validatorSet
+
+	// waiters is the number of callers waiting for the fetch. Once every
+	// waiter has left, the fetch is cancelled.
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// ValidatorSetCache caches the canonical validator sets of subnets at P-chain
+// heights.
+//
+// ValidatorSetCache is safe for concurrent use. Concurrent requests for a
+// validator set that is not cached result in a single fetch.
+type ValidatorSetCache struct {
+	state ValidatorState
+	ttl   time.Duration
+	clock mockable.Clock
+
+	lock     sync.Mutex
+	sets     *cache.LRU[validatorSetKey, *validatorSet]
+	fetching map[validatorSetKey]*validatorSetFetch
+}
+
+// NewValidatorSetCache returns a cache of up to [size] validator sets that are
+// fetched from [state]. Cached validator sets are re-fetched after [ttl]. If
+// [ttl] is not positive, cached validator sets never expire.
+func NewValidatorSetCache(
+	state ValidatorState,
+	size int,
+	ttl time.Duration,
+) *ValidatorSetCache {
+	return &ValidatorSetCache{
+		state:    state,
+		ttl:      ttl,
+		sets:     &cache.LRU[validatorSetKey, *validatorSet]{Size: size},
+		fetching: make(map[validatorSetKey]*validatorSetFetch),
+	}
+}
+
+// Get returns the validator set of [subnetID] at [height] in a canonical
+// ordering along with its total weight. The returned validators are shared
+// between callers and must not be modified.
+//
+// Get waits for the validator set to be fetched, or for [ctx] to be cancelled.
+// The fetch is not tied to [ctx], so cancelling one caller does not fail the
+// other callers that are waiting for the same validator set. Once every caller
+// waiting for the fetch has been cancelled, the fetch is cancelled too. Failed
+// fetches are not cached.
+func (c *ValidatorSetCache) Get(
+	ctx context.Context,
+	subnetID ids.ID,
+	height uint64,
+) ([]*Validator, uint64, error) {
+	key := validatorSetKey{
+		subnetID: subnetID,
+		height:   height,
+	}
+
+	c.lock.Lock()
+	if set, ok := c.sets.Get(key); ok {
+		if c.ttl <= 0 || c.clock.Time().Before(set.expiry) {
+			c.lock.Unlock()
+			return set.vdrs, set.totalWeight, nil
+		}
+		c.sets.Evict(key)
+	}
+
+	fetch, ok := c.fetching[key]
+	if !ok {
+		fetchCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		fetch = &validatorSetFetch{
+			done:   make(chan struct{}),
+			cancel: cancel,
+		}
+		c.fetching[key] = fetch
+		go c.fetch(fetchCtx, key, fetch)
+	}
+	fetch.waiters++
+	c.lock.Unlock()
+
+	select {
+	case <-fetch.done:
+		return fetch.set.vdrs, fetch.set.totalWeight, fetch.set.err
+	case <-ctx.Done():
+		c.leave(key, fetch)
+		return nil, 0, ctx.Err()
+	}
+}
+
+// leave removes a waiter from [fetch]. If no waiters remain, the fetch is
+// cancelled and a later Get starts a new fetch.
+func (c *ValidatorSetCache) leave(key validatorSetKey, fetch *validatorSetFetch) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	fetch.waiters--
+	if fetch.waiters > 0 {
+		return
+	}
+
+	fetch.cancel()
+	if c.fetching[key] == fetch {
+		delete(c.fetching, key)
+	}
+}
+
+// fetch populates [fetch] with the validator set identified by [key] and
+// caches it if it was fetched successfully.
+func (c *ValidatorSetCache) fetch(
+	ctx context.Context,
+	key validatorSetKey,
+	fetch *validatorSetFetch,
+) {
+	vdrs, totalWeight, err := GetCanonicalValidatorSet(ctx, c.state, key.height, key.subnetID)
+	fetch.set = validatorSet{
+		vdrs:        vdrs,
+		totalWeight: totalWeight,
+		err:         err,
+	}
+
+	c.lock.Lock()
+	if c.fetching[key] == fetch {
+		delete(c.fetching, key)
+	}
+	if err == nil {
+		set := fetch.set
+		set.expiry = c.clock.Time().Add(c.ttl)
+		c.sets.Put(key, &set)
+	}
+	c.lock.Unlock()
+
+	fetch.cancel()
+	close(fetch.done)
+}
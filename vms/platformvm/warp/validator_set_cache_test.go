@@ -0,0 +1,301 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+)
+
+// countingState returns the validator set of [vdr] for every subnet and height,
+// and counts the calls to GetValidatorSet. If [release] is non-nil, calls
+// block until it is closed or their context is cancelled. Cancelled calls are
+// counted separately. If [err] is non-nil, it is returned once.
+type countingState struct {
+	vdr     *testValidator
+	release chan struct{}
+
+	lock      sync.Mutex
+	calls     int
+	cancelled int
+	err       error
+}
+
+func (s *countingState) GetValidatorSet(ctx context.Context, _ uint64, _ ids.ID) (map[ids.NodeID]*validators.GetValidatorOutput, error) {
+	if s.release != nil {
+		select {
+		case <-s.release:
+		case <-ctx.Done():
+			s.lock.Lock()
+			defer s.lock.Unlock()
+
+			s.cancelled++
+			return nil, ctx.Err()
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.calls++
+	if err := s.err; err != nil {
+		s.err = nil
+		return nil, err
+	}
+	return map[ids.NodeID]*validators.GetValidatorOutput{
+		s.vdr.nodeID: {
+			NodeID:    s.vdr.nodeID,
+			PublicKey: s.vdr.vdr.PublicKey,
+			Weight:    s.vdr.vdr.Weight,
+		},
+	}, nil
+}
+
+func (s *countingState) numCalls() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.calls
+}
+
+func (s *countingState) numCancelled() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.cancelled
+}
+
+// numWaiters returns the number of callers waiting for the fetch of the
+// validator set of [subnetID] at [pChainHeight].
+func numWaiters(c *ValidatorSetCache) int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	fetch, ok := c.fetching[validatorSetKey{
+		subnetID: subnetID,
+		height:   pChainHeight,
+	}]
+	if !ok {
+		return 0
+	}
+	return fetch.waiters
+}
+
+func TestValidatorSetCache(t *testing.T) {
+	require := require.New(t)
+
+	state := &countingState{
+		vdr: newTestValidator(),
+	}
+	c := NewValidatorSetCache(state, 1, time.Minute)
+	now := time.Now()
+	c.clock.Set(now)
+
+	vdrs, totalWeight, err := c.Get(context.Background(), subnetID, pChainHeight)
+	require.NoError(err)
+	require.Equal([]*Validator{state.vdr.vdr}, vdrs)
+	require.Equal(state.vdr.vdr.Weight, totalWeight)
+	require.Equal(1, state.numCalls())
+
+	// The validator set is cached.
+	_, _, err = c.Get(context.Background(), subnetID, pChainHeight)
+	require.NoError(err)
+	require.Equal(1, state.numCalls())
+
+	// Validator sets are keyed by height.
+	_, _, err = c.Get(context.Background(), subnetID, pChainHeight+1)
+	require.NoError(err)
+	require.Equal(2, state.numCalls())
+
+	// The cache is limited to 1 validator set, so the first validator set was
+	// evicted.
+	_, _, err = c.Get(context.Background(), subnetID, pChainHeight)
+	require.NoError(err)
+	require.Equal(3, state.numCalls())
+
+	// The validator set has not expired.
+	c.clock.Set(now.Add(time.Minute - time.Nanosecond))
+	_, _, err = c.Get(context.Background(), subnetID, pChainHeight)
+	require.NoError(err)
+	require.Equal(3, state.numCalls())
+
+	// The validator set has expired.
+	c.clock.Set(now.Add(time.Minute))
+	_, _, err = c.Get(context.Background(), subnetID, pChainHeight)
+	require.NoError(err)
+	require.Equal(4, state.numCalls())
+}
+
+func TestValidatorSetCacheErrorNotCached(t *testing.T) {
+	require := require.New(t)
+
+	state := &countingState{
+		vdr: newTestValidator(),
+		err: errTest,
+	}
+	c := NewValidatorSetCache(state, 1, 0)
+
+	_, _, err := c.Get(context.Background(), subnetID, pChainHeight)
+	require.ErrorIs(err, errTest)
+
+	_, _, err = c.Get(context.Background(), subnetID, pChainHeight)
+	require.NoError(err)
+	require.Equal(2, state.numCalls())
+}
+
+func TestValidatorSetCacheCoalescesFetches(t *testing.T) {
+	require := require.New(t)
+
+	state := &countingState{
+		vdr:     newTestValidator(),
+		release: make(chan struct{}),
+	}
+	c := NewValidatorSetCache(state, 1, 0)
+
+	const numGets = 10
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, numGets)
+	)
+	for i := 0; i < numGets; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, _, err := c.Get(context.Background(), subnetID, pChainHeight)
+			errs <- err
+		}()
+	}
+
+	// Gets that start after the fetch finishes are served from the cache, so
+	// a single fetch is performed regardless of the scheduling of the gets.
+	close(state.release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(err)
+	}
+	require.Equal(1, state.numCalls())
+}
+
+func TestValidatorSetCacheWaitCancelled(t *testing.T) {
+	require := require.New(t)
+
+	state := &countingState{
+		vdr:     newTestValidator(),
+		release: make(chan struct{}),
+	}
+	c := NewValidatorSetCache(state, 1, 0)
+
+	fetched := make(chan error)
+	go func() {
+		_, _, err := c.Get(context.Background(), subnetID, pChainHeight)
+		fetched <- err
+	}()
+
+	// Wait for the fetch to start so that the next Get waits for it.
+	require.Eventually(func() bool {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		return len(c.fetching) == 1
+	}, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err := c.Get(ctx, subnetID, pChainHeight)
+	require.ErrorIs(err, context.Canceled)
+
+	close(state.release)
+	require.NoError(<-fetched)
+	require.Equal(1, state.numCalls())
+}
+
+func TestValidatorSetCacheFetchNotCancelled(t *testing.T) {
+	require := require.New(t)
+
+	state := &countingState{
+		vdr:     newTestValidator(),
+		release: make(chan struct{}),
+	}
+	c := NewValidatorSetCache(state, 1, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error)
+	go func() {
+		_, _, err := c.Get(ctx, subnetID, pChainHeight)
+		cancelled <- err
+	}()
+
+	// Wait for the fetch to start so that the next Get waits for it.
+	require.Eventually(func() bool {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		return len(c.fetching) == 1
+	}, time.Second, time.Millisecond)
+
+	fetched := make(chan error)
+	go func() {
+		_, _, err := c.Get(context.Background(), subnetID, pChainHeight)
+		fetched <- err
+	}()
+	require.Eventually(func() bool {
+		return numWaiters(c) == 2
+	}, time.Second, time.Millisecond)
+
+	// Cancelling the Get that started the fetch does not fail the fetch.
+	cancel()
+	require.ErrorIs(<-cancelled, context.Canceled)
+
+	close(state.release)
+	require.NoError(<-fetched)
+	require.Equal(1, state.numCalls())
+	require.Zero(state.numCancelled())
+}
+
+func TestValidatorSetCacheFetchCancelledWithoutWaiters(t *testing.T) {
+	require := require.New(t)
+
+	state := &countingState{
+		vdr:     newTestValidator(),
+		release: make(chan struct{}),
+	}
+	c := NewValidatorSetCache(state, 1, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error)
+	go func() {
+		_, _, err := c.Get(ctx, subnetID, pChainHeight)
+		cancelled <- err
+	}()
+	require.Eventually(func() bool {
+		return numWaiters(c) == 1
+	}, time.Second, time.Millisecond)
+
+	// Once its only waiter leaves, the fetch is cancelled and abandoned.
+	cancel()
+	require.ErrorIs(<-cancelled, context.Canceled)
+	require.Eventually(func() bool {
+		return state.numCancelled() == 1
+	}, time.Second, time.Millisecond)
+
+	c.lock.Lock()
+	require.Empty(c.fetching)
+	c.lock.Unlock()
+
+	// A later Get starts a new fetch.
+	close(state.release)
+	_, _, err := c.Get(context.Background(), subnetID, pChainHeight)
+	require.NoError(err)
+	require.Equal(1, state.numCalls())
+}
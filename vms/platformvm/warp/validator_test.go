@@ -345,3 +345,143 @@ func BenchmarkGetCanonicalValidatorSet(b *testing.B) {
 		})
 	}
 }
+
+func TestAggregateForBits(t *testing.T) {
+	vdrs := make([]*Validator, 3)
+	for i := range vdrs {
+		sk, err := bls.NewSigner()
+		require.NoError(t, err)
+		pk := sk.PublicKey()
+		vdrs[i] = &Validator{
+			PublicKey:      pk,
+			PublicKeyBytes: bls.PublicKeyToUncompressedBytes(pk),
+			Weight:         uint64(i + 1),
+		}
+	}
+	overflowVdrs := []*Validator{
+		{
+			PublicKey:      vdrs[0].PublicKey,
+			PublicKeyBytes: vdrs[0].PublicKeyBytes,
+			Weight:         math.MaxUint64,
+		},
+		vdrs[1],
+	}
+
+	tests := []struct {
+		name           string
+		indices        set.Bits
+		vdrs           []*Validator
+		expectedPKs    []*bls.PublicKey
+		expectedWeight uint64
+		expectedErr    error
+	}{
+		{
+			name:           "single signer",
+			indices:        set.NewBits(1),
+			vdrs:           vdrs,
+			expectedPKs:    []*bls.PublicKey{vdrs[1].PublicKey},
+			expectedWeight: 2,
+		},
+		{
+			name:           "subset of signers",
+			indices:        set.NewBits(0, 2),
+			vdrs:           vdrs,
+			expectedPKs:    []*bls.PublicKey{vdrs[0].PublicKey, vdrs[2].PublicKey},
+			expectedWeight: 4,
+		},
+		{
+			name:    "all signers",
+			indices: set.NewBits(0, 1, 2),
+			vdrs:    vdrs,
+			expectedPKs: []*bls.PublicKey{
+				vdrs[0].PublicKey,
+				vdrs[1].PublicKey,
+				vdrs[2].PublicKey,
+			},
+			expectedWeight: 6,
+		},
+		{
+			name:        "unknown validator",
+			indices:     set.NewBits(3),
+			vdrs:        vdrs,
+			expectedErr: ErrUnknownValidator,
+		},
+		{
+			name:        "no signers",
+			indices:     set.NewBits(),
+			vdrs:        vdrs,
+			expectedErr: bls.ErrNoPublicKeys,
+		},
+		{
+			name:        "weight overflow",
+			indices:     set.NewBits(0, 1),
+			vdrs:        overflowVdrs,
+			expectedErr: ErrWeightOverflow,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			aggPK, weight, err := AggregateForBits(test.indices, test.vdrs)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			expectedPK, err := bls.AggregatePublicKeys(test.expectedPKs)
+			require.NoError(err)
+			require.Equal(
+				bls.PublicKeyToCompressedBytes(expectedPK),
+				bls.PublicKeyToCompressedBytes(aggPK),
+			)
+			require.Equal(test.expectedWeight, weight)
+		})
+	}
+}
+
+func TestValidatorSetHash(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := []*Validator{
+		testVdrs[0].vdr,
+		testVdrs[1].vdr,
+		testVdrs[2].vdr,
+	}
+	hash := ValidatorSetHash(vdrs)
+
+	// The hash does not depend on the order of the validators.
+	reversed := []*Validator{
+		testVdrs[2].vdr,
+		testVdrs[1].vdr,
+		testVdrs[0].vdr,
+	}
+	require.Equal(hash, ValidatorSetHash(reversed))
+	require.Equal(testVdrs[2].vdr, reversed[0])
+
+	// NodeIDs are not part of the hash.
+	withNodeIDs := []*Validator{
+		testVdrs[0].vdr,
+		testVdrs[1].vdr,
+		{
+			PublicKey:      testVdrs[2].vdr.PublicKey,
+			PublicKeyBytes: testVdrs[2].vdr.PublicKeyBytes,
+			Weight:         testVdrs[2].vdr.Weight,
+			NodeIDs:        []ids.NodeID{ids.GenerateTestNodeID()},
+		},
+	}
+	require.Equal(hash, ValidatorSetHash(withNodeIDs))
+
+	differentWeight := []*Validator{
+		testVdrs[0].vdr,
+		testVdrs[1].vdr,
+		{
+			PublicKey:      testVdrs[2].vdr.PublicKey,
+			PublicKeyBytes: testVdrs[2].vdr.PublicKeyBytes,
+			Weight:         testVdrs[2].vdr.Weight + 1,
+		},
+	}
+	require.NotEqual(hash, ValidatorSetHash(differentWeight))
+
+	require.NotEqual(hash, ValidatorSetHash(vdrs[:2]))
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/set"
+)
+
+var (
+	ErrWrongSigner = errors.New("signature was not produced by the expected address")
+
+	_ keychain.Keychain = (*externalKeychain)(nil)
+	_ keychain.Signer   = (*externalSigner)(nil)
+)
+
+// externalKeychain exposes the addresses of a [keychain.ExternalSigner] as a
+// [keychain.Keychain].
+type externalKeychain struct {
+	signer keychain.ExternalSigner
+	addrs  set.Set[ids.ShortID]
+}
+
+// externalSigner signs for a single address of a [keychain.ExternalSigner].
+type externalSigner struct {
+	signer keychain.ExternalSigner
+	addr   ids.ShortID
+}
+
+// NewExternalKeychain returns a keychain that delegates signing to [signer].
+// This allows keys held in an HSM or a remote KMS to be used by the wallet.
+//
+// The addresses of [signer] are read once, when the keychain is created.
+func NewExternalKeychain(signer keychain.ExternalSigner) keychain.Keychain {
+	return &externalKeychain{
+		signer: signer,
+		addrs:  set.Of(signer.Addresses()...),
+	}
+}
+
+func (kc *externalKeychain) Get(addr ids.ShortID) (keychain.Signer, bool) {
+	if !kc.addrs.Contains(addr) {
+		return nil, false
+	}
+	return &externalSigner{
+		signer: kc.signer,
+		addr:   addr,
+	}, true
+}
+
+func (kc *externalKeychain) Addresses() set.Set[ids.ShortID] {
+	return kc.addrs
+}
+
+// SignHash returns the signature of [hash] after verifying that it was produced
+// by the key of the expected address.
+func (s *externalSigner) SignHash(hash []byte) ([]byte, error) {
+	sig, err := s.signer.Sign(s.addr, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	pk, err := secp256k1.RecoverPublicKeyFromHash(hash, sig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover public key: %w", err)
+	}
+	if addr := pk.Address(); addr != s.addr {
+		return nil, fmt.Errorf("%w: expected %s but got %s",
+			ErrWrongSigner,
+			s.addr,
+			addr,
+		)
+	}
+	return sig, nil
+}
+
+func (s *externalSigner) Sign(msg []byte) ([]byte, error) {
+	return s.SignHash(hashing.ComputeHash256(msg))
+}
+
+func (s *externalSigner) Address() ids.ShortID {
+	return s.addr
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+var errTestSigner = errors.New("test signer error")
+
+// testExternalSigner signs with in-memory keys, optionally using the wrong key
+// or failing.
+type testExternalSigner struct {
+	keys     map[ids.ShortID]*secp256k1.PrivateKey
+	override *secp256k1.PrivateKey
+	err      error
+}
+
+func (s *testExternalSigner) Sign(addr ids.ShortID, hash []byte) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	key := s.keys[addr]
+	if s.override != nil {
+		key = s.override
+	}
+	return key.SignHash(hash)
+}
+
+func (s *testExternalSigner) Addresses() []ids.ShortID {
+	addrs := make([]ids.ShortID, 0, len(s.keys))
+	for addr := range s.keys {
+		addrs = append(addrs, addr)
+	}
+	return addrs
+}
+
+func TestExternalKeychain(t *testing.T) {
+	keys := secp256k1.TestKeys()
+	key := keys[0]
+	signer := &testExternalSigner{
+		keys: map[ids.ShortID]*secp256k1.PrivateKey{
+			key.Address(): key,
+		},
+	}
+	kc := NewExternalKeychain(signer)
+
+	t.Run("addresses", func(t *testing.T) {
+		require := require.New(t)
+
+		addrs := kc.Addresses()
+		require.Equal(1, addrs.Len())
+		require.True(addrs.Contains(key.Address()))
+
+		_, ok := kc.Get(keys[1].Address())
+		require.False(ok)
+	})
+
+	t.Run("sign", func(t *testing.T) {
+		require := require.New(t)
+
+		s, ok := kc.Get(key.Address())
+		require.True(ok)
+		require.Equal(key.Address(), s.Address())
+
+		msg := []byte("message")
+		sig, err := s.Sign(msg)
+		require.NoError(err)
+
+		expectedSig, err := key.Sign(msg)
+		require.NoError(err)
+		require.Equal(expectedSig, sig)
+
+		hashSig, err := s.SignHash(hashing.ComputeHash256(msg))
+		require.NoError(err)
+		require.Equal(expectedSig, hashSig)
+	})
+
+	t.Run("wrong signer", func(t *testing.T) {
+		signer.override = keys[1]
+		defer func() {
+			signer.override = nil
+		}()
+
+		s, ok := kc.Get(key.Address())
+		require.True(t, ok)
+
+		_, err := s.Sign([]byte("message"))
+		require.ErrorIs(t, err, ErrWrongSigner)
+	})
+
+	t.Run("signer error", func(t *testing.T) {
+		signer.err = errTestSigner
+		defer func() {
+			signer.err = nil
+		}()
+
+		s, ok := kc.Get(key.Address())
+		require.True(t, ok)
+
+		_, err := s.Sign([]byte("message"))
+		require.ErrorIs(t, err, errTestSigner)
+	})
+}
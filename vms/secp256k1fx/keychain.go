@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/formatting"
@@ -20,6 +21,9 @@ import (
 )
 
 var (
+	ErrUnknownAddress = errors.New("unknown address")
+	ErrNoBLSSigner    = errors.New("no BLS signer associated with address")
+
 	errCantSpend = errors.New("unable to spend this UTXO")
 
 	_ keychain.Keychain = (*Keychain)(nil)
@@ -29,6 +33,7 @@ var (
 type Keychain struct {
 	avaxAddrToKeyIndex map[ids.ShortID]int
 	ethAddrToKeyIndex  map[common.Address]int
+	avaxAddrToBLS      map[ids.ShortID]bls.Signer
 
 	// These can be used to iterate over. However, they should not be modified
 	// externally.
@@ -42,6 +47,7 @@ func NewKeychain(keys ...*secp256k1.PrivateKey) *Keychain {
 	kc := &Keychain{
 		avaxAddrToKeyIndex: make(map[ids.ShortID]int),
 		ethAddrToKeyIndex:  make(map[common.Address]int),
+		avaxAddrToBLS:      make(map[ids.ShortID]bls.Signer),
 	}
 	for _, key := range keys {
 		kc.Add(key)
@@ -63,6 +69,31 @@ func (kc *Keychain) Add(key *secp256k1.PrivateKey) {
 	}
 }
 
+// AddBLSSigner associates [signer] with the key controlling [addr]. This allows
+// a single keychain to manage both the funding key and the BLS key of simple
+// operator setups.
+func (kc *Keychain) AddBLSSigner(addr ids.ShortID, signer bls.Signer) error {
+	if _, ok := kc.avaxAddrToKeyIndex[addr]; !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownAddress, addr)
+	}
+	if kc.avaxAddrToBLS == nil {
+		kc.avaxAddrToBLS = make(map[ids.ShortID]bls.Signer)
+	}
+	kc.avaxAddrToBLS[addr] = signer
+	return nil
+}
+
+// KeychainBLSSigner returns the BLS signer associated with [addr] in [kc].
+//
+// Returns an error if no BLS signer was associated with [addr].
+func KeychainBLSSigner(kc *Keychain, addr ids.ShortID) (bls.Signer, error) {
+	signer, ok := kc.avaxAddrToBLS[addr]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoBLSSigner, addr)
+	}
+	return signer, nil
+}
+
 // Get a key from the keychain and return whether the key existed.
 func (kc Keychain) Get(id ids.ShortID) (keychain.Signer, bool) {
 	return kc.get(id)
@@ -137,6 +168,21 @@ func (kc *Keychain) Match(owners *OutputOwners, time uint64) ([]uint32, []*secp2
 	return sigs, keys, uint32(len(keys)) == owners.Threshold
 }
 
+// CanSpend returns true if [kc] holds the keys of at least [owners.Threshold]
+// of [owners.Addrs]. The locktime of [owners] is not considered.
+func CanSpend(kc keychain.Keychain, owners *OutputOwners) bool {
+	var numSigners uint32
+	for _, addr := range owners.Addrs {
+		if numSigners >= owners.Threshold {
+			break
+		}
+		if _, ok := kc.Get(addr); ok {
+			numSigners++
+		}
+	}
+	return numSigners >= owners.Threshold
+}
+
 // PrefixedString returns the key chain as a string representation with [prefix]
 // added before every line.
 func (kc *Keychain) PrefixedString(prefix string) string {
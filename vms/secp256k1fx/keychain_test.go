@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 )
@@ -62,6 +63,36 @@ func TestKeychainAdd(t *testing.T) {
 	require.True(addrs.Contains(addr))
 }
 
+func TestKeychainBLSSigner(t *testing.T) {
+	require := require.New(t)
+	kc := NewKeychain()
+
+	skBytes, err := formatting.Decode(formatting.HexNC, keys[0])
+	require.NoError(err)
+	sk, err := secp256k1.ToPrivateKey(skBytes)
+	require.NoError(err)
+	kc.Add(sk)
+
+	addr, err := ids.ShortFromString(addrs[0])
+	require.NoError(err)
+
+	_, err = KeychainBLSSigner(kc, addr)
+	require.ErrorIs(err, ErrNoBLSSigner)
+
+	blsSigner, err := bls.NewSigner()
+	require.NoError(err)
+	require.NoError(kc.AddBLSSigner(addr, blsSigner))
+
+	signer, err := KeychainBLSSigner(kc, addr)
+	require.NoError(err)
+	require.Equal(blsSigner, signer)
+
+	unknownAddr, err := ids.ShortFromString(addrs[1])
+	require.NoError(err)
+	err = kc.AddBLSSigner(unknownAddr, blsSigner)
+	require.ErrorIs(err, ErrUnknownAddress)
+}
+
 func TestKeychainNew(t *testing.T) {
 	require := require.New(t)
 	kc := NewKeychain()
@@ -123,6 +154,82 @@ func TestKeychainMatch(t *testing.T) {
 	require.Equal(sks[1].PublicKey().Address(), keys[0].PublicKey().Address())
 }
 
+func TestCanSpend(t *testing.T) {
+	sks := secp256k1.TestKeys()[:3]
+	addrs := []ids.ShortID{
+		sks[0].Address(),
+		sks[1].Address(),
+		sks[2].Address(),
+	}
+
+	tests := []struct {
+		name     string
+		keys     []*secp256k1.PrivateKey
+		owners   *OutputOwners
+		expected bool
+	}{
+		{
+			name: "threshold met",
+			keys: sks[:1],
+			owners: &OutputOwners{
+				Threshold: 1,
+				Addrs:     addrs,
+			},
+			expected: true,
+		},
+		{
+			name: "multisig threshold met",
+			keys: sks[1:],
+			owners: &OutputOwners{
+				Threshold: 2,
+				Addrs:     addrs,
+			},
+			expected: true,
+		},
+		{
+			name: "threshold not met",
+			keys: sks[:1],
+			owners: &OutputOwners{
+				Threshold: 2,
+				Addrs:     addrs,
+			},
+			expected: false,
+		},
+		{
+			name: "no matching keys",
+			keys: sks[:1],
+			owners: &OutputOwners{
+				Threshold: 1,
+				Addrs:     addrs[1:],
+			},
+			expected: false,
+		},
+		{
+			name: "zero threshold",
+			owners: &OutputOwners{
+				Threshold: 0,
+			},
+			expected: true,
+		},
+		{
+			name: "locktime is ignored",
+			keys: sks[:1],
+			owners: &OutputOwners{
+				Locktime:  1,
+				Threshold: 1,
+				Addrs:     addrs[:1],
+			},
+			expected: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kc := NewKeychain(test.keys...)
+			require.Equal(t, test.expected, CanSpend(kc, test.owners))
+		})
+	}
+}
+
 func TestKeychainSpendMint(t *testing.T) {
 	require := require.New(t)
 	kc := NewKeychain()
@@ -24,6 +24,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/fee"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/vms/txs/mempool"
 	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
 )
 
@@ -33,6 +34,9 @@ var (
 	ErrUnknownOwnerType          = errors.New("unknown owner type")
 	ErrInsufficientAuthorization = errors.New("insufficient authorization")
 	ErrInsufficientFunds         = errors.New("insufficient funds")
+	ErrInvalidFeeMultiplier      = errors.New("invalid fee multiplier")
+	ErrUnknownSubnetAuthKey      = errors.New("unknown subnet auth key")
+	ErrTooManyConvertValidators  = errors.New("too many validators to convert in a single tx")
 
 	_ Builder = (*builder)(nil)
 )
@@ -157,6 +161,12 @@ type Builder interface {
 	// - [chainID] specifies which chain the manager is deployed on
 	// - [address] specifies the address of the manager
 	// - [validators] specifies the initial L1 validators of the L1
+	//
+	// Returns [ErrTooManyConvertValidators] if [validators] contains more than
+	// [fee.MaxConvertSubnetToL1Validators] validators or can not fit in a
+	// single transaction. In that case, [txs.ChunkConvertValidators] can be
+	// used to convert the subnet with a subset of the validators and register
+	// the remaining validators afterwards.
 	NewConvertSubnetToL1Tx(
 		subnetID ids.ID,
 		chainID ids.ID,
@@ -833,6 +843,10 @@ func (b *builder) NewConvertSubnetToL1Tx(
 	validators []*txs.ConvertSubnetToL1Validator,
 	options ...common.Option,
 ) (*txs.ConvertSubnetToL1Tx, error) {
+	if err := txs.ValidateValidatorSet(validators); err != nil {
+		return nil, err
+	}
+
 	var avaxToBurn uint64
 	for _, vdr := range validators {
 		var err error
@@ -849,6 +863,15 @@ func (b *builder) NewConvertSubnetToL1Tx(
 		toStake = map[ids.ID]uint64{}
 		ops     = common.NewOptions(options)
 	)
+	if len(validators) > fee.MaxConvertSubnetToL1Validators {
+		return nil, fmt.Errorf(
+			"%w: %d validators exceeds the limit of %d; use txs.ChunkConvertValidators with fee.MaxConvertSubnetToL1Validators to register the remaining validators after the conversion",
+			ErrTooManyConvertValidators,
+			len(validators),
+			fee.MaxConvertSubnetToL1Validators,
+		)
+	}
+
 	subnetAuth, err := b.authorize(subnetID, ops)
 	if err != nil {
 		return nil, err
@@ -878,6 +901,17 @@ func (b *builder) NewConvertSubnetToL1Tx(
 	if err != nil {
 		return nil, err
 	}
+	// The inputs and outputs only increase the size of the tx, so there is no
+	// need to spend UTXOs if the tx would already be too large to be issued.
+	if complexity[gas.Bandwidth] > mempool.MaxTxSize {
+		return nil, fmt.Errorf(
+			"%w: %d validators require at least %d bytes but the maximum tx size is %d bytes; use txs.ChunkConvertValidators to register the remaining validators after the conversion",
+			ErrTooManyConvertValidators,
+			len(validators),
+			complexity[gas.Bandwidth],
+			mempool.MaxTxSize,
+		)
+	}
 
 	inputs, outputs, _, err := b.spend(
 		toBurn,
@@ -1609,12 +1643,21 @@ func (b *builder) spend(
 	stakeOutputs []*avax.TransferableOutput,
 	err error,
 ) {
+	feeMultiplierNumerator, feeMultiplierDenominator := options.FeeMultiplier()
+	if feeMultiplierDenominator == 0 || feeMultiplierNumerator < feeMultiplierDenominator {
+		return nil, nil, nil, fmt.Errorf("%w: %d/%d",
+			ErrInvalidFeeMultiplier,
+			feeMultiplierNumerator,
+			feeMultiplierDenominator,
+		)
+	}
+
 	utxos, err := b.backend.UTXOs(options.Context(), constants.PlatformChainID)
 	if err != nil {
 		return nil, nil, nil, err
 	}
 
-	addrs := options.Addresses(b.addrs)
+	addrs := options.SpendAddresses(b.addrs)
 	minIssuanceTime := options.MinIssuanceTime()
 
 	addr, ok := addrs.Peek()
@@ -1630,8 +1673,10 @@ func (b *builder) spend(
 	}
 
 	s := spendHelper{
-		weights:  b.context.ComplexityWeights,
-		gasPrice: b.context.GasPrice,
+		weights:                  b.context.ComplexityWeights,
+		gasPrice:                 b.context.GasPrice,
+		feeMultiplierNumerator:   feeMultiplierNumerator,
+		feeMultiplierDenominator: feeMultiplierDenominator,
 
 		toBurn:     toBurn,
 		toStake:    toStake,
@@ -1897,6 +1942,15 @@ func (b *builder) authorize(ownerID ids.ID, options *common.Options) (*secp256k1
 	}
 
 	addrs := options.Addresses(b.addrs)
+	if authAddrs, ok := options.SubnetAuthAddresses(); ok {
+		for _, addr := range authAddrs {
+			if !addrs.Contains(addr) {
+				return nil, fmt.Errorf("%w: %s", ErrUnknownSubnetAuthKey, addr)
+			}
+		}
+		addrs = set.Of(authAddrs...)
+	}
+
 	minIssuanceTime := options.MinIssuanceTime()
 	inputSigIndices, ok := common.MatchOwners(owner, addrs, minIssuanceTime)
 	if !ok {
@@ -1919,8 +1973,10 @@ func (b *builder) initCtx(tx txs.UnsignedTx) error {
 }
 
 type spendHelper struct {
-	weights  gas.Dimensions
-	gasPrice gas.Price
+	weights                  gas.Dimensions
+	gasPrice                 gas.Price
+	feeMultiplierNumerator   uint64
+	feeMultiplierDenominator uint64
 
 	toBurn     map[ids.ID]uint64
 	toStake    map[ids.ID]uint64
@@ -1999,7 +2055,19 @@ func (s *spendHelper) calculateFee() (uint64, error) {
 	if err != nil {
 		return 0, err
 	}
-	return gas.Cost(s.gasPrice)
+	fee, err := gas.Cost(s.gasPrice)
+	if err != nil {
+		return 0, err
+	}
+	paddedFee, err := math.Mul(fee, s.feeMultiplierNumerator)
+	if err != nil {
+		return 0, err
+	}
+	// Round up to ensure the padded fee is never less than the required fee.
+	return math.Add(
+		paddedFee/s.feeMultiplierDenominator,
+		min(paddedFee%s.feeMultiplierDenominator, 1),
+	)
 }
 
 func (s *spendHelper) verifyAssetsConsumed() error {
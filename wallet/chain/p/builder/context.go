@@ -4,12 +4,18 @@
 package builder
 
 import (
+	"fmt"
+	"math"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/components/gas"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/fee"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
 
 const Alias = "P"
@@ -20,6 +26,9 @@ type Context struct {
 	StaticFeeConfig   fee.StaticConfig
 	ComplexityWeights gas.Dimensions
 	GasPrice          gas.Price
+	// ValidatorFeePrice is the fee charged per second to each active L1
+	// validator.
+	ValidatorFeePrice gas.Price
 }
 
 func NewSnowContext(networkID uint32, avaxAssetID ids.ID) (*snow.Context, error) {
@@ -33,3 +42,28 @@ func NewSnowContext(networkID uint32, avaxAssetID ids.ID) (*snow.Context, error)
 		BCLookup:    lookup,
 	}, lookup.Alias(constants.PlatformChainID, Alias)
 }
+
+// FeeDelta returns the fee of [tx] under the [after] context minus the fee of
+// [tx] under the [before] context.
+//
+// A positive delta means that issuing [tx] became more expensive between the
+// two snapshots.
+func FeeDelta(before, after *Context, tx *txs.Tx) (int64, error) {
+	beforeFee, err := fee.NewDynamicCalculator(before.ComplexityWeights, before.GasPrice).CalculateFee(tx.Unsigned)
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate fee before: %w", err)
+	}
+	afterFee, err := fee.NewDynamicCalculator(after.ComplexityWeights, after.GasPrice).CalculateFee(tx.Unsigned)
+	if err != nil {
+		return 0, fmt.Errorf("failed to calculate fee after: %w", err)
+	}
+
+	delta := safemath.AbsDiff(beforeFee, afterFee)
+	if delta > math.MaxInt64 {
+		return 0, fmt.Errorf("%w: fee delta of %d", safemath.ErrOverflow, delta)
+	}
+	if afterFee < beforeFee {
+		return -int64(delta), nil
+	}
+	return int64(delta), nil
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package builder
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/fee"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+func TestFeeDelta(t *testing.T) {
+	weights := gas.Dimensions{
+		gas.Bandwidth: 1,
+		gas.DBRead:    10,
+		gas.DBWrite:   100,
+		gas.Compute:   1000,
+	}
+	tx := &txs.Tx{
+		Unsigned: &txs.BaseTx{
+			BaseTx: avax.BaseTx{
+				NetworkID:    constants.UnitTestID,
+				BlockchainID: constants.PlatformChainID,
+				Ins: []*avax.TransferableInput{
+					{
+						UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+						Asset:  avax.Asset{ID: ids.GenerateTestID()},
+						In: &secp256k1fx.TransferInput{
+							Amt: 1,
+							Input: secp256k1fx.Input{
+								SigIndices: []uint32{0},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	oneGasFee, err := fee.NewDynamicCalculator(weights, 1).CalculateFee(tx.Unsigned)
+	require.NoError(t, err)
+	require.Positive(t, oneGasFee)
+
+	tests := []struct {
+		name          string
+		before        gas.Price
+		after         gas.Price
+		expectedDelta int64
+		expectedErr   error
+	}{
+		{
+			name:          "unchanged",
+			before:        10,
+			after:         10,
+			expectedDelta: 0,
+		},
+		{
+			name:          "more expensive",
+			before:        10,
+			after:         15,
+			expectedDelta: 5 * int64(oneGasFee),
+		},
+		{
+			name:          "cheaper",
+			before:        15,
+			after:         10,
+			expectedDelta: -5 * int64(oneGasFee),
+		},
+		{
+			name:        "fee overflow",
+			before:      1,
+			after:       math.MaxUint64,
+			expectedErr: safemath.ErrOverflow,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			before := &Context{
+				ComplexityWeights: weights,
+				GasPrice:          test.before,
+			}
+			after := &Context{
+				ComplexityWeights: weights,
+				GasPrice:          test.after,
+			}
+			delta, err := FeeDelta(before, after, tx)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedDelta, delta)
+		})
+	}
+}
@@ -366,6 +366,38 @@ func TestCreateSubnetTx(t *testing.T) {
 	}
 }
 
+func TestChangeOwners(t *testing.T) {
+	changeOwner := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+	}
+	for _, e := range testEnvironment {
+		t.Run(e.name, func(t *testing.T) {
+			var (
+				require    = require.New(t)
+				chainUTXOs = utxotest.NewDeterministicChainUTXOs(t, map[ids.ID][]*avax.UTXO{
+					constants.PlatformChainID: utxos,
+				})
+				backend = wallet.NewBackend(e.context, chainUTXOs, subnetOwners)
+				builder = builder.New(set.Of(utxoAddr, subnetAuthAddr), e.context, backend)
+			)
+
+			utx, err := builder.NewCreateSubnetTx(
+				subnetOwner,
+				common.WithChangeOwner(changeOwner),
+			)
+			require.NoError(err)
+
+			owners, err := txs.ChangeOwners(&txs.Tx{Unsigned: utx})
+			require.NoError(err)
+			require.NotEmpty(owners)
+			for _, owner := range owners {
+				require.True(changeOwner.Equals(owner))
+			}
+		})
+	}
+}
+
 func TestTransferSubnetOwnershipTx(t *testing.T) {
 	for _, e := range testEnvironment {
 		t.Run(e.name, func(t *testing.T) {
@@ -759,6 +791,76 @@ func TestConvertSubnetToL1Tx(t *testing.T) {
 	}
 }
 
+func TestConvertSubnetToL1TxTooManyValidators(t *testing.T) {
+	const (
+		maxPerTx      = fee.MaxConvertSubnetToL1Validators
+		numValidators = 2*maxPerTx + 1
+	)
+
+	sk, err := bls.NewSigner()
+	require.NoError(t, err)
+
+	var (
+		chainID    = ids.GenerateTestID()
+		address    = utils.RandomBytes(32)
+		pop        = signer.NewProofOfPossession(sk)
+		validators = make([]*txs.ConvertSubnetToL1Validator, numValidators)
+	)
+	for i := range validators {
+		validators[i] = &txs.ConvertSubnetToL1Validator{
+			NodeID:  ids.GenerateTestNodeID().Bytes(),
+			Weight:  1,
+			Balance: 1,
+			Signer:  *pop,
+			RemainingBalanceOwner: message.PChainOwner{
+				Threshold: 1,
+				Addresses: []ids.ShortID{ids.GenerateTestShortID()},
+			},
+			DeactivationOwner: message.PChainOwner{
+				Threshold: 1,
+				Addresses: []ids.ShortID{ids.GenerateTestShortID()},
+			},
+		}
+	}
+	chunks := txs.ChunkConvertValidators(validators, maxPerTx)
+	require.Len(t, chunks, 3)
+
+	// [builder] is shadowed by the builder of each environment.
+	errTooManyConvertValidators := builder.ErrTooManyConvertValidators
+
+	for _, e := range testEnvironmentPostEtna {
+		t.Run(e.name, func(t *testing.T) {
+			var (
+				require    = require.New(t)
+				chainUTXOs = utxotest.NewDeterministicChainUTXOs(t, map[ids.ID][]*avax.UTXO{
+					constants.PlatformChainID: utxos,
+				})
+				backend = wallet.NewBackend(e.context, chainUTXOs, subnetOwners)
+				builder = builder.New(set.Of(utxoAddr, subnetAuthAddr), e.context, backend)
+			)
+
+			_, err := builder.NewConvertSubnetToL1Tx(
+				subnetID,
+				chainID,
+				address,
+				slices.Clone(validators),
+				common.WithMemo(e.memo),
+			)
+			require.ErrorIs(err, errTooManyConvertValidators)
+
+			utx, err := builder.NewConvertSubnetToL1Tx(
+				subnetID,
+				chainID,
+				address,
+				slices.Clone(chunks[0]),
+				common.WithMemo(e.memo),
+			)
+			require.NoError(err)
+			require.Len(utx.Validators, maxPerTx)
+		})
+	}
+}
+
 func TestRegisterL1ValidatorTx(t *testing.T) {
 	const (
 		expiry = 1731005097
@@ -776,16 +878,18 @@ func TestRegisterL1ValidatorTx(t *testing.T) {
 		nodeID,
 		pop.PublicKey,
 		expiry,
-		message.PChainOwner{
-			Threshold: 1,
-			Addresses: []ids.ShortID{
-				ids.GenerateTestShortID(),
+		message.L1ValidatorOwners{
+			RemainingBalanceOwner: message.PChainOwner{
+				Threshold: 1,
+				Addresses: []ids.ShortID{
+					ids.GenerateTestShortID(),
+				},
 			},
-		},
-		message.PChainOwner{
-			Threshold: 1,
-			Addresses: []ids.ShortID{
-				ids.GenerateTestShortID(),
+			DeactivationOwner: message.PChainOwner{
+				Threshold: 1,
+				Addresses: []ids.ShortID{
+					ids.GenerateTestShortID(),
+				},
 			},
 		},
 		weight,
@@ -1008,6 +1112,165 @@ func TestDisableL1ValidatorTx(t *testing.T) {
 	}
 }
 
+func TestFeeMultiplier(t *testing.T) {
+	tests := []struct {
+		name        string
+		numerator   uint64
+		denominator uint64
+		expectedErr error
+	}{
+		{
+			name:        "no padding",
+			numerator:   1,
+			denominator: 1,
+		},
+		{
+			name:        "110%",
+			numerator:   11,
+			denominator: 10,
+		},
+		{
+			name:        "rounds up",
+			numerator:   4,
+			denominator: 3,
+		},
+		{
+			name:        "zero denominator",
+			numerator:   1,
+			denominator: 0,
+			expectedErr: builder.ErrInvalidFeeMultiplier,
+		},
+		{
+			name:        "reduces fee",
+			numerator:   9,
+			denominator: 10,
+			expectedErr: builder.ErrInvalidFeeMultiplier,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				require    = require.New(t)
+				chainUTXOs = utxotest.NewDeterministicChainUTXOs(t, map[ids.ID][]*avax.UTXO{
+					constants.PlatformChainID: utxos,
+				})
+				backend = wallet.NewBackend(testContextPostEtna, chainUTXOs, nil)
+				builder = builder.New(set.Of(utxoAddr), testContextPostEtna, backend)
+			)
+
+			utx, err := builder.NewBaseTx(
+				[]*avax.TransferableOutput{avaxOutput},
+				common.WithFeeMultiplier(test.numerator, test.denominator),
+			)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			requiredFee, err := dynamicFeeCalculator.CalculateFee(utx)
+			require.NoError(err)
+
+			amountConsumed := addInputAmounts(utx.Ins)
+			amountProduced := addOutputAmounts(utx.Outs)
+			burned := amountConsumed[avaxAssetID] - amountProduced[avaxAssetID]
+
+			paddedFee := requiredFee * test.numerator
+			expectedFee := paddedFee / test.denominator
+			if paddedFee%test.denominator != 0 {
+				expectedFee++
+			}
+			require.Equal(expectedFee, burned)
+			require.GreaterOrEqual(burned, requiredFee)
+		})
+	}
+}
+
+func TestSubnetAuthKeys(t *testing.T) {
+	// The keychain holds every control key of the subnet, which is more than
+	// the threshold requires.
+	var (
+		controlAddrs = []ids.ShortID{
+			testKeys[0].Address(),
+			testKeys[2].Address(),
+			testKeys[3].Address(),
+		}
+		owners = map[ids.ID]fx.Owner{
+			subnetID: &secp256k1fx.OutputOwners{
+				Threshold: 2,
+				Addrs:     controlAddrs,
+			},
+		}
+		addrs = set.Of(utxoAddr)
+	)
+	addrs.Add(controlAddrs...)
+
+	tests := []struct {
+		name               string
+		options            []common.Option
+		expectedSigIndices []uint32
+		expectedErr        error
+	}{
+		{
+			name:               "inferred from keychain",
+			expectedSigIndices: []uint32{0, 1},
+		},
+		{
+			name: "explicit keys",
+			options: []common.Option{
+				common.WithSubnetAuthKeys([]ids.ShortID{
+					controlAddrs[0],
+					controlAddrs[2],
+				}),
+			},
+			expectedSigIndices: []uint32{0, 2},
+		},
+		{
+			name: "insufficient explicit keys",
+			options: []common.Option{
+				common.WithSubnetAuthKeys([]ids.ShortID{
+					controlAddrs[1],
+				}),
+			},
+			expectedErr: builder.ErrInsufficientAuthorization,
+		},
+		{
+			name: "key not in keychain",
+			options: []common.Option{
+				common.WithSubnetAuthKeys([]ids.ShortID{
+					controlAddrs[1],
+					ids.GenerateTestShortID(),
+				}),
+			},
+			expectedErr: builder.ErrUnknownSubnetAuthKey,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				require    = require.New(t)
+				chainUTXOs = utxotest.NewDeterministicChainUTXOs(t, map[ids.ID][]*avax.UTXO{
+					constants.PlatformChainID: utxos,
+				})
+				backend = wallet.NewBackend(testContextPostEtna, chainUTXOs, owners)
+				builder = builder.New(addrs, testContextPostEtna, backend)
+			)
+
+			utx, err := builder.NewRemoveSubnetValidatorTx(
+				nodeID,
+				subnetID,
+				test.options...,
+			)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			subnetAuth := utx.SubnetAuth.(*secp256k1fx.Input)
+			require.Equal(test.expectedSigIndices, subnetAuth.SigIndices)
+		})
+	}
+}
+
 func makeTestUTXOs(utxosKey *secp256k1.PrivateKey) []*avax.UTXO {
 	// Note: we avoid ids.GenerateTestNodeID here to make sure that UTXO IDs
 	// won't change run by run. This simplifies checking what utxos are included
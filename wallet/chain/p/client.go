@@ -4,26 +4,65 @@
 package p
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/wallet/chain/p/wallet"
 	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
 )
 
-var _ wallet.Client = (*Client)(nil)
+const fetchLimit = 1024
+
+var (
+	_ wallet.Client        = (*Client)(nil)
+	_ wallet.Refresher     = (*Client)(nil)
+	_ wallet.PendingLister = (*Client)(nil)
+	_ wallet.StateExporter = (*Client)(nil)
+	_ wallet.UTXOSyncer    = (*Client)(nil)
+	_ wallet.TxAwaiter     = (*Client)(nil)
+	_ wallet.TxReissuer    = (*Client)(nil)
+
+	// ErrStaleFeeContext is returned when the node rejects a transaction and
+	// the gas price of the node has increased above the gas price of the
+	// wallet's context, so the transaction did not burn enough fees.
+	ErrStaleFeeContext = errors.New("stale fee context")
+)
 
 func NewClient(
 	c platformvm.Client,
 	b wallet.Backend,
+) *Client {
+	return NewClientWithAddresses(c, nil, b)
+}
+
+// NewClientWithAddresses returns a client that issues transactions to [c] and
+// applies the accepted transactions to [b]. [addrs] are the addresses whose
+// UTXOs are fetched when the client is refreshed and whose txs are reported as
+// pending.
+func NewClientWithAddresses(
+	c platformvm.Client,
+	addrs set.Set[ids.ShortID],
+	b wallet.Backend,
 ) *Client {
 	return &Client{
 		client:  c,
+		addrs:   addrs,
 		backend: b,
 	}
 }
 
 type Client struct {
 	client  platformvm.Client
+	addrs   set.Set[ids.ShortID]
 	backend wallet.Backend
 }
 
@@ -31,24 +70,240 @@ func (c *Client) IssueTx(
 	tx *txs.Tx,
 	options ...common.Option,
 ) error {
+	_, err := c.issue(tx, options...)
+	return err
+}
+
+func (c *Client) ReissueTx(
+	tx *txs.Tx,
+	options ...common.Option,
+) (wallet.ReissueStatus, error) {
+	return c.issue(
+		tx,
+		common.UnionOptions(options, []common.Option{common.WithIdempotencyCheck()})...,
+	)
+}
+
+// issue broadcasts [tx], unless the idempotency check finds that the node
+// already knows about it, and waits for it to be accepted. [tx] is only
+// applied to the backend if it was broadcast.
+func (c *Client) issue(
+	tx *txs.Tx,
+	options ...common.Option,
+) (wallet.ReissueStatus, error) {
 	ops := common.NewOptions(options)
 	ctx := ops.Context()
-	txID, err := c.client.IssueTx(ctx, tx.Bytes())
+	status, err := c.issueTx(ctx, tx, ops)
 	if err != nil {
-		return err
+		return status, err
 	}
 
 	if f := ops.PostIssuanceFunc(); f != nil {
-		f(txID)
+		f(tx.ID())
+	}
+
+	if !ops.AssumeDecided() {
+		if err := c.AwaitTx(tx, options...); err != nil {
+			return status, err
+		}
+	}
+	if status != wallet.Reissued {
+		return status, nil
+	}
+	return status, c.backend.AcceptTx(ctx, tx)
+}
+
+// AwaitTx waits for the issued [tx] to be accepted, and for any confirmations
+// requested in [options]. The backend is not modified.
+func (c *Client) AwaitTx(
+	tx *txs.Tx,
+	options ...common.Option,
+) error {
+	ops := common.NewOptions(options)
+	ctx := ops.Context()
+	txID := tx.ID()
+	err := platformvm.AwaitTxAcceptedWithGracePeriod(
+		c.client,
+		ctx,
+		txID,
+		ops.PollFrequency(),
+		ops.UnknownGracePeriod(),
+	)
+	if err != nil {
+		return err
+	}
+
+	if confirmations := ops.Confirmations(); confirmations > 0 {
+		return platformvm.AwaitTxConfirmations(c.client, ctx, txID, confirmations, ops.PollFrequency())
+	}
+	return nil
+}
+
+// issueTx broadcasts [tx] to the node. If the idempotency check is enabled and
+// the node already knows about [tx], it is not broadcast again.
+func (c *Client) issueTx(ctx context.Context, tx *txs.Tx, ops *common.Options) (wallet.ReissueStatus, error) {
+	if ops.IdempotencyCheck() {
+		txID := tx.ID()
+		res, err := c.client.GetTxStatus(ctx, txID)
+		if err != nil {
+			return wallet.Reissued, fmt.Errorf("failed to fetch status of tx %s: %w", txID, err)
+		}
+		switch res.Status {
+		case status.Processing:
+			return wallet.AlreadyProcessing, nil
+		case status.Committed, status.Aborted:
+			return wallet.AlreadyDecided, nil
+		}
 	}
 
-	if ops.AssumeDecided() {
-		return c.backend.AcceptTx(ctx, tx)
+	if _, err := c.client.IssueTx(ctx, tx.Bytes()); err != nil {
+		if c.isFeeContextStale(ctx) {
+			return wallet.Reissued, fmt.Errorf("%w: %w; refetch the context with NewContextFromClients and rebuild the wallet",
+				ErrStaleFeeContext,
+				err,
+			)
+		}
+		return wallet.Reissued, err
 	}
+	return wallet.Reissued, nil
+}
+
+// Refresh fetches all the P-chain UTXOs referenced by the addresses of the
+// client and replaces the UTXOs in the backend with them.
+//
+// The P-chain API does not expose the UTXOs that changed since a given height,
+// so every UTXO is re-fetched.
+//
+// Returns [wallet.ErrRefreshNotSupported] if the client was created without
+// addresses, as the backend would otherwise be emptied.
+func (c *Client) Refresh(ctx context.Context) error {
+	if c.addrs.Len() == 0 {
+		return fmt.Errorf("%w: client has no addresses", wallet.ErrRefreshNotSupported)
+	}
+
+	utxos, err := c.fetchUTXOs(ctx, c.addrs)
+	if err != nil {
+		return err
+	}
+	return c.backend.ReplaceUTXOs(ctx, utxos)
+}
 
-	if err := platformvm.AwaitTxAccepted(c.client, ctx, txID, ops.PollFrequency()); err != nil {
+// SyncUTXOs fetches all the P-chain UTXOs referenced by [addrs] and adds them
+// to the backend. The UTXOs already in the backend are not modified.
+func (c *Client) SyncUTXOs(ctx context.Context, addrs set.Set[ids.ShortID]) error {
+	utxos, err := c.fetchUTXOs(ctx, addrs)
+	if err != nil {
 		return err
 	}
+	for _, utxo := range utxos {
+		if err := c.backend.AddUTXO(ctx, constants.PlatformChainID, utxo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fetchUTXOs fetches all the P-chain UTXOs referenced by [addrs].
+func (c *Client) fetchUTXOs(ctx context.Context, addrs set.Set[ids.ShortID]) ([]*avax.UTXO, error) {
+	var (
+		addrList  = addrs.List()
+		utxos     []*avax.UTXO
+		startAddr ids.ShortID
+		startUTXO ids.ID
+	)
+	for {
+		utxosBytes, endAddr, endUTXO, err := c.client.GetUTXOs(
+			ctx,
+			addrList,
+			fetchLimit,
+			startAddr,
+			startUTXO,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, utxoBytes := range utxosBytes {
+			utxo := &avax.UTXO{}
+			if _, err := txs.Codec.Unmarshal(utxoBytes, utxo); err != nil {
+				return nil, err
+			}
+			utxos = append(utxos, utxo)
+		}
+
+		if len(utxosBytes) < fetchLimit {
+			return utxos, nil
+		}
+
+		// Update the vars to query the next page of UTXOs.
+		startAddr = endAddr
+		startUTXO = endUTXO
+	}
+}
+
+// ExportState returns the state of the backend along with the addresses of the
+// client. The P-chain height is fetched before reading the backend, so the
+// returned state is at least as recent as the reported height.
+func (c *Client) ExportState(ctx context.Context) (*wallet.State, error) {
+	height, err := c.client.GetHeight(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch P-chain height: %w", err)
+	}
+
+	state, err := c.backend.ExportState(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs := c.addrs.List()
+	utils.Sort(addrs)
+	state.Height = height
+	state.Addresses = addrs
+	return state, nil
+}
+
+// PendingFromKey returns the IDs of the txs in the mempool of the node that
+// spend inputs signed by any of the addresses of the client.
+//
+// The signers of a tx are recovered with [txs.SpendingAddresses], so txs that
+// were only authorized by the client, such as by a subnet authorization, are
+// not reported.
+func (c *Client) PendingFromKey(ctx context.Context) ([]ids.ID, error) {
+	txsBytes, err := c.client.GetMempoolTxBytes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	txIDs := make([]ids.ID, 0, len(txsBytes))
+	for _, txBytes := range txsBytes {
+		tx, err := txs.Parse(txs.Codec, txBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse mempool tx: %w", err)
+		}
+
+		signers, err := txs.SpendingAddresses(tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to recover signers of tx %s: %w", tx.ID(), err)
+		}
+		if c.addrs.Overlaps(set.Of(signers...)) {
+			txIDs = append(txIDs, tx.ID())
+		}
+	}
+	return txIDs, nil
+}
+
+// isFeeContextStale returns true if the node's current gas price exceeds the
+// gas price of the context that the backend builds txs with, which means that
+// txs are built burning too little.
+//
+// Errors returned over the API lose their type, so the node is asked for its
+// gas price rather than inspecting why the tx was rejected.
+func (c *Client) isFeeContextStale(ctx context.Context) bool {
+	state, err := c.backend.ExportState(ctx)
+	if err != nil || state.Context.GasPrice == 0 {
+		return false
+	}
 
-	return c.backend.AcceptTx(ctx, tx)
+	_, gasPrice, _, err := c.client.GetFeeState(ctx)
+	return err == nil && gasPrice > state.Context.GasPrice
 }
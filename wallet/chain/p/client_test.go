@@ -0,0 +1,685 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	"github.com/ava-labs/avalanchego/wallet/chain/p/wallet"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+
+	walletsigner "github.com/ava-labs/avalanchego/wallet/chain/p/signer"
+)
+
+// utxoNode is a platformvm.Client that maintains the UTXO set of the P-chain
+// by applying every issued base tx.
+type utxoNode struct {
+	platformvm.Client
+
+	lock  sync.Mutex
+	utxos map[ids.ID]*avax.UTXO
+}
+
+func (n *utxoNode) IssueTx(_ context.Context, txBytes []byte, _ ...rpc.Option) (ids.ID, error) {
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	if err != nil {
+		return ids.Empty, err
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	for _, in := range tx.Unsigned.InputIDs().List() {
+		delete(n.utxos, in)
+	}
+	for _, utxo := range tx.UTXOs() {
+		n.utxos[utxo.InputID()] = utxo
+	}
+	return tx.ID(), nil
+}
+
+func (n *utxoNode) GetUTXOs(
+	_ context.Context,
+	addrs []ids.ShortID,
+	_ uint32,
+	_ ids.ShortID,
+	_ ids.ID,
+	_ ...rpc.Option,
+) ([][]byte, ids.ShortID, ids.ID, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	addrSet := set.Of(addrs...)
+	var utxosBytes [][]byte
+	for _, utxo := range n.utxos {
+		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if !ok || !addrSet.Overlaps(set.Of(out.Addrs...)) {
+			continue
+		}
+
+		utxoBytes, err := txs.Codec.Marshal(txs.CodecVersion, utxo)
+		if err != nil {
+			return nil, ids.ShortEmpty, ids.Empty, err
+		}
+		utxosBytes = append(utxosBytes, utxoBytes)
+	}
+	return utxosBytes, ids.ShortEmpty, ids.Empty, nil
+}
+
+// issueErrNode is a platformvm.Client that rejects every issued tx with err
+// and reports [gasPrice] as its current gas price.
+type issueErrNode struct {
+	platformvm.Client
+
+	err      error
+	gasPrice gas.Price
+}
+
+func (n *issueErrNode) IssueTx(context.Context, []byte, ...rpc.Option) (ids.ID, error) {
+	return ids.Empty, n.err
+}
+
+func (n *issueErrNode) GetFeeState(context.Context, ...rpc.Option) (gas.State, gas.Price, time.Time, error) {
+	return gas.State{}, n.gasPrice, time.Time{}, nil
+}
+
+func TestClientIssueTxStaleFeeContext(t *testing.T) {
+	errTest := errors.New("non-nil error")
+	tests := []struct {
+		name          string
+		gasPrice      gas.Price
+		expectedStale bool
+	}{
+		{
+			name:          "gas price increased",
+			gasPrice:      11,
+			expectedStale: true,
+		},
+		{
+			// The context's gas price still covers the node's gas price, so
+			// the tx was rejected for another reason, such as spending more
+			// than is available.
+			name:     "gas price unchanged",
+			gasPrice: 10,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			var (
+				node    = &issueErrNode{err: errTest, gasPrice: test.gasPrice}
+				utxos   = common.NewChainUTXOs(constants.PlatformChainID, common.NewUTXOs())
+				backend = wallet.NewBackend(&builder.Context{GasPrice: 10}, utxos, nil)
+				client  = NewClient(node, backend)
+			)
+			err := client.IssueTx(&txs.Tx{
+				Unsigned: &txs.BaseTx{},
+			})
+			require.ErrorIs(err, errTest)
+			require.Equal(test.expectedStale, errors.Is(err, ErrStaleFeeContext))
+		})
+	}
+}
+
+// statusNode is a platformvm.Client that reports every issued tx as
+// committed.
+type statusNode struct {
+	platformvm.Client
+
+	issued []ids.ID
+}
+
+func (n *statusNode) IssueTx(_ context.Context, txBytes []byte, _ ...rpc.Option) (ids.ID, error) {
+	tx, err := txs.Parse(txs.Codec, txBytes)
+	if err != nil {
+		return ids.Empty, err
+	}
+
+	txID := tx.ID()
+	n.issued = append(n.issued, txID)
+	return txID, nil
+}
+
+func (n *statusNode) GetTxStatus(_ context.Context, txID ids.ID, _ ...rpc.Option) (*platformvm.GetTxStatusResponse, error) {
+	if slices.Contains(n.issued, txID) {
+		return &platformvm.GetTxStatusResponse{Status: status.Committed}, nil
+	}
+	return &platformvm.GetTxStatusResponse{Status: status.Unknown}, nil
+}
+
+func TestClientIssueTxIdempotencyCheck(t *testing.T) {
+	tests := []struct {
+		name           string
+		options        []common.Option
+		expectedIssued int
+	}{
+		{
+			name:           "without check",
+			expectedIssued: 2,
+		},
+		{
+			name: "with check",
+			options: []common.Option{
+				common.WithIdempotencyCheck(),
+			},
+			expectedIssued: 1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			tx := &txs.Tx{
+				Unsigned: &txs.BaseTx{
+					BaseTx: avax.BaseTx{
+						NetworkID:    constants.UnitTestID,
+						BlockchainID: constants.PlatformChainID,
+					},
+				},
+			}
+			require.NoError(tx.Initialize(txs.Codec))
+
+			var (
+				node    = &statusNode{}
+				utxos   = common.NewChainUTXOs(constants.PlatformChainID, common.NewUTXOs())
+				backend = wallet.NewBackend(&builder.Context{}, utxos, nil)
+				client  = NewClient(node, backend)
+			)
+			for i := 0; i < 2; i++ {
+				require.NoError(client.IssueTx(tx, test.options...))
+			}
+			require.Len(node.issued, test.expectedIssued)
+		})
+	}
+}
+
+func TestWalletReissue(t *testing.T) {
+	var (
+		require = require.New(t)
+		ctx     = context.Background()
+		addr    = secp256k1.TestKeys()[0].Address()
+		tx      = &txs.Tx{
+			Unsigned: &txs.BaseTx{
+				BaseTx: avax.BaseTx{
+					NetworkID:    constants.UnitTestID,
+					BlockchainID: constants.PlatformChainID,
+					Outs: []*avax.TransferableOutput{
+						{
+							Asset: avax.Asset{ID: ids.GenerateTestID()},
+							Out: &secp256k1fx.TransferOutput{
+								Amt: units.Avax,
+								OutputOwners: secp256k1fx.OutputOwners{
+									Threshold: 1,
+									Addrs:     []ids.ShortID{addr},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	)
+	require.NoError(tx.Initialize(txs.Codec))
+
+	var (
+		node    = &statusNode{}
+		utxos   = common.NewChainUTXOs(constants.PlatformChainID, common.NewUTXOs())
+		backend = wallet.NewBackend(&builder.Context{}, utxos, nil)
+		w       = wallet.New(NewClient(node, backend), nil, nil)
+		utxoID  = tx.UTXOs()[0].InputID()
+	)
+
+	// The node doesn't know about the tx, so it is broadcast and applied.
+	status, err := w.Reissue(tx)
+	require.NoError(err)
+	require.Equal(wallet.Reissued, status)
+	require.Equal([]ids.ID{tx.ID()}, node.issued)
+
+	_, err = utxos.GetUTXO(ctx, constants.PlatformChainID, utxoID)
+	require.NoError(err)
+
+	// Spend the produced UTXO.
+	require.NoError(utxos.RemoveUTXO(ctx, constants.PlatformChainID, utxoID))
+
+	// The node reports the tx as accepted, so it isn't broadcast again and
+	// the spent UTXO isn't re-added.
+	status, err = w.Reissue(tx)
+	require.NoError(err)
+	require.Equal(wallet.AlreadyDecided, status)
+	require.Equal([]ids.ID{tx.ID()}, node.issued)
+
+	_, err = utxos.GetUTXO(ctx, constants.PlatformChainID, utxoID)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestClientRefresh(t *testing.T) {
+	var (
+		require = require.New(t)
+		ctx     = context.Background()
+		key     = secp256k1.TestKeys()[0]
+		addr    = key.Address()
+		addrs   = set.Of(addr)
+		kc      = secp256k1fx.NewKeychain(key)
+		owner   = secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr},
+		}
+		avaxAssetID = ids.GenerateTestID()
+		testContext = &builder.Context{
+			NetworkID:   constants.UnitTestID,
+			AVAXAssetID: avaxAssetID,
+			ComplexityWeights: gas.Dimensions{
+				gas.Bandwidth: 1,
+				gas.DBRead:    10,
+				gas.DBWrite:   100,
+				gas.Compute:   1000,
+			},
+			GasPrice: 1,
+		}
+		initialUTXO = &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID: ids.GenerateTestID(),
+			},
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          units.Avax,
+				OutputOwners: owner,
+			},
+		}
+		node = &utxoNode{
+			utxos: map[ids.ID]*avax.UTXO{
+				initialUTXO.InputID(): initialUTXO,
+			},
+		}
+	)
+
+	// newWallet returns a wallet, along with its backend, that has not
+	// fetched any UTXOs.
+	newWallet := func() (wallet.Wallet, wallet.Backend) {
+		utxos := common.NewChainUTXOs(constants.PlatformChainID, common.NewUTXOs())
+		backend := wallet.NewBackend(testContext, utxos, nil)
+		return wallet.New(
+			NewClientWithAddresses(node, addrs, backend),
+			builder.New(addrs, testContext, backend),
+			walletsigner.New(kc, backend),
+		), backend
+	}
+
+	issuer, _ := newWallet()
+	require.NoError(issuer.Refresh(ctx))
+
+	observer, observerBackend := newWallet()
+	require.NoError(observer.Refresh(ctx))
+
+	observedUTXOIDs := func() []ids.ID {
+		utxos, err := observerBackend.UTXOs(ctx, constants.PlatformChainID)
+		require.NoError(err)
+
+		utxoIDs := make([]ids.ID, len(utxos))
+		for i, utxo := range utxos {
+			utxoIDs[i] = utxo.InputID()
+		}
+		return utxoIDs
+	}
+	require.Equal([]ids.ID{initialUTXO.InputID()}, observedUTXOIDs())
+
+	tx, err := issuer.IssueBaseTx(
+		[]*avax.TransferableOutput{
+			{
+				Asset: avax.Asset{ID: avaxAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: units.MilliAvax,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+					},
+				},
+			},
+		},
+		common.WithAssumeDecided(),
+	)
+	require.NoError(err)
+
+	// The change output is the only output of the tx owned by [addr].
+	var changeUTXO *avax.UTXO
+	for _, utxo := range tx.UTXOs() {
+		out := utxo.Out.(*secp256k1fx.TransferOutput)
+		if out.Addrs[0] == addr {
+			changeUTXO = utxo
+		}
+	}
+	require.NotNil(changeUTXO)
+
+	// The observer is unaware of the tx until it refreshes.
+	require.Equal([]ids.ID{initialUTXO.InputID()}, observedUTXOIDs())
+	require.NoError(observer.Refresh(ctx))
+	require.Equal([]ids.ID{changeUTXO.InputID()}, observedUTXOIDs())
+}
+
+func TestClientRefreshWithoutAddresses(t *testing.T) {
+	var (
+		require = require.New(t)
+		ctx     = context.Background()
+		utxo    = &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID: ids.GenerateTestID(),
+			},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: units.Avax,
+			},
+		}
+		utxos   = common.NewChainUTXOs(constants.PlatformChainID, common.NewUTXOs())
+		backend = wallet.NewBackend(&builder.Context{}, utxos, nil)
+		w       = wallet.New(NewClient(&utxoNode{}, backend), nil, nil)
+	)
+	require.NoError(backend.AddUTXO(ctx, constants.PlatformChainID, utxo))
+
+	// Refreshing without any addresses must not drop the known UTXOs.
+	err := w.Refresh(ctx)
+	require.ErrorIs(err, wallet.ErrRefreshNotSupported)
+
+	_, err = utxos.GetUTXO(ctx, constants.PlatformChainID, utxo.InputID())
+	require.NoError(err)
+}
+
+func TestWalletFeePayerSync(t *testing.T) {
+	var (
+		require   = require.New(t)
+		ctx       = context.Background()
+		ownerKey  = secp256k1.TestKeys()[0]
+		ownerAddr = ownerKey.Address()
+		payerKey  = secp256k1.TestKeys()[1]
+		payerAddr = payerKey.Address()
+
+		avaxAssetID = ids.GenerateTestID()
+		testContext = &builder.Context{
+			NetworkID:   constants.UnitTestID,
+			AVAXAssetID: avaxAssetID,
+			ComplexityWeights: gas.Dimensions{
+				gas.Bandwidth: 1,
+				gas.DBRead:    10,
+				gas.DBWrite:   100,
+				gas.Compute:   1000,
+			},
+			GasPrice: 1,
+		}
+		payerUTXO = &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID: ids.GenerateTestID(),
+			},
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: units.Avax,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{payerAddr},
+				},
+			},
+		}
+		node = &utxoNode{
+			utxos: map[ids.ID]*avax.UTXO{
+				payerUTXO.InputID(): payerUTXO,
+			},
+		}
+		addrs   = set.Of(ownerAddr)
+		utxos   = common.NewChainUTXOs(constants.PlatformChainID, common.NewUTXOs())
+		backend = wallet.NewBackend(testContext, utxos, nil)
+		w       = wallet.New(
+			NewClientWithAddresses(node, addrs, backend),
+			builder.New(addrs, testContext, backend),
+			walletsigner.New(secp256k1fx.NewKeychain(ownerKey), backend),
+		)
+	)
+
+	// The backend holds no UTXOs, so the UTXOs of the fee payer must be
+	// synced when the option is used.
+	tx, err := w.IssueBaseTx(
+		[]*avax.TransferableOutput{
+			{
+				Asset: avax.Asset{ID: avaxAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: units.MilliAvax,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{ownerAddr},
+					},
+				},
+			},
+		},
+		common.WithFeePayer(secp256k1fx.NewKeychain(payerKey)),
+		common.WithAssumeDecided(),
+	)
+	require.NoError(err)
+
+	ins := tx.Unsigned.(*txs.BaseTx).Ins
+	require.Len(ins, 1)
+	require.Equal(payerUTXO.InputID(), ins[0].InputID())
+
+	var changeUTXO *avax.UTXO
+	for _, utxo := range tx.UTXOs() {
+		out := utxo.Out.(*secp256k1fx.TransferOutput)
+		if slices.Contains(out.Addrs, payerAddr) {
+			changeUTXO = utxo
+		}
+	}
+	require.NotNil(changeUTXO)
+
+	// Refreshing drops the UTXOs of the fee payer from the backend, so they
+	// must be synced again.
+	require.NoError(w.Refresh(ctx))
+	tx, err = w.IssueBaseTx(
+		[]*avax.TransferableOutput{
+			{
+				Asset: avax.Asset{ID: avaxAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: units.MilliAvax,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{ownerAddr},
+					},
+				},
+			},
+		},
+		common.WithFeePayer(secp256k1fx.NewKeychain(payerKey)),
+		common.WithAssumeDecided(),
+	)
+	require.NoError(err)
+
+	ins = tx.Unsigned.(*txs.BaseTx).Ins
+	require.Len(ins, 1)
+	require.Equal(changeUTXO.InputID(), ins[0].InputID())
+}
+
+// mempoolNode is a platformvm.Client whose mempool contains [txs].
+type mempoolNode struct {
+	platformvm.Client
+
+	txs [][]byte
+}
+
+func (n *mempoolNode) GetMempoolTxBytes(context.Context, ...rpc.Option) ([][]byte, error) {
+	return n.txs, nil
+}
+
+func TestClientPendingFromKey(t *testing.T) {
+	var (
+		key      = secp256k1.TestKeys()[0]
+		otherKey = secp256k1.TestKeys()[1]
+	)
+	newSignedTx := func(t *testing.T, keys ...[]*secp256k1.PrivateKey) *txs.Tx {
+		utx := &txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: constants.PlatformChainID,
+			Ins: []*avax.TransferableInput{{
+				UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+				Asset:  avax.Asset{ID: ids.GenerateTestID()},
+				In: &secp256k1fx.TransferInput{
+					Amt:   units.Avax,
+					Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+				},
+			}},
+		}}
+		tx := &txs.Tx{Unsigned: utx}
+		require.NoError(t, tx.Sign(txs.Codec, keys))
+		return tx
+	}
+
+	var (
+		fromKey   = newSignedTx(t, []*secp256k1.PrivateKey{key})
+		fromOther = newSignedTx(t, []*secp256k1.PrivateKey{otherKey})
+		fromBoth  = newSignedTx(t, []*secp256k1.PrivateKey{otherKey, key})
+		// The credential following the input credentials, such as a subnet
+		// authorization, does not spend any inputs.
+		authByKey = newSignedTx(
+			t,
+			[]*secp256k1.PrivateKey{otherKey},
+			[]*secp256k1.PrivateKey{key},
+		)
+	)
+	tests := []struct {
+		name          string
+		mempool       []*txs.Tx
+		expectedTxIDs []ids.ID
+	}{
+		{
+			name:          "empty mempool",
+			expectedTxIDs: []ids.ID{},
+		},
+		{
+			name:          "only other keys",
+			mempool:       []*txs.Tx{fromOther},
+			expectedTxIDs: []ids.ID{},
+		},
+		{
+			name:          "mixed",
+			mempool:       []*txs.Tx{fromKey, fromOther, fromBoth},
+			expectedTxIDs: []ids.ID{fromKey.ID(), fromBoth.ID()},
+		},
+		{
+			name:          "only authorized by key",
+			mempool:       []*txs.Tx{authByKey},
+			expectedTxIDs: []ids.ID{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			txsBytes := make([][]byte, len(test.mempool))
+			for i, tx := range test.mempool {
+				txsBytes[i] = tx.Bytes()
+			}
+
+			var (
+				addrs   = set.Of(key.Address())
+				backend = wallet.NewBackend(&builder.Context{}, nil, nil)
+				client  = NewClientWithAddresses(&mempoolNode{txs: txsBytes}, addrs, backend)
+				w       = wallet.New(client, nil, nil)
+			)
+			txIDs, err := w.PendingFromKey(context.Background())
+			require.NoError(err)
+			require.Equal(test.expectedTxIDs, txIDs)
+		})
+	}
+}
+
+// heightNode is a utxoNode whose P-chain is at [height].
+type heightNode struct {
+	*utxoNode
+
+	height uint64
+}
+
+func (n *heightNode) GetHeight(context.Context, ...rpc.Option) (uint64, error) {
+	return n.height, nil
+}
+
+func TestClientExportState(t *testing.T) {
+	var (
+		require     = require.New(t)
+		ctx         = context.Background()
+		key         = secp256k1.TestKeys()[0]
+		addr        = key.Address()
+		addrs       = set.Of(addr)
+		subnetID    = ids.GenerateTestID()
+		avaxAssetID = ids.GenerateTestID()
+		testContext = &builder.Context{
+			NetworkID:   constants.UnitTestID,
+			AVAXAssetID: avaxAssetID,
+			GasPrice:    1,
+		}
+		owner = &secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr},
+		}
+		utxo = &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID: ids.GenerateTestID(),
+			},
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          units.Avax,
+				OutputOwners: *owner,
+			},
+		}
+		node = &heightNode{
+			utxoNode: &utxoNode{
+				utxos: map[ids.ID]*avax.UTXO{
+					utxo.InputID(): utxo,
+				},
+			},
+			height: 10,
+		}
+		utxos   = common.NewChainUTXOs(constants.PlatformChainID, common.NewUTXOs())
+		backend = wallet.NewBackend(testContext, utxos, map[ids.ID]fx.Owner{
+			subnetID: owner,
+		})
+		w = wallet.New(NewClientWithAddresses(node, addrs, backend), nil, nil)
+	)
+	require.NoError(w.Refresh(ctx))
+
+	stateBytes, err := w.ExportState(ctx)
+	require.NoError(err)
+
+	var state wallet.State
+	require.NoError(json.Unmarshal(stateBytes, &state))
+	require.Equal(uint64(10), state.Height)
+	require.Equal([]ids.ShortID{addr}, state.Addresses)
+	require.Equal(testContext, state.Context)
+	require.Len(state.UTXOs, 1)
+	require.Equal(utxo.InputID(), state.UTXOs[0].InputID())
+	require.Equal(utxo.Out, state.UTXOs[0].Out)
+	require.Equal(map[ids.ID]fx.Owner{subnetID: owner}, state.Owners)
+
+	// Exported states are versioned so that older states are rejected once
+	// the format changes.
+	stateBytes = []byte(strings.Replace(string(stateBytes), `"version":0`, `"version":1`, 1))
+	err = json.Unmarshal(stateBytes, &state)
+	require.ErrorIs(err, wallet.ErrUnknownStateVersion)
+}
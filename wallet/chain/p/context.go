@@ -5,9 +5,11 @@ package p
 
 import (
 	"context"
+	"errors"
 
 	"github.com/ava-labs/avalanchego/api/info"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/rpc"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/fee"
 	"github.com/ava-labs/avalanchego/wallet/chain/p/builder"
@@ -52,11 +54,23 @@ func NewContextFromClients(
 			return nil, err
 		}
 
+		// Nodes running releases that predate platform.getValidatorFeeState
+		// can't report the validator fee price, so it is left as zero rather
+		// than preventing the wallet from being created.
+		_, validatorFeePrice, _, err := chainClient.GetValidatorFeeState(ctx)
+		switch {
+		case errors.Is(err, rpc.ErrMethodNotFound):
+			validatorFeePrice = 0
+		case err != nil:
+			return nil, err
+		}
+
 		return &builder.Context{
 			NetworkID:         networkID,
 			AVAXAssetID:       avaxAssetID,
 			ComplexityWeights: dynamicFeeConfig.Weights,
 			GasPrice:          gasPriceMultiplier * gasPrice,
+			ValidatorFeePrice: validatorFeePrice,
 		}, nil
 	}
 
@@ -0,0 +1,112 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package p
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+)
+
+var errTest = errors.New("non-nil error")
+
+type networkIDClient struct {
+	info.Client
+
+	networkID uint32
+}
+
+func (c *networkIDClient) GetNetworkID(context.Context, ...rpc.Option) (uint32, error) {
+	return c.networkID, nil
+}
+
+// feeStateClient is a post-Etna P-chain that reports [validatorFeeErr] if
+// the validator fee state is requested.
+type feeStateClient struct {
+	platformvm.Client
+
+	avaxAssetID       ids.ID
+	gasPrice          gas.Price
+	validatorFeePrice gas.Price
+	validatorFeeErr   error
+}
+
+func (c *feeStateClient) GetStakingAssetID(context.Context, ids.ID, ...rpc.Option) (ids.ID, error) {
+	return c.avaxAssetID, nil
+}
+
+func (*feeStateClient) GetFeeConfig(context.Context, ...rpc.Option) (*gas.Config, error) {
+	return &gas.Config{MinPrice: 1}, nil
+}
+
+func (c *feeStateClient) GetFeeState(context.Context, ...rpc.Option) (gas.State, gas.Price, time.Time, error) {
+	return gas.State{}, c.gasPrice, time.Time{}, nil
+}
+
+func (c *feeStateClient) GetValidatorFeeState(context.Context, ...rpc.Option) (gas.Gas, gas.Price, time.Time, error) {
+	return 0, c.validatorFeePrice, time.Time{}, c.validatorFeeErr
+}
+
+func TestNewContextFromClientsValidatorFee(t *testing.T) {
+	tests := []struct {
+		name                      string
+		validatorFeePrice         gas.Price
+		validatorFeeErr           error
+		expectedValidatorFeePrice gas.Price
+		expectedErr               error
+	}{
+		{
+			name:                      "validator fee state supported",
+			validatorFeePrice:         512,
+			expectedValidatorFeePrice: 512,
+		},
+		{
+			name:              "validator fee state unsupported",
+			validatorFeePrice: 512,
+			validatorFeeErr:   fmt.Errorf("%w: can't find method", rpc.ErrMethodNotFound),
+		},
+		{
+			name:              "validator fee state failed",
+			validatorFeePrice: 512,
+			validatorFeeErr:   errTest,
+			expectedErr:       errTest,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			avaxAssetID := ids.GenerateTestID()
+			pCTX, err := NewContextFromClients(
+				context.Background(),
+				&networkIDClient{networkID: constants.UnitTestID},
+				&feeStateClient{
+					avaxAssetID:       avaxAssetID,
+					gasPrice:          10,
+					validatorFeePrice: test.validatorFeePrice,
+					validatorFeeErr:   test.validatorFeeErr,
+				},
+			)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.Equal(constants.UnitTestID, pCTX.NetworkID)
+			require.Equal(avaxAssetID, pCTX.AVAXAssetID)
+			require.Equal(gasPriceMultiplier*gas.Price(10), pCTX.GasPrice)
+			require.Equal(test.expectedValidatorFeePrice, pCTX.ValidatorFeePrice)
+		})
+	}
+}
@@ -4,6 +4,8 @@
 package signer
 
 import (
+	"errors"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
@@ -13,7 +15,11 @@ import (
 	stdcontext "context"
 )
 
-var _ Signer = (*txSigner)(nil)
+var (
+	_ Signer = (*txSigner)(nil)
+
+	ErrUnsupportedSigner = errors.New("unsupported signer")
+)
 
 type Signer interface {
 	// Sign adds as many missing signatures as possible to the provided
@@ -44,6 +50,22 @@ func New(kc keychain.Keychain, backend Backend) Signer {
 	}
 }
 
+// WithKeychain returns a signer that signs with the keys of both [signer] and
+// [kc]. This allows a transaction to be signed by multiple parties, such as a
+// fee payer and an owner.
+//
+// Returns [ErrUnsupportedSigner] if [signer] was not returned by [New].
+func WithKeychain(signer Signer, kc keychain.Keychain) (Signer, error) {
+	s, ok := signer.(*txSigner)
+	if !ok {
+		return nil, ErrUnsupportedSigner
+	}
+	return &txSigner{
+		kc:      keychain.Combine(s.kc, kc),
+		backend: s.backend,
+	}, nil
+}
+
 func (s *txSigner) Sign(ctx stdcontext.Context, tx *txs.Tx) error {
 	return tx.Unsigned.Visit(&visitor{
 		kc:      s.kc,
@@ -5,6 +5,7 @@ package wallet
 
 import (
 	"context"
+	"maps"
 	"sync"
 
 	"github.com/ava-labs/avalanchego/database"
@@ -27,6 +28,19 @@ type Backend interface {
 	signer.Backend
 
 	AcceptTx(ctx context.Context, tx *txs.Tx) error
+
+	// AddUTXO adds [utxo] to the UTXOs that are spendable on
+	// [destinationChainID].
+	AddUTXO(ctx context.Context, destinationChainID ids.ID, utxo *avax.UTXO) error
+
+	// ReplaceUTXOs replaces the P-chain UTXOs with [utxos]. Imported UTXOs
+	// are not modified.
+	ReplaceUTXOs(ctx context.Context, utxos []*avax.UTXO) error
+
+	// ExportState returns the context, P-chain UTXOs, and owners of the
+	// backend. The height and addresses of the returned state are not
+	// populated.
+	ExportState(ctx context.Context) (*State, error)
 }
 
 type backend struct {
@@ -61,6 +75,46 @@ func (b *backend) AcceptTx(ctx context.Context, tx *txs.Tx) error {
 	return b.addUTXOs(ctx, constants.PlatformChainID, producedUTXOSlice)
 }
 
+func (b *backend) ReplaceUTXOs(ctx context.Context, utxos []*avax.UTXO) error {
+	currentUTXOs, err := b.UTXOs(ctx, constants.PlatformChainID)
+	if err != nil {
+		return err
+	}
+
+	newUTXOIDs := set.NewSet[ids.ID](len(utxos))
+	for _, utxo := range utxos {
+		newUTXOIDs.Add(utxo.InputID())
+	}
+
+	spentUTXOIDs := set.NewSet[ids.ID](len(currentUTXOs))
+	for _, utxo := range currentUTXOs {
+		utxoID := utxo.InputID()
+		if !newUTXOIDs.Contains(utxoID) {
+			spentUTXOIDs.Add(utxoID)
+		}
+	}
+	if err := b.removeUTXOs(ctx, constants.PlatformChainID, spentUTXOIDs); err != nil {
+		return err
+	}
+	return b.addUTXOs(ctx, constants.PlatformChainID, utxos)
+}
+
+func (b *backend) ExportState(ctx context.Context) (*State, error) {
+	utxos, err := b.UTXOs(ctx, constants.PlatformChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	b.ownersLock.RLock()
+	defer b.ownersLock.RUnlock()
+
+	return &State{
+		Context: b.context,
+		UTXOs:   utxos,
+		Owners:  maps.Clone(b.owners),
+	}, nil
+}
+
 func (b *backend) addUTXOs(ctx context.Context, destinationChainID ids.ID, utxos []*avax.UTXO) error {
 	for _, utxo := range utxos {
 		if err := b.AddUTXO(ctx, destinationChainID, utxo); err != nil {
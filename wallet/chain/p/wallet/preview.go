@@ -0,0 +1,268 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/p/signer"
+)
+
+var (
+	_ txs.Visitor = (*previewVisitor)(nil)
+
+	ErrProducedMoreThanConsumed = errors.New("tx produces more than it consumes")
+)
+
+// TxPreview is a breakdown of the funds consumed and produced by a
+// transaction.
+type TxPreview struct {
+	// Inputs are the UTXOs consumed by the transaction, including any UTXOs
+	// imported from another chain.
+	Inputs []*avax.TransferableInput
+	// Outputs are the UTXOs produced on the P-chain by the transaction.
+	Outputs []*avax.TransferableOutput
+	// Change are the [Outputs] that are returned to an owner of the UTXOs
+	// consumed by the transaction.
+	Change []*avax.TransferableOutput
+	// Staked are the outputs locked for the duration of a staking period.
+	Staked []*avax.TransferableOutput
+	// Exported are the outputs sent to another chain.
+	Exported []*avax.TransferableOutput
+	// Balance is the amount of AVAX given to L1 validators to pay for their
+	// continuous fees.
+	Balance uint64
+	// Fee is the amount of AVAX burned by the transaction.
+	Fee uint64
+}
+
+// PreviewTx calculates the breakdown of the funds consumed and produced by
+// [tx] using the UTXOs and owners known by [backend]. The UTXOs consumed by
+// [tx] must be known by [backend], so this should be called before [tx] is
+// issued.
+func PreviewTx(
+	ctx context.Context,
+	backend signer.Backend,
+	avaxAssetID ids.ID,
+	tx *txs.Tx,
+) (*TxPreview, error) {
+	visitor := &previewVisitor{
+		preview: &TxPreview{},
+	}
+	if err := tx.Unsigned.Visit(visitor); err != nil {
+		return nil, err
+	}
+
+	preview := visitor.preview
+	spenders := set.Set[ids.ShortID]{}
+	for _, in := range preview.Inputs {
+		utxo, err := backend.GetUTXO(ctx, visitor.sourceChainID(in), in.InputID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get UTXO %s: %w", in.InputID(), err)
+		}
+		if out, ok := utxo.Out.(*secp256k1fx.TransferOutput); ok {
+			spenders.Add(out.Addrs...)
+		}
+	}
+	for _, out := range preview.Outputs {
+		transferOut, ok := out.Out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			continue
+		}
+		for _, addr := range transferOut.Addrs {
+			if spenders.Contains(addr) {
+				preview.Change = append(preview.Change, out)
+				break
+			}
+		}
+	}
+
+	consumed, err := sumInputs(avaxAssetID, preview.Inputs)
+	if err != nil {
+		return nil, err
+	}
+	produced := preview.Balance
+	for _, outs := range [][]*avax.TransferableOutput{
+		preview.Outputs,
+		preview.Staked,
+		preview.Exported,
+	} {
+		amount, err := sumOutputs(avaxAssetID, outs)
+		if err != nil {
+			return nil, err
+		}
+		produced, err = math.Add(produced, amount)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if produced > consumed {
+		return nil, fmt.Errorf("%w: %d > %d", ErrProducedMoreThanConsumed, produced, consumed)
+	}
+	preview.Fee = consumed - produced
+	return preview, nil
+}
+
+func sumInputs(assetID ids.ID, ins []*avax.TransferableInput) (uint64, error) {
+	var sum uint64
+	for _, in := range ins {
+		if in.AssetID() != assetID {
+			continue
+		}
+		var err error
+		sum, err = math.Add(sum, in.In.Amount())
+		if err != nil {
+			return 0, err
+		}
+	}
+	return sum, nil
+}
+
+func sumOutputs(assetID ids.ID, outs []*avax.TransferableOutput) (uint64, error) {
+	var sum uint64
+	for _, out := range outs {
+		if out.AssetID() != assetID {
+			continue
+		}
+		var err error
+		sum, err = math.Add(sum, out.Out.Amount())
+		if err != nil {
+			return 0, err
+		}
+	}
+	return sum, nil
+}
+
+// previewVisitor collects the inputs and outputs of a transaction.
+type previewVisitor struct {
+	preview *TxPreview
+
+	// importedInputs are the IDs of the inputs consumed from
+	// [importSourceChainID].
+	importedInputs      set.Set[ids.ID]
+	importSourceChainID ids.ID
+}
+
+// sourceChainID returns the chain that [in] is consumed from.
+func (p *previewVisitor) sourceChainID(in *avax.TransferableInput) ids.ID {
+	if p.importedInputs.Contains(in.InputID()) {
+		return p.importSourceChainID
+	}
+	return constants.PlatformChainID
+}
+
+func (*previewVisitor) AdvanceTimeTx(*txs.AdvanceTimeTx) error {
+	return ErrUnsupportedTxType
+}
+
+func (*previewVisitor) RewardValidatorTx(*txs.RewardValidatorTx) error {
+	return ErrUnsupportedTxType
+}
+
+func (p *previewVisitor) AddValidatorTx(tx *txs.AddValidatorTx) error {
+	p.preview.Staked = tx.StakeOuts
+	return p.baseTx(&tx.BaseTx)
+}
+
+func (p *previewVisitor) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error {
+	return p.baseTx(&tx.BaseTx)
+}
+
+func (p *previewVisitor) AddDelegatorTx(tx *txs.AddDelegatorTx) error {
+	p.preview.Staked = tx.StakeOuts
+	return p.baseTx(&tx.BaseTx)
+}
+
+func (p *previewVisitor) CreateChainTx(tx *txs.CreateChainTx) error {
+	return p.baseTx(&tx.BaseTx)
+}
+
+func (p *previewVisitor) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
+	return p.baseTx(&tx.BaseTx)
+}
+
+func (p *previewVisitor) ImportTx(tx *txs.ImportTx) error {
+	p.importedInputs = tx.InputUTXOs()
+	p.importSourceChainID = tx.SourceChain
+	if err := p.baseTx(&tx.BaseTx); err != nil {
+		return err
+	}
+	p.preview.Inputs = append(p.preview.Inputs, tx.ImportedInputs...)
+	return nil
+}
+
+func (p *previewVisitor) ExportTx(tx *txs.ExportTx) error {
+	p.preview.Exported = tx.ExportedOutputs
+	return p.baseTx(&tx.BaseTx)
+}
+
+func (p *previewVisitor) RemoveSubnetValidatorTx(tx *txs.RemoveSubnetValidatorTx) error {
+	return p.baseTx(&tx.BaseTx)
+}
+
+func (p *previewVisitor) TransformSubnetTx(tx *txs.TransformSubnetTx) error {
+	return p.baseTx(&tx.BaseTx)
+}
+
+func (p *previewVisitor) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	p.preview.Staked = tx.StakeOuts
+	return p.baseTx(&tx.BaseTx)
+}
+
+func (p *previewVisitor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	p.preview.Staked = tx.StakeOuts
+	return p.baseTx(&tx.BaseTx)
+}
+
+func (p *previewVisitor) TransferSubnetOwnershipTx(tx *txs.TransferSubnetOwnershipTx) error {
+	return p.baseTx(&tx.BaseTx)
+}
+
+func (p *previewVisitor) BaseTx(tx *txs.BaseTx) error {
+	return p.baseTx(tx)
+}
+
+func (p *previewVisitor) ConvertSubnetToL1Tx(tx *txs.ConvertSubnetToL1Tx) error {
+	for _, vdr := range tx.Validators {
+		balance, err := math.Add(p.preview.Balance, vdr.Balance)
+		if err != nil {
+			return err
+		}
+		p.preview.Balance = balance
+	}
+	return p.baseTx(&tx.BaseTx)
+}
+
+func (p *previewVisitor) RegisterL1ValidatorTx(tx *txs.RegisterL1ValidatorTx) error {
+	p.preview.Balance = tx.Balance
+	return p.baseTx(&tx.BaseTx)
+}
+
+func (p *previewVisitor) SetL1ValidatorWeightTx(tx *txs.SetL1ValidatorWeightTx) error {
+	return p.baseTx(&tx.BaseTx)
+}
+
+func (p *previewVisitor) IncreaseL1ValidatorBalanceTx(tx *txs.IncreaseL1ValidatorBalanceTx) error {
+	p.preview.Balance = tx.Balance
+	return p.baseTx(&tx.BaseTx)
+}
+
+func (p *previewVisitor) DisableL1ValidatorTx(tx *txs.DisableL1ValidatorTx) error {
+	return p.baseTx(&tx.BaseTx)
+}
+
+func (p *previewVisitor) baseTx(tx *txs.BaseTx) error {
+	p.preview.Inputs = append(p.preview.Inputs, tx.Ins...)
+	p.preview.Outputs = tx.Outs
+	return nil
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wallet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/fee"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common/utxotest"
+
+	walletsigner "github.com/ava-labs/avalanchego/wallet/chain/p/signer"
+)
+
+func TestPreviewTx(t *testing.T) {
+	var (
+		require = require.New(t)
+		ctx     = context.Background()
+		key     = secp256k1.TestKeys()[0]
+		addr    = key.Address()
+		owner   = secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr},
+		}
+		recipient = secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+		}
+		avaxAssetID = ids.GenerateTestID()
+		testContext = &builder.Context{
+			NetworkID:   constants.UnitTestID,
+			AVAXAssetID: avaxAssetID,
+			ComplexityWeights: gas.Dimensions{
+				gas.Bandwidth: 1,
+				gas.DBRead:    10,
+				gas.DBWrite:   100,
+				gas.Compute:   1000,
+			},
+			GasPrice: 1,
+		}
+		utxo = &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID: ids.GenerateTestID(),
+			},
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          units.Avax,
+				OutputOwners: owner,
+			},
+		}
+		chainUTXOs = utxotest.NewDeterministicChainUTXOs(t, map[ids.ID][]*avax.UTXO{
+			constants.PlatformChainID: {utxo},
+		})
+		backend   = NewBackend(testContext, chainUTXOs, nil)
+		txBuilder = builder.New(set.Of(addr), testContext, backend)
+		txSigner  = walletsigner.New(secp256k1fx.NewKeychain(key), backend)
+		output    = &avax.TransferableOutput{
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          units.MilliAvax,
+				OutputOwners: recipient,
+			},
+		}
+	)
+
+	utx, err := txBuilder.NewBaseTx([]*avax.TransferableOutput{output})
+	require.NoError(err)
+	tx, err := walletsigner.SignUnsigned(ctx, txSigner, utx)
+	require.NoError(err)
+
+	preview, err := PreviewTx(ctx, backend, avaxAssetID, tx)
+	require.NoError(err)
+
+	require.Len(preview.Inputs, 1)
+	require.Equal(utxo.InputID(), preview.Inputs[0].InputID())
+	require.Equal(units.Avax, preview.Inputs[0].In.Amount())
+
+	require.Len(preview.Outputs, 2)
+	require.Contains(preview.Outputs, output)
+	require.Len(preview.Change, 1)
+	require.NotEqual(output, preview.Change[0])
+	require.Empty(preview.Staked)
+	require.Empty(preview.Exported)
+	require.Zero(preview.Balance)
+
+	expectedFee, err := fee.NewDynamicCalculator(
+		testContext.ComplexityWeights,
+		testContext.GasPrice,
+	).CalculateFee(utx)
+	require.NoError(err)
+	require.Equal(expectedFee, preview.Fee)
+	require.Equal(
+		units.Avax,
+		preview.Fee+output.Out.Amount()+preview.Change[0].Out.Amount(),
+	)
+
+	// Once the tx is accepted, its inputs are no longer known to the backend.
+	require.NoError(backend.AcceptTx(ctx, tx))
+	_, err = PreviewTx(ctx, backend, avaxAssetID, tx)
+	require.ErrorIs(err, database.ErrNotFound)
+}
@@ -0,0 +1,118 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wallet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/types"
+	"github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+)
+
+const stateVersion = 0
+
+var (
+	ErrExportNotSupported  = errors.New("client does not support exporting state")
+	ErrUnknownStateVersion = errors.New("unknown state version")
+	ErrMissingStateContext = errors.New("missing state context")
+)
+
+// State is a snapshot of a synced P-chain wallet that can be used to create
+// a wallet without fetching all of its UTXOs.
+type State struct {
+	// Height is a P-chain height that the snapshot is at least as recent as.
+	Height uint64
+	// Addresses are the addresses whose UTXOs were synced.
+	Addresses []ids.ShortID
+	Context   *builder.Context
+	UTXOs     []*avax.UTXO
+	Owners    map[ids.ID]fx.Owner
+}
+
+// owner wraps an [fx.Owner] so that it is serialized along with its type.
+type owner struct {
+	Owner fx.Owner `serialize:"true"`
+}
+
+// state is the serialized format of a [State]. UTXOs and owners are encoded
+// with [txs.Codec].
+type state struct {
+	Version   uint16                         `json:"version"`
+	Height    uint64                         `json:"height"`
+	Addresses []ids.ShortID                  `json:"addresses"`
+	Context   *builder.Context               `json:"context"`
+	UTXOs     []types.JSONByteSlice          `json:"utxos"`
+	Owners    map[ids.ID]types.JSONByteSlice `json:"owners"`
+}
+
+func (s *State) MarshalJSON() ([]byte, error) {
+	serialized := state{
+		Version:   stateVersion,
+		Height:    s.Height,
+		Addresses: s.Addresses,
+		Context:   s.Context,
+		UTXOs:     make([]types.JSONByteSlice, len(s.UTXOs)),
+		Owners:    make(map[ids.ID]types.JSONByteSlice, len(s.Owners)),
+	}
+	for i, utxo := range s.UTXOs {
+		utxoBytes, err := txs.Codec.Marshal(txs.CodecVersion, utxo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal UTXO %s: %w", utxo.InputID(), err)
+		}
+		serialized.UTXOs[i] = utxoBytes
+	}
+	for ownerID, o := range s.Owners {
+		ownerBytes, err := txs.Codec.Marshal(txs.CodecVersion, &owner{Owner: o})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal owner %s: %w", ownerID, err)
+		}
+		serialized.Owners[ownerID] = ownerBytes
+	}
+	return json.Marshal(&serialized)
+}
+
+func (s *State) UnmarshalJSON(b []byte) error {
+	var serialized state
+	if err := json.Unmarshal(b, &serialized); err != nil {
+		return err
+	}
+	if serialized.Version != stateVersion {
+		return fmt.Errorf("%w: %d", ErrUnknownStateVersion, serialized.Version)
+	}
+	if serialized.Context == nil {
+		return ErrMissingStateContext
+	}
+
+	utxos := make([]*avax.UTXO, len(serialized.UTXOs))
+	for i, utxoBytes := range serialized.UTXOs {
+		utxo := &avax.UTXO{}
+		if _, err := txs.Codec.Unmarshal(utxoBytes, utxo); err != nil {
+			return fmt.Errorf("failed to unmarshal UTXO %d: %w", i, err)
+		}
+		utxos[i] = utxo
+	}
+	owners := make(map[ids.ID]fx.Owner, len(serialized.Owners))
+	for ownerID, ownerBytes := range serialized.Owners {
+		var o owner
+		if _, err := txs.Codec.Unmarshal(ownerBytes, &o); err != nil {
+			return fmt.Errorf("failed to unmarshal owner %s: %w", ownerID, err)
+		}
+		owners[ownerID] = o.Owner
+	}
+
+	*s = State{
+		Height:    serialized.Height,
+		Addresses: serialized.Addresses,
+		Context:   serialized.Context,
+		UTXOs:     utxos,
+		Owners:    owners,
+	}
+	return nil
+}
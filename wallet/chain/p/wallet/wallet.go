@@ -4,10 +4,17 @@
 package wallet
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
@@ -18,7 +25,50 @@ import (
 	walletsigner "github.com/ava-labs/avalanchego/wallet/chain/p/signer"
 )
 
-var _ Wallet = (*wallet)(nil)
+// MinInitialL1BalanceDuration is the duration that the balance returned by
+// [Wallet.MinInitialL1Balance] is expected to fund an L1 validator for.
+const MinInitialL1BalanceDuration = 24 * time.Hour
+
+var (
+	_ Wallet = (*wallet)(nil)
+
+	ErrRefreshNotSupported = errors.New("client does not support refreshing")
+	ErrPendingNotSupported = errors.New("client does not support listing pending txs")
+	ErrReissueNotSupported = errors.New("client does not support reissuing txs")
+	ErrUnknownValidatorFee = errors.New("unknown validator fee")
+)
+
+// ReissueStatus describes what [Wallet.Reissue] found the node to know about a
+// tx.
+type ReissueStatus uint8
+
+const (
+	// Reissued means that the node did not know about the tx, so it was
+	// broadcast again.
+	Reissued ReissueStatus = iota
+	// AlreadyProcessing means that the node was already processing the tx, so
+	// it was not broadcast again.
+	AlreadyProcessing
+	// AlreadyDecided means that the tx was already committed or aborted, so it
+	// was not broadcast again.
+	AlreadyDecided
+)
+
+// RegisterItem describes an L1 validator to be registered with a
+// RegisterL1ValidatorTx.
+type RegisterItem struct {
+	// Balance that the validator should allocate to continuous fees.
+	Balance uint64
+	// ProofOfPossession is the BLS PoP for the key included in [Message].
+	ProofOfPossession [bls.SignatureLen]byte
+	// Message is the Warp message that authorizes the validator to be added.
+	Message []byte
+}
+
+// UnsignedTxBuilder builds an unsigned tx with [b]. It is used by
+// [Wallet.IssueSequential] so that each tx can be built after the previous txs
+// were accepted, allowing it to spend their outputs.
+type UnsignedTxBuilder func(b builder.Builder) (txs.UnsignedTx, error)
 
 type Client interface {
 	// IssueTx issues the signed tx.
@@ -28,6 +78,64 @@ type Client interface {
 	) error
 }
 
+// Refresher is optionally implemented by a Client that is able to re-sync the
+// backend with the UTXOs currently reported by a node.
+type Refresher interface {
+	// Refresh replaces the UTXOs in the backend with the UTXOs currently
+	// reported by the node.
+	Refresh(ctx context.Context) error
+}
+
+// PendingLister is optionally implemented by a Client that is able to report
+// the txs issued by its addresses that are still in the mempool of a node.
+type PendingLister interface {
+	// PendingFromKey returns the IDs of the txs in the mempool that were
+	// signed by the addresses of the client.
+	PendingFromKey(ctx context.Context) ([]ids.ID, error)
+}
+
+// StateExporter is optionally implemented by a Client that is able to export
+// the state of its backend.
+type StateExporter interface {
+	// ExportState returns the state of the backend, including a P-chain
+	// height that the state is at least as recent as.
+	ExportState(ctx context.Context) (*State, error)
+}
+
+// UTXOSyncer is optionally implemented by a Client that is able to fetch the
+// UTXOs of addresses other than its own, such as the addresses of a fee payer.
+type UTXOSyncer interface {
+	// SyncUTXOs adds the P-chain UTXOs referenced by [addrs] to the backend.
+	SyncUTXOs(ctx context.Context, addrs set.Set[ids.ShortID]) error
+}
+
+// TxReissuer is optionally implemented by a Client that is able to report
+// whether a tx had to be broadcast again.
+type TxReissuer interface {
+	// ReissueTx issues [tx] unless the node already knows about it. [tx] is
+	// only applied to the backend if it was broadcast, as a tx that the node
+	// already knows about was applied when it was first issued.
+	ReissueTx(tx *txs.Tx, options ...common.Option) (ReissueStatus, error)
+}
+
+// TxAwaiter is optionally implemented by a Client that is able to wait for an
+// issued tx to be accepted separately from issuing it.
+type TxAwaiter interface {
+	// AwaitTx waits for the issued [tx] to be accepted without applying it to
+	// the backend.
+	AwaitTx(tx *txs.Tx, options ...common.Option) error
+}
+
+// Wallet is safe for concurrent use. Transactions are built, signed, issued,
+// and applied to the backend one at a time so that the same UTXO is never
+// consumed by multiple transactions.
+//
+// If the client implements [TxAwaiter], each transaction is applied to the
+// backend as soon as it is issued, and the wallet waits for it to be accepted
+// without blocking other callers. If the transaction is then not accepted,
+// [Wallet.Refresh] restores its UTXOs. Otherwise, the wallet waits for each
+// transaction to be accepted before releasing its UTXOs to the next caller, so
+// [common.WithAssumeDecided] can be used to increase issuance throughput.
 type Wallet interface {
 	Client
 
@@ -313,6 +421,100 @@ type Wallet interface {
 		utx txs.UnsignedTx,
 		options ...common.Option,
 	) (*txs.Tx, error)
+
+	// IssueSequential builds, signs, and issues a tx with each of [builders]
+	// in order. Each tx is accepted, and its outputs are added to the
+	// backend, before the next tx is built. This allows a tx to spend the
+	// change of the previous txs. Issuance stops at the first tx that fails.
+	// [options] are used to sign and issue the txs, so any options used to
+	// build a tx must be provided by its builder.
+	//
+	// Returns the txs that were issued successfully, in order.
+	IssueSequential(
+		builders []UnsignedTxBuilder,
+		options ...common.Option,
+	) ([]*txs.Tx, error)
+
+	// Reissue re-broadcasts the already signed [tx], such as a tx that was
+	// dropped from the mempool of the node, and waits for it to be accepted.
+	// If the node already knows about [tx], it is not broadcast again and it
+	// is not re-applied to the backend. The returned status reports which of
+	// these cases occurred.
+	//
+	// Returns [ErrReissueNotSupported] if the client does not implement
+	// [TxReissuer].
+	Reissue(
+		tx *txs.Tx,
+		options ...common.Option,
+	) (ReissueStatus, error)
+
+	// Refresh re-syncs the UTXOs of the wallet with the node. UTXOs that were
+	// spent are removed and UTXOs that were produced, including by external
+	// issuers, are added.
+	//
+	// Returns [ErrRefreshNotSupported] if the client does not implement
+	// [Refresher].
+	Refresh(ctx context.Context) error
+
+	// PendingFromKey returns the IDs of the txs signed by the wallet's
+	// addresses that are still in the mempool of the node. This can be used to
+	// check whether a previously issued tx is still in flight before issuing
+	// it again.
+	//
+	// Returns [ErrPendingNotSupported] if the client does not implement
+	// [PendingLister].
+	PendingFromKey(ctx context.Context) ([]ids.ID, error)
+
+	// ExportState serializes the UTXOs, owners, and context of the wallet so
+	// that a wallet can later be created from them without re-fetching every
+	// UTXO. The serialized state includes the P-chain height and addresses it
+	// was exported for, so that stale or mismatched state can be detected
+	// when it is loaded.
+	//
+	// Returns [ErrExportNotSupported] if the client does not implement
+	// [StateExporter].
+	ExportState(ctx context.Context) ([]byte, error)
+
+	// MinInitialL1Balance returns the recommended minimum initial balance of
+	// a new L1 validator. The balance pays the continuous fee, at the price
+	// reported by the context of the builder, for
+	// [MinInitialL1BalanceDuration]. The price can increase as more L1
+	// validators become active, so the balance is not guaranteed to last the
+	// full duration.
+	//
+	// Returns [ErrUnknownValidatorFee] if the context does not include the
+	// continuous fee.
+	MinInitialL1Balance() (uint64, error)
+
+	// Balances returns the amount of each asset that the wallet is able to
+	// spend, based on the UTXOs in the backend.
+	Balances(
+		options ...common.Option,
+	) (map[ids.ID]uint64, error)
+
+	// Balance returns the amount of AVAX that the wallet is able to spend,
+	// based on the UTXOs in the backend.
+	Balance(
+		options ...common.Option,
+	) (uint64, error)
+
+	// CanAffordBatch reports whether the wallet is able to fund the
+	// registration of every item in [items], without issuing any
+	// transactions.
+	//
+	// The fee of each registration is estimated by building its transaction
+	// against the UTXOs in the backend. Because every transaction is built from
+	// the same UTXOs, the balances and fees are summed and compared against the
+	// spendable AVAX of the wallet rather than checking each transaction
+	// independently.
+	//
+	// Returns the total estimated fee of the batch. If any single item can not
+	// be afforded, false is returned along with the fees of the preceding
+	// items.
+	CanAffordBatch(
+		items []RegisterItem,
+		options ...common.Option,
+	) (bool, uint64, error)
 }
 
 func New(
@@ -331,6 +533,16 @@ type wallet struct {
 	Client
 	builder builder.Builder
 	signer  walletsigner.Signer
+
+	// lock is held from the selection of the UTXOs used by a transaction until
+	// the transaction has been applied to the backend. This prevents
+	// concurrent issuance from consuming the same UTXOs in multiple
+	// transactions.
+	lock sync.Mutex
+	// syncedFeePayers are the fee payer addresses whose UTXOs have been added
+	// to the backend. The UTXOs are only fetched once, so that UTXOs consumed
+	// by txs that have not yet been accepted by the node are not re-added.
+	syncedFeePayers set.Set[ids.ShortID]
 }
 
 func (w *wallet) Builder() builder.Builder {
@@ -345,11 +557,9 @@ func (w *wallet) IssueBaseTx(
 	outputs []*avax.TransferableOutput,
 	options ...common.Option,
 ) (*txs.Tx, error) {
-	utx, err := w.builder.NewBaseTx(outputs, options...)
-	if err != nil {
-		return nil, err
-	}
-	return w.IssueUnsignedTx(utx, options...)
+	return w.buildAndIssue(func() (txs.UnsignedTx, error) {
+		return w.builder.NewBaseTx(outputs, options...)
+	}, options...)
 }
 
 func (w *wallet) IssueAddValidatorTx(
@@ -358,22 +568,18 @@ func (w *wallet) IssueAddValidatorTx(
 	shares uint32,
 	options ...common.Option,
 ) (*txs.Tx, error) {
-	utx, err := w.builder.NewAddValidatorTx(vdr, rewardsOwner, shares, options...)
-	if err != nil {
-		return nil, err
-	}
-	return w.IssueUnsignedTx(utx, options...)
+	return w.buildAndIssue(func() (txs.UnsignedTx, error) {
+		return w.builder.NewAddValidatorTx(vdr, rewardsOwner, shares, options...)
+	}, options...)
 }
 
 func (w *wallet) IssueAddSubnetValidatorTx(
 	vdr *txs.SubnetValidator,
 	options ...common.Option,
 ) (*txs.Tx, error) {
-	utx, err := w.builder.NewAddSubnetValidatorTx(vdr, options...)
-	if err != nil {
-		return nil, err
-	}
-	return w.IssueUnsignedTx(utx, options...)
+	return w.buildAndIssue(func() (txs.UnsignedTx, error) {
+		return w.builder.NewAddSubnetValidatorTx(vdr, options...)
+	}, options...)
 }
 
 func (w *wallet) IssueRemoveSubnetValidatorTx(
@@ -381,11 +587,9 @@ func (w *wallet) IssueRemoveSubnetValidatorTx(
 	subnetID ids.ID,
 	options ...common.Option,
 ) (*txs.Tx, error) {
-	utx, err := w.builder.NewRemoveSubnetValidatorTx(nodeID, subnetID, options...)
-	if err != nil {
-		return nil, err
-	}
-	return w.IssueUnsignedTx(utx, options...)
+	return w.buildAndIssue(func() (txs.UnsignedTx, error) {
+		return w.builder.NewRemoveSubnetValidatorTx(nodeID, subnetID, options...)
+	}, options...)
 }
 
 func (w *wallet) IssueAddDelegatorTx(
@@ -393,11 +597,9 @@ func (w *wallet) IssueAddDelegatorTx(
 	rewardsOwner *secp256k1fx.OutputOwners,
 	options ...common.Option,
 ) (*txs.Tx, error) {
-	utx, err := w.builder.NewAddDelegatorTx(vdr, rewardsOwner, options...)
-	if err != nil {
-		return nil, err
-	}
-	return w.IssueUnsignedTx(utx, options...)
+	return w.buildAndIssue(func() (txs.UnsignedTx, error) {
+		return w.builder.NewAddDelegatorTx(vdr, rewardsOwner, options...)
+	}, options...)
 }
 
 func (w *wallet) IssueCreateChainTx(
@@ -408,22 +610,18 @@ func (w *wallet) IssueCreateChainTx(
 	chainName string,
 	options ...common.Option,
 ) (*txs.Tx, error) {
-	utx, err := w.builder.NewCreateChainTx(subnetID, genesis, vmID, fxIDs, chainName, options...)
-	if err != nil {
-		return nil, err
-	}
-	return w.IssueUnsignedTx(utx, options...)
+	return w.buildAndIssue(func() (txs.UnsignedTx, error) {
+		return w.builder.NewCreateChainTx(subnetID, genesis, vmID, fxIDs, chainName, options...)
+	}, options...)
 }
 
 func (w *wallet) IssueCreateSubnetTx(
 	owner *secp256k1fx.OutputOwners,
 	options ...common.Option,
 ) (*txs.Tx, error) {
-	utx, err := w.builder.NewCreateSubnetTx(owner, options...)
-	if err != nil {
-		return nil, err
-	}
-	return w.IssueUnsignedTx(utx, options...)
+	return w.buildAndIssue(func() (txs.UnsignedTx, error) {
+		return w.builder.NewCreateSubnetTx(owner, options...)
+	}, options...)
 }
 
 func (w *wallet) IssueTransferSubnetOwnershipTx(
@@ -431,11 +629,9 @@ func (w *wallet) IssueTransferSubnetOwnershipTx(
 	owner *secp256k1fx.OutputOwners,
 	options ...common.Option,
 ) (*txs.Tx, error) {
-	utx, err := w.builder.NewTransferSubnetOwnershipTx(subnetID, owner, options...)
-	if err != nil {
-		return nil, err
-	}
-	return w.IssueUnsignedTx(utx, options...)
+	return w.buildAndIssue(func() (txs.UnsignedTx, error) {
+		return w.builder.NewTransferSubnetOwnershipTx(subnetID, owner, options...)
+	}, options...)
 }
 
 func (w *wallet) IssueConvertSubnetToL1Tx(
@@ -445,11 +641,9 @@ func (w *wallet) IssueConvertSubnetToL1Tx(
 	validators []*txs.ConvertSubnetToL1Validator,
 	options ...common.Option,
 ) (*txs.Tx, error) {
-	utx, err := w.builder.NewConvertSubnetToL1Tx(subnetID, chainID, address, validators, options...)
-	if err != nil {
-		return nil, err
-	}
-	return w.IssueUnsignedTx(utx, options...)
+	return w.buildAndIssue(func() (txs.UnsignedTx, error) {
+		return w.builder.NewConvertSubnetToL1Tx(subnetID, chainID, address, validators, options...)
+	}, options...)
 }
 
 func (w *wallet) IssueRegisterL1ValidatorTx(
@@ -458,22 +652,18 @@ func (w *wallet) IssueRegisterL1ValidatorTx(
 	message []byte,
 	options ...common.Option,
 ) (*txs.Tx, error) {
-	utx, err := w.builder.NewRegisterL1ValidatorTx(balance, proofOfPossession, message, options...)
-	if err != nil {
-		return nil, err
-	}
-	return w.IssueUnsignedTx(utx, options...)
+	return w.buildAndIssue(func() (txs.UnsignedTx, error) {
+		return w.builder.NewRegisterL1ValidatorTx(balance, proofOfPossession, message, options...)
+	}, options...)
 }
 
 func (w *wallet) IssueSetL1ValidatorWeightTx(
 	message []byte,
 	options ...common.Option,
 ) (*txs.Tx, error) {
-	utx, err := w.builder.NewSetL1ValidatorWeightTx(message, options...)
-	if err != nil {
-		return nil, err
-	}
-	return w.IssueUnsignedTx(utx, options...)
+	return w.buildAndIssue(func() (txs.UnsignedTx, error) {
+		return w.builder.NewSetL1ValidatorWeightTx(message, options...)
+	}, options...)
 }
 
 func (w *wallet) IssueIncreaseL1ValidatorBalanceTx(
@@ -481,22 +671,18 @@ func (w *wallet) IssueIncreaseL1ValidatorBalanceTx(
 	balance uint64,
 	options ...common.Option,
 ) (*txs.Tx, error) {
-	utx, err := w.builder.NewIncreaseL1ValidatorBalanceTx(validationID, balance, options...)
-	if err != nil {
-		return nil, err
-	}
-	return w.IssueUnsignedTx(utx, options...)
+	return w.buildAndIssue(func() (txs.UnsignedTx, error) {
+		return w.builder.NewIncreaseL1ValidatorBalanceTx(validationID, balance, options...)
+	}, options...)
 }
 
 func (w *wallet) IssueDisableL1ValidatorTx(
 	validationID ids.ID,
 	options ...common.Option,
 ) (*txs.Tx, error) {
-	utx, err := w.builder.NewDisableL1ValidatorTx(validationID, options...)
-	if err != nil {
-		return nil, err
-	}
-	return w.IssueUnsignedTx(utx, options...)
+	return w.buildAndIssue(func() (txs.UnsignedTx, error) {
+		return w.builder.NewDisableL1ValidatorTx(validationID, options...)
+	}, options...)
 }
 
 func (w *wallet) IssueImportTx(
@@ -504,11 +690,9 @@ func (w *wallet) IssueImportTx(
 	to *secp256k1fx.OutputOwners,
 	options ...common.Option,
 ) (*txs.Tx, error) {
-	utx, err := w.builder.NewImportTx(sourceChainID, to, options...)
-	if err != nil {
-		return nil, err
-	}
-	return w.IssueUnsignedTx(utx, options...)
+	return w.buildAndIssue(func() (txs.UnsignedTx, error) {
+		return w.builder.NewImportTx(sourceChainID, to, options...)
+	}, options...)
 }
 
 func (w *wallet) IssueExportTx(
@@ -516,11 +700,9 @@ func (w *wallet) IssueExportTx(
 	outputs []*avax.TransferableOutput,
 	options ...common.Option,
 ) (*txs.Tx, error) {
-	utx, err := w.builder.NewExportTx(chainID, outputs, options...)
-	if err != nil {
-		return nil, err
-	}
-	return w.IssueUnsignedTx(utx, options...)
+	return w.buildAndIssue(func() (txs.UnsignedTx, error) {
+		return w.builder.NewExportTx(chainID, outputs, options...)
+	}, options...)
 }
 
 func (w *wallet) IssueTransformSubnetTx(
@@ -540,27 +722,25 @@ func (w *wallet) IssueTransformSubnetTx(
 	uptimeRequirement uint32,
 	options ...common.Option,
 ) (*txs.Tx, error) {
-	utx, err := w.builder.NewTransformSubnetTx(
-		subnetID,
-		assetID,
-		initialSupply,
-		maxSupply,
-		minConsumptionRate,
-		maxConsumptionRate,
-		minValidatorStake,
-		maxValidatorStake,
-		minStakeDuration,
-		maxStakeDuration,
-		minDelegationFee,
-		minDelegatorStake,
-		maxValidatorWeightFactor,
-		uptimeRequirement,
-		options...,
-	)
-	if err != nil {
-		return nil, err
-	}
-	return w.IssueUnsignedTx(utx, options...)
+	return w.buildAndIssue(func() (txs.UnsignedTx, error) {
+		return w.builder.NewTransformSubnetTx(
+			subnetID,
+			assetID,
+			initialSupply,
+			maxSupply,
+			minConsumptionRate,
+			maxConsumptionRate,
+			minValidatorStake,
+			maxValidatorStake,
+			minStakeDuration,
+			maxStakeDuration,
+			minDelegationFee,
+			minDelegatorStake,
+			maxValidatorWeightFactor,
+			uptimeRequirement,
+			options...,
+		)
+	}, options...)
 }
 
 func (w *wallet) IssueAddPermissionlessValidatorTx(
@@ -572,19 +752,17 @@ func (w *wallet) IssueAddPermissionlessValidatorTx(
 	shares uint32,
 	options ...common.Option,
 ) (*txs.Tx, error) {
-	utx, err := w.builder.NewAddPermissionlessValidatorTx(
-		vdr,
-		signer,
-		assetID,
-		validationRewardsOwner,
-		delegationRewardsOwner,
-		shares,
-		options...,
-	)
-	if err != nil {
-		return nil, err
-	}
-	return w.IssueUnsignedTx(utx, options...)
+	return w.buildAndIssue(func() (txs.UnsignedTx, error) {
+		return w.builder.NewAddPermissionlessValidatorTx(
+			vdr,
+			signer,
+			assetID,
+			validationRewardsOwner,
+			delegationRewardsOwner,
+			shares,
+			options...,
+		)
+	}, options...)
 }
 
 func (w *wallet) IssueAddPermissionlessDelegatorTx(
@@ -593,28 +771,343 @@ func (w *wallet) IssueAddPermissionlessDelegatorTx(
 	rewardsOwner *secp256k1fx.OutputOwners,
 	options ...common.Option,
 ) (*txs.Tx, error) {
-	utx, err := w.builder.NewAddPermissionlessDelegatorTx(
-		vdr,
-		assetID,
-		rewardsOwner,
-		options...,
-	)
+	return w.buildAndIssue(func() (txs.UnsignedTx, error) {
+		return w.builder.NewAddPermissionlessDelegatorTx(
+			vdr,
+			assetID,
+			rewardsOwner,
+			options...,
+		)
+	}, options...)
+}
+
+func (w *wallet) IssueUnsignedTx(
+	utx txs.UnsignedTx,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	return w.buildAndIssue(func() (txs.UnsignedTx, error) {
+		return utx, nil
+	}, options...)
+}
+
+func (w *wallet) IssueSequential(
+	builders []UnsignedTxBuilder,
+	options ...common.Option,
+) ([]*txs.Tx, error) {
+	issued := make([]*txs.Tx, 0, len(builders))
+	for i, build := range builders {
+		var buildErr error
+		tx, err := w.buildAndIssue(func() (txs.UnsignedTx, error) {
+			utx, err := build(w.builder)
+			buildErr = err
+			return utx, err
+		}, options...)
+		switch {
+		case buildErr != nil:
+			return issued, fmt.Errorf("failed to build tx %d: %w", i, buildErr)
+		case err != nil:
+			return issued, fmt.Errorf("failed to issue tx %d: %w", i, err)
+		}
+		issued = append(issued, tx)
+	}
+	return issued, nil
+}
+
+func (w *wallet) IssueTx(
+	tx *txs.Tx,
+	options ...common.Option,
+) error {
+	awaiter, err := w.lockedIssueTx(tx, options...)
+	if err != nil || awaiter == nil {
+		return err
+	}
+	return awaiter.AwaitTx(tx, options...)
+}
+
+func (w *wallet) Reissue(
+	tx *txs.Tx,
+	options ...common.Option,
+) (ReissueStatus, error) {
+	reissuer, ok := w.Client.(TxReissuer)
+	if !ok {
+		return Reissued, ErrReissueNotSupported
+	}
+
+	var status ReissueStatus
+	w.lock.Lock()
+	awaiter, err := w.issueWith(func(options ...common.Option) error {
+		var err error
+		status, err = reissuer.ReissueTx(tx, options...)
+		return err
+	}, options...)
+	w.lock.Unlock()
+	if err != nil || awaiter == nil {
+		return status, err
+	}
+	return status, awaiter.AwaitTx(tx, options...)
+}
+
+func (w *wallet) Refresh(ctx context.Context) error {
+	refresher, ok := w.Client.(Refresher)
+	if !ok {
+		return ErrRefreshNotSupported
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if err := refresher.Refresh(ctx); err != nil {
+		return err
+	}
+
+	// Refreshing replaces the UTXOs of the backend, which drops the UTXOs of
+	// any synced fee payers, so they must be synced again when next used.
+	w.syncedFeePayers.Clear()
+	return nil
+}
+
+func (w *wallet) PendingFromKey(ctx context.Context) ([]ids.ID, error) {
+	lister, ok := w.Client.(PendingLister)
+	if !ok {
+		return nil, ErrPendingNotSupported
+	}
+	return lister.PendingFromKey(ctx)
+}
+
+func (w *wallet) ExportState(ctx context.Context) ([]byte, error) {
+	exporter, ok := w.Client.(StateExporter)
+	if !ok {
+		return nil, ErrExportNotSupported
+	}
+
+	// Holding the lock prevents the state from being exported while a tx is
+	// being applied to the backend.
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	state, err := exporter.ExportState(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return w.IssueUnsignedTx(utx, options...)
+	return json.Marshal(state)
 }
 
-func (w *wallet) IssueUnsignedTx(
+func (w *wallet) MinInitialL1Balance() (uint64, error) {
+	price := w.builder.Context().ValidatorFeePrice
+	if price == 0 {
+		return 0, ErrUnknownValidatorFee
+	}
+	return math.Mul(uint64(price), uint64(MinInitialL1BalanceDuration/time.Second))
+}
+
+func (w *wallet) Balances(
+	options ...common.Option,
+) (map[ids.ID]uint64, error) {
+	return w.builder.GetBalance(options...)
+}
+
+func (w *wallet) Balance(
+	options ...common.Option,
+) (uint64, error) {
+	balances, err := w.Balances(options...)
+	if err != nil {
+		return 0, err
+	}
+	return balances[w.builder.Context().AVAXAssetID], nil
+}
+
+func (w *wallet) CanAffordBatch(
+	items []RegisterItem,
+	options ...common.Option,
+) (bool, uint64, error) {
+	avaxAssetID := w.builder.Context().AVAXAssetID
+	var (
+		totalFee uint64
+		required uint64
+	)
+	for i, item := range items {
+		utx, err := w.builder.NewRegisterL1ValidatorTx(
+			item.Balance,
+			item.ProofOfPossession,
+			item.Message,
+			options...,
+		)
+		if errors.Is(err, builder.ErrInsufficientFunds) {
+			return false, totalFee, nil
+		}
+		if err != nil {
+			return false, 0, fmt.Errorf("failed to build tx %d: %w", i, err)
+		}
+
+		consumed, err := sumInputs(avaxAssetID, utx.Ins)
+		if err != nil {
+			return false, 0, err
+		}
+		produced, err := sumOutputs(avaxAssetID, utx.Outs)
+		if err != nil {
+			return false, 0, err
+		}
+		// The builder never produces more than it consumes, so this can only
+		// underflow if the tx is malformed.
+		fee, err := math.Sub(consumed, produced)
+		if err != nil {
+			return false, 0, err
+		}
+		fee, err = math.Sub(fee, item.Balance)
+		if err != nil {
+			return false, 0, err
+		}
+
+		totalFee, err = math.Add(totalFee, fee)
+		if err != nil {
+			return false, 0, err
+		}
+		required, err = math.Add(required, fee)
+		if err != nil {
+			return false, 0, err
+		}
+		required, err = math.Add(required, item.Balance)
+		if err != nil {
+			return false, 0, err
+		}
+	}
+
+	balance, err := w.Balance(options...)
+	if err != nil {
+		return false, 0, err
+	}
+	return required <= balance, totalFee, nil
+}
+
+// buildAndIssue builds the unsigned tx with [build], then signs and issues it.
+// [w.lock] is only held until the tx has been applied to the backend.
+func (w *wallet) buildAndIssue(
+	build func() (txs.UnsignedTx, error),
+	options ...common.Option,
+) (*txs.Tx, error) {
+	tx, awaiter, err := w.buildAndApply(build, options...)
+	if err != nil || awaiter == nil {
+		return tx, err
+	}
+	return tx, awaiter.AwaitTx(tx, options...)
+}
+
+// buildAndApply builds the unsigned tx with [build], then signs and issues it
+// while holding [w.lock]. See issueTx for the returned awaiter.
+func (w *wallet) buildAndApply(
+	build func() (txs.UnsignedTx, error),
+	options ...common.Option,
+) (*txs.Tx, TxAwaiter, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if err := w.syncFeePayer(options...); err != nil {
+		return nil, nil, err
+	}
+
+	utx, err := build()
+	if err != nil {
+		return nil, nil, err
+	}
+	tx, err := w.signUnsignedTx(utx, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	awaiter, err := w.issueTx(tx, options...)
+	return tx, awaiter, err
+}
+
+// lockedIssueTx issues [tx] while holding [w.lock]. See issueTx for
+// the returned awaiter.
+func (w *wallet) lockedIssueTx(
+	tx *txs.Tx,
+	options ...common.Option,
+) (TxAwaiter, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.issueTx(tx, options...)
+}
+
+// syncFeePayer adds the UTXOs of the fee payer provided in [options], if any,
+// to the backend. It assumes that [w.lock] is held.
+//
+// If the client does not implement [UTXOSyncer], the UTXOs of the fee payer
+// must already be held by the backend.
+func (w *wallet) syncFeePayer(options ...common.Option) error {
+	ops := common.NewOptions(options)
+	feePayer := ops.FeePayer()
+	if feePayer == nil {
+		return nil
+	}
+	syncer, ok := w.Client.(UTXOSyncer)
+	if !ok {
+		return nil
+	}
+
+	var unsynced set.Set[ids.ShortID]
+	for addr := range feePayer.Addresses() {
+		if !w.syncedFeePayers.Contains(addr) {
+			unsynced.Add(addr)
+		}
+	}
+	if unsynced.Len() == 0 {
+		return nil
+	}
+
+	if err := syncer.SyncUTXOs(ops.Context(), unsynced); err != nil {
+		return fmt.Errorf("failed to sync fee payer UTXOs: %w", err)
+	}
+	w.syncedFeePayers.Union(unsynced)
+	return nil
+}
+
+// signUnsignedTx signs the unsigned tx, including with the keys of the fee
+// payer provided in [options], if any.
+func (w *wallet) signUnsignedTx(
 	utx txs.UnsignedTx,
 	options ...common.Option,
 ) (*txs.Tx, error) {
 	ops := common.NewOptions(options)
-	ctx := ops.Context()
-	tx, err := walletsigner.SignUnsigned(ctx, w.signer, utx)
-	if err != nil {
-		return nil, err
+	signer := w.signer
+	if feePayer := ops.FeePayer(); feePayer != nil {
+		var err error
+		signer, err = walletsigner.WithKeychain(signer, feePayer)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return walletsigner.SignUnsigned(ops.Context(), signer, utx)
+}
+
+// issueTx issues [tx] and applies it to the backend. It assumes that [w.lock]
+// is held. See issueWith for the returned awaiter.
+func (w *wallet) issueTx(
+	tx *txs.Tx,
+	options ...common.Option,
+) (TxAwaiter, error) {
+	return w.issueWith(func(options ...common.Option) error {
+		return w.Client.IssueTx(tx, options...)
+	}, options...)
+}
+
+// issueWith issues a tx with [issue]. It assumes that [w.lock] is held.
+//
+// If the client implements [TxAwaiter], and the tx isn't assumed to be
+// decided, the tx is applied to the backend as soon as it is issued and the
+// returned awaiter must be used to wait for it to be accepted. Otherwise, the
+// returned awaiter is nil.
+func (w *wallet) issueWith(
+	issue func(options ...common.Option) error,
+	options ...common.Option,
+) (TxAwaiter, error) {
+	awaiter, ok := w.Client.(TxAwaiter)
+	if !ok || common.NewOptions(options).AssumeDecided() {
+		return nil, issue(options...)
 	}
 
-	return tx, w.IssueTx(tx, options...)
+	err := issue(
+		common.UnionOptions(options, []common.Option{common.WithAssumeDecided()})...,
+	)
+	return awaiter, err
 }
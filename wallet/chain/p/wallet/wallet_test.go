@@ -0,0 +1,635 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package wallet
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common/utxotest"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+	walletsigner "github.com/ava-labs/avalanchego/wallet/chain/p/signer"
+)
+
+var (
+	errDoubleSpend = errors.New("double spend")
+	errBuild       = errors.New("failed to build")
+
+	_ Client    = (*acceptingClient)(nil)
+	_ TxAwaiter = (*awaitingClient)(nil)
+)
+
+// acceptingClient immediately accepts every issued tx and records the UTXOs
+// that were consumed.
+type acceptingClient struct {
+	backend Backend
+
+	lock     sync.Mutex
+	consumed set.Set[ids.ID]
+}
+
+func (c *acceptingClient) IssueTx(tx *txs.Tx, options ...common.Option) error {
+	utx, ok := tx.Unsigned.(*txs.BaseTx)
+	if !ok {
+		return nil
+	}
+
+	c.lock.Lock()
+	for _, in := range utx.Ins {
+		inputID := in.InputID()
+		if c.consumed.Contains(inputID) {
+			c.lock.Unlock()
+			return errDoubleSpend
+		}
+		c.consumed.Add(inputID)
+	}
+	c.lock.Unlock()
+
+	// Simulate the latency of issuing the tx to a node.
+	time.Sleep(time.Millisecond)
+
+	ops := common.NewOptions(options)
+	return c.backend.AcceptTx(ops.Context(), tx)
+}
+
+// awaitingClient is an acceptingClient whose first acceptance is delayed until
+// [release] is closed. [awaiting] is closed once the first acceptance is being
+// awaited.
+type awaitingClient struct {
+	*acceptingClient
+
+	awaited  atomic.Bool
+	awaiting chan struct{}
+	release  chan struct{}
+}
+
+func (c *awaitingClient) AwaitTx(*txs.Tx, ...common.Option) error {
+	if c.awaited.CompareAndSwap(false, true) {
+		close(c.awaiting)
+		<-c.release
+	}
+	return nil
+}
+
+func TestWalletConcurrentIssuance(t *testing.T) {
+	const (
+		numUTXOs      = 64
+		numGoroutines = 8
+		numTxsPerGo   = 8
+	)
+
+	var (
+		require = require.New(t)
+		key     = secp256k1.TestKeys()[0]
+		addr    = key.Address()
+		owner   = secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr},
+		}
+		avaxAssetID = ids.GenerateTestID()
+		testContext = &builder.Context{
+			NetworkID:   constants.UnitTestID,
+			AVAXAssetID: avaxAssetID,
+			ComplexityWeights: gas.Dimensions{
+				gas.Bandwidth: 1,
+				gas.DBRead:    10,
+				gas.DBWrite:   100,
+				gas.Compute:   1000,
+			},
+			GasPrice: 1,
+		}
+		utxos = make([]*avax.UTXO, numUTXOs)
+	)
+	for i := range utxos {
+		utxos[i] = &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID: ids.GenerateTestID(),
+			},
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          units.Avax,
+				OutputOwners: owner,
+			},
+		}
+	}
+
+	var (
+		// Deterministic ordering of the UTXOs causes concurrently built
+		// transactions to select the same UTXOs unless issuance is
+		// synchronized.
+		chainUTXOs = utxotest.NewDeterministicChainUTXOs(t, map[ids.ID][]*avax.UTXO{
+			constants.PlatformChainID: utxos,
+		})
+		backend = NewBackend(testContext, chainUTXOs, nil)
+		client  = &acceptingClient{
+			backend: backend,
+		}
+		wallet = New(
+			client,
+			builder.New(set.Of(addr), testContext, backend),
+			walletsigner.New(secp256k1fx.NewKeychain(key), backend),
+		)
+		output = &avax.TransferableOutput{
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          units.MilliAvax,
+				OutputOwners: owner,
+			},
+		}
+	)
+
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, numGoroutines*numTxsPerGo)
+	)
+	for i := 0; i < numGoroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < numTxsPerGo; j++ {
+				_, err := wallet.IssueBaseTx(
+					[]*avax.TransferableOutput{output},
+				)
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(err)
+	}
+}
+
+func TestWalletIssueSequential(t *testing.T) {
+	var (
+		require = require.New(t)
+		key     = secp256k1.TestKeys()[0]
+		addr    = key.Address()
+		owner   = secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr},
+		}
+		avaxAssetID = ids.GenerateTestID()
+		testContext = &builder.Context{
+			NetworkID:   constants.UnitTestID,
+			AVAXAssetID: avaxAssetID,
+			ComplexityWeights: gas.Dimensions{
+				gas.Bandwidth: 1,
+				gas.DBRead:    10,
+				gas.DBWrite:   100,
+				gas.Compute:   1000,
+			},
+			GasPrice: 1,
+		}
+		chainUTXOs = utxotest.NewDeterministicChainUTXOs(t, map[ids.ID][]*avax.UTXO{
+			constants.PlatformChainID: {
+				{
+					UTXOID: avax.UTXOID{
+						TxID: ids.GenerateTestID(),
+					},
+					Asset: avax.Asset{ID: avaxAssetID},
+					Out: &secp256k1fx.TransferOutput{
+						Amt:          units.Avax,
+						OutputOwners: owner,
+					},
+				},
+			},
+		})
+		backend = NewBackend(testContext, chainUTXOs, nil)
+		client  = &acceptingClient{
+			backend: backend,
+		}
+		txBuilder = builder.New(set.Of(addr), testContext, backend)
+		txSigner  = walletsigner.New(secp256k1fx.NewKeychain(key), backend)
+		wallet    = New(client, txBuilder, txSigner)
+		outputs   = []*avax.TransferableOutput{
+			{
+				Asset: avax.Asset{ID: avaxAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          units.MilliAvax,
+					OutputOwners: owner,
+				},
+			},
+		}
+	)
+
+	newBaseTx := func(b builder.Builder) (txs.UnsignedTx, error) {
+		return b.NewBaseTx(outputs)
+	}
+	failingTx := func(builder.Builder) (txs.UnsignedTx, error) {
+		return nil, errBuild
+	}
+
+	// Only one UTXO is held, so the second tx must spend the outputs of the
+	// first tx.
+	issued, err := wallet.IssueSequential([]UnsignedTxBuilder{newBaseTx, newBaseTx})
+	require.NoError(err)
+	require.Len(issued, 2)
+
+	for _, in := range issued[1].Unsigned.(*txs.BaseTx).Ins {
+		require.Equal(issued[0].ID(), in.TxID)
+	}
+
+	issued, err = wallet.IssueSequential([]UnsignedTxBuilder{newBaseTx, failingTx, newBaseTx})
+	require.ErrorIs(err, errBuild)
+	require.Len(issued, 1)
+}
+
+func TestWalletIssueDoesNotHoldLockWhileAwaiting(t *testing.T) {
+	var (
+		require = require.New(t)
+		key     = secp256k1.TestKeys()[0]
+		addr    = key.Address()
+		owner   = secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr},
+		}
+		avaxAssetID = ids.GenerateTestID()
+		testContext = &builder.Context{
+			NetworkID:   constants.UnitTestID,
+			AVAXAssetID: avaxAssetID,
+			ComplexityWeights: gas.Dimensions{
+				gas.Bandwidth: 1,
+				gas.DBRead:    10,
+				gas.DBWrite:   100,
+				gas.Compute:   1000,
+			},
+			GasPrice: 1,
+		}
+		chainUTXOs = utxotest.NewDeterministicChainUTXOs(t, map[ids.ID][]*avax.UTXO{
+			constants.PlatformChainID: {
+				{
+					UTXOID: avax.UTXOID{
+						TxID: ids.GenerateTestID(),
+					},
+					Asset: avax.Asset{ID: avaxAssetID},
+					Out: &secp256k1fx.TransferOutput{
+						Amt:          units.Avax,
+						OutputOwners: owner,
+					},
+				},
+			},
+		})
+		backend = NewBackend(testContext, chainUTXOs, nil)
+		client  = &awaitingClient{
+			acceptingClient: &acceptingClient{
+				backend: backend,
+			},
+			awaiting: make(chan struct{}),
+			release:  make(chan struct{}),
+		}
+		wallet = New(
+			client,
+			builder.New(set.Of(addr), testContext, backend),
+			walletsigner.New(secp256k1fx.NewKeychain(key), backend),
+		)
+		outputs = []*avax.TransferableOutput{
+			{
+				Asset: avax.Asset{ID: avaxAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          units.MilliAvax,
+					OutputOwners: owner,
+				},
+			},
+		}
+	)
+
+	var (
+		first    *txs.Tx
+		firstErr = make(chan error, 1)
+	)
+	go func() {
+		var err error
+		first, err = wallet.IssueBaseTx(outputs)
+		firstErr <- err
+	}()
+	<-client.awaiting
+
+	// The first tx was applied to the backend when it was issued, so the
+	// second tx spends its outputs while the first tx is still being awaited.
+	second, err := wallet.IssueBaseTx(outputs)
+	require.NoError(err)
+
+	close(client.release)
+	require.NoError(<-firstErr)
+	for _, in := range second.Unsigned.(*txs.BaseTx).Ins {
+		require.Equal(first.ID(), in.TxID)
+	}
+}
+
+func TestWalletMinInitialL1Balance(t *testing.T) {
+	tests := []struct {
+		name            string
+		price           gas.Price
+		expectedBalance uint64
+		expectedErr     error
+	}{
+		{
+			name:            "known price",
+			price:           512,
+			expectedBalance: 512 * 24 * 60 * 60,
+		},
+		{
+			name:        "unknown price",
+			price:       0,
+			expectedErr: ErrUnknownValidatorFee,
+		},
+		{
+			name:        "overflow",
+			price:       math.MaxUint64,
+			expectedErr: safemath.ErrOverflow,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testContext := &builder.Context{
+				NetworkID:         constants.UnitTestID,
+				AVAXAssetID:       ids.GenerateTestID(),
+				ValidatorFeePrice: test.price,
+			}
+			wallet := New(
+				nil,
+				builder.New(set.Set[ids.ShortID]{}, testContext, nil),
+				nil,
+			)
+
+			balance, err := wallet.MinInitialL1Balance()
+			require.ErrorIs(t, err, test.expectedErr)
+			require.Equal(t, test.expectedBalance, balance)
+		})
+	}
+}
+
+func TestWalletBalances(t *testing.T) {
+	var (
+		require = require.New(t)
+		key     = secp256k1.TestKeys()[0]
+		addr    = key.Address()
+		owner   = secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr},
+		}
+		avaxAssetID  = ids.GenerateTestID()
+		otherAssetID = ids.GenerateTestID()
+		testContext  = &builder.Context{
+			NetworkID:   constants.UnitTestID,
+			AVAXAssetID: avaxAssetID,
+		}
+		newUTXO = func(assetID ids.ID, amount uint64, owner secp256k1fx.OutputOwners) *avax.UTXO {
+			return &avax.UTXO{
+				UTXOID: avax.UTXOID{
+					TxID: ids.GenerateTestID(),
+				},
+				Asset: avax.Asset{ID: assetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          amount,
+					OutputOwners: owner,
+				},
+			}
+		}
+		chainUTXOs = utxotest.NewDeterministicChainUTXOs(t, map[ids.ID][]*avax.UTXO{
+			constants.PlatformChainID: {
+				newUTXO(avaxAssetID, units.Avax, owner),
+				newUTXO(avaxAssetID, units.MilliAvax, owner),
+				newUTXO(otherAssetID, 7, owner),
+				// UTXOs that are not spendable by [addr] are not included.
+				newUTXO(otherAssetID, 11, secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+				}),
+			},
+		})
+		backend = NewBackend(testContext, chainUTXOs, nil)
+		wallet  = New(
+			nil,
+			builder.New(set.Of(addr), testContext, backend),
+			walletsigner.New(secp256k1fx.NewKeychain(key), backend),
+		)
+	)
+
+	balances, err := wallet.Balances()
+	require.NoError(err)
+	require.Equal(
+		map[ids.ID]uint64{
+			avaxAssetID:  units.Avax + units.MilliAvax,
+			otherAssetID: 7,
+		},
+		balances,
+	)
+
+	balance, err := wallet.Balance()
+	require.NoError(err)
+	require.Equal(units.Avax+units.MilliAvax, balance)
+}
+
+func TestWalletFeePayer(t *testing.T) {
+	var (
+		require   = require.New(t)
+		ownerKey  = secp256k1.TestKeys()[0]
+		ownerAddr = ownerKey.Address()
+		payerKey  = secp256k1.TestKeys()[1]
+		payerAddr = payerKey.Address()
+		newUTXO   = func(addr ids.ShortID, assetID ids.ID) *avax.UTXO {
+			return &avax.UTXO{
+				UTXOID: avax.UTXOID{
+					TxID: ids.GenerateTestID(),
+				},
+				Asset: avax.Asset{ID: assetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: units.Avax,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{addr},
+					},
+				},
+			}
+		}
+		avaxAssetID = ids.GenerateTestID()
+		testContext = &builder.Context{
+			NetworkID:   constants.UnitTestID,
+			AVAXAssetID: avaxAssetID,
+			ComplexityWeights: gas.Dimensions{
+				gas.Bandwidth: 1,
+				gas.DBRead:    10,
+				gas.DBWrite:   100,
+				gas.Compute:   1000,
+			},
+			GasPrice: 1,
+		}
+		ownerUTXO  = newUTXO(ownerAddr, avaxAssetID)
+		payerUTXO  = newUTXO(payerAddr, avaxAssetID)
+		chainUTXOs = utxotest.NewDeterministicChainUTXOs(t, map[ids.ID][]*avax.UTXO{
+			constants.PlatformChainID: {
+				ownerUTXO,
+				payerUTXO,
+			},
+		})
+		backend = NewBackend(testContext, chainUTXOs, nil)
+		client  = &acceptingClient{
+			backend: backend,
+		}
+		wallet = New(
+			client,
+			builder.New(set.Of(ownerAddr), testContext, backend),
+			walletsigner.New(secp256k1fx.NewKeychain(ownerKey), backend),
+		)
+	)
+
+	tx, err := wallet.IssueBaseTx(
+		[]*avax.TransferableOutput{
+			{
+				Asset: avax.Asset{ID: avaxAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: units.MilliAvax,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{ownerAddr},
+					},
+				},
+			},
+		},
+		common.WithFeePayer(secp256k1fx.NewKeychain(payerKey)),
+	)
+	require.NoError(err)
+
+	// Only the UTXO of the fee payer was consumed.
+	utx := tx.Unsigned.(*txs.BaseTx)
+	require.Len(utx.Ins, 1)
+	require.Equal(payerUTXO.InputID(), utx.Ins[0].InputID())
+
+	// The input was signed by the fee payer.
+	require.Len(tx.Creds, 1)
+	cred := tx.Creds[0].(*secp256k1fx.Credential)
+	require.Len(cred.Sigs, 1)
+	pk, err := secp256k1.RecoverPublicKey(tx.Unsigned.Bytes(), cred.Sigs[0][:])
+	require.NoError(err)
+	require.Equal(payerAddr, pk.Address())
+
+	// The change was returned to the fee payer.
+	for _, out := range utx.Outs {
+		if out.Out.Amount() == units.MilliAvax {
+			continue
+		}
+		owners := out.Out.(*secp256k1fx.TransferOutput).OutputOwners
+		require.Equal([]ids.ShortID{payerAddr}, owners.Addrs)
+	}
+}
+
+func TestWalletCanAffordBatch(t *testing.T) {
+	var (
+		require = require.New(t)
+		key     = secp256k1.TestKeys()[0]
+		addr    = key.Address()
+		owner   = secp256k1fx.OutputOwners{
+			Threshold: 1,
+			Addrs:     []ids.ShortID{addr},
+		}
+		avaxAssetID = ids.GenerateTestID()
+		testContext = &builder.Context{
+			NetworkID:   constants.UnitTestID,
+			AVAXAssetID: avaxAssetID,
+			ComplexityWeights: gas.Dimensions{
+				gas.Bandwidth: 1,
+				gas.DBRead:    10,
+				gas.DBWrite:   100,
+				gas.Compute:   1000,
+			},
+			GasPrice: 1,
+		}
+		newUTXO = func() *avax.UTXO {
+			return &avax.UTXO{
+				UTXOID: avax.UTXOID{
+					TxID: ids.GenerateTestID(),
+				},
+				Asset: avax.Asset{ID: avaxAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          units.Avax,
+					OutputOwners: owner,
+				},
+			}
+		}
+		chainUTXOs = utxotest.NewDeterministicChainUTXOs(t, map[ids.ID][]*avax.UTXO{
+			constants.PlatformChainID: {
+				newUTXO(),
+				newUTXO(),
+			},
+		})
+		backend = NewBackend(testContext, chainUTXOs, nil)
+		// The wallet must not issue any txs, so no client is provided.
+		wallet = New(
+			nil,
+			builder.New(set.Of(addr), testContext, backend),
+			walletsigner.New(secp256k1fx.NewKeychain(key), backend),
+		)
+	)
+
+	unsignedMsg, err := warp.NewUnsignedMessage(
+		constants.UnitTestID,
+		ids.GenerateTestID(),
+		[]byte("payload"),
+	)
+	require.NoError(err)
+	msg, err := warp.NewMessage(
+		unsignedMsg,
+		&warp.BitSetSignature{
+			Signers: set.NewBits(0).Bytes(),
+		},
+	)
+	require.NoError(err)
+
+	item := RegisterItem{
+		Balance: 900 * units.MilliAvax,
+		Message: msg.Bytes(),
+	}
+
+	// Each item can be funded on its own, but only two of them can be funded
+	// together.
+	affordable, twoFee, err := wallet.CanAffordBatch([]RegisterItem{item, item})
+	require.NoError(err)
+	require.True(affordable)
+	require.Positive(twoFee)
+
+	affordable, threeFee, err := wallet.CanAffordBatch([]RegisterItem{item, item, item})
+	require.NoError(err)
+	require.False(affordable)
+	require.Greater(threeFee, twoFee)
+
+	// An item that can not be funded on its own is not affordable.
+	affordable, fee, err := wallet.CanAffordBatch([]RegisterItem{
+		item,
+		{
+			Balance: 3 * units.Avax,
+			Message: msg.Bytes(),
+		},
+	})
+	require.NoError(err)
+	require.False(affordable)
+	require.Equal(twoFee/2, fee)
+
+	affordable, fee, err = wallet.CanAffordBatch(nil)
+	require.NoError(err)
+	require.True(affordable)
+	require.Zero(fee)
+}
@@ -4,6 +4,7 @@
 package wallet
 
 import (
+	"context"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -319,3 +320,65 @@ func (w *withOptions) IssueTx(
 		common.UnionOptions(w.options, options)...,
 	)
 }
+
+func (w *withOptions) IssueSequential(
+	builders []UnsignedTxBuilder,
+	options ...common.Option,
+) ([]*txs.Tx, error) {
+	return w.wallet.IssueSequential(
+		builders,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
+func (w *withOptions) Reissue(
+	tx *txs.Tx,
+	options ...common.Option,
+) (ReissueStatus, error) {
+	return w.wallet.Reissue(
+		tx,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
+func (w *withOptions) Refresh(ctx context.Context) error {
+	return w.wallet.Refresh(ctx)
+}
+
+func (w *withOptions) PendingFromKey(ctx context.Context) ([]ids.ID, error) {
+	return w.wallet.PendingFromKey(ctx)
+}
+
+func (w *withOptions) ExportState(ctx context.Context) ([]byte, error) {
+	return w.wallet.ExportState(ctx)
+}
+
+func (w *withOptions) MinInitialL1Balance() (uint64, error) {
+	return w.wallet.MinInitialL1Balance()
+}
+
+func (w *withOptions) Balances(
+	options ...common.Option,
+) (map[ids.ID]uint64, error) {
+	return w.wallet.Balances(
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
+func (w *withOptions) Balance(
+	options ...common.Option,
+) (uint64, error) {
+	return w.wallet.Balance(
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
+func (w *withOptions) CanAffordBatch(
+	items []RegisterItem,
+	options ...common.Option,
+) (bool, uint64, error) {
+	return w.wallet.CanAffordBatch(
+		items,
+		common.UnionOptions(w.options, options)...,
+	)
+}
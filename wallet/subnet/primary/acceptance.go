@@ -0,0 +1,57 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+)
+
+// acceptanceSampleSize is the number of recent block intervals that are
+// sampled to estimate the acceptance time of a transaction.
+const acceptanceSampleSize = 10
+
+// EstimateAcceptanceTime returns the approximate time until a newly issued
+// P-chain transaction is accepted, according to [client].
+//
+// The estimate is the median time between the most recently accepted blocks.
+// P-chain blocks are only produced when there are transactions to include, so
+// the median is used to prevent an idle period from dominating the estimate.
+//
+// Returns [platformvm.ErrInsufficientBlockTimes] if the chain has not accepted
+// any blocks after genesis.
+func EstimateAcceptanceTime(
+	ctx context.Context,
+	client platformvm.Client,
+) (time.Duration, error) {
+	height, err := client.GetHeight(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	numIntervals := min(height, acceptanceSampleSize)
+	if numIntervals == 0 {
+		return 0, platformvm.ErrInsufficientBlockTimes
+	}
+
+	prevTime, err := platformvm.GetBlockTime(client, ctx, height-numIntervals)
+	if err != nil {
+		return 0, err
+	}
+	intervals := make([]time.Duration, 0, numIntervals)
+	for blkHeight := height - numIntervals + 1; blkHeight <= height; blkHeight++ {
+		blkTime, err := platformvm.GetBlockTime(client, ctx, blkHeight)
+		if err != nil {
+			return 0, err
+		}
+		intervals = append(intervals, max(blkTime.Sub(prevTime), 0))
+		prevTime = blkTime
+	}
+
+	slices.Sort(intervals)
+	return intervals[len(intervals)/2], nil
+}
@@ -0,0 +1,145 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/block"
+)
+
+// blockClient is a P-chain at [height] that has accepted [blocks].
+type blockClient struct {
+	platformvm.Client
+
+	height uint64
+	blocks map[uint64][]byte
+}
+
+func (c *blockClient) GetHeight(context.Context, ...rpc.Option) (uint64, error) {
+	return c.height, nil
+}
+
+func (c *blockClient) GetBlockByHeight(_ context.Context, height uint64, _ ...rpc.Option) ([]byte, error) {
+	blkBytes, ok := c.blocks[height]
+	if !ok {
+		return nil, errTest
+	}
+	return blkBytes, nil
+}
+
+func TestEstimateAcceptanceTime(t *testing.T) {
+	now := time.Unix(time.Now().Unix(), 0)
+
+	// newBlocks returns Banff blocks ending at [now] that are separated by
+	// [intervals].
+	newBlocks := func(t *testing.T, intervals ...time.Duration) map[uint64][]byte {
+		blocks := make(map[uint64][]byte, len(intervals)+1)
+		timestamp := now
+		for height := uint64(len(intervals)); ; height-- {
+			blk, err := block.NewBanffStandardBlock(timestamp, ids.GenerateTestID(), height, nil)
+			require.NoError(t, err)
+			blocks[height] = blk.Bytes()
+			if height == 0 {
+				return blocks
+			}
+			timestamp = timestamp.Add(-intervals[height-1])
+		}
+	}
+	newApricotBlock := func(t *testing.T, height uint64) []byte {
+		blk, err := block.NewApricotStandardBlock(ids.GenerateTestID(), height, nil)
+		require.NoError(t, err)
+		return blk.Bytes()
+	}
+
+	tests := []struct {
+		name             string
+		height           uint64
+		blocks           map[uint64][]byte
+		expectedEstimate time.Duration
+		expectedErr      error
+	}{
+		{
+			name:             "regular block times",
+			height:           3,
+			blocks:           newBlocks(t, 2*time.Second, 2*time.Second, 2*time.Second),
+			expectedEstimate: 2 * time.Second,
+		},
+		{
+			name:   "irregular block times",
+			height: 5,
+			blocks: newBlocks(t,
+				2*time.Second,
+				time.Minute,
+				time.Second,
+				3*time.Second,
+				2*time.Second,
+			),
+			expectedEstimate: 2 * time.Second,
+		},
+		{
+			name:             "single block",
+			height:           1,
+			blocks:           newBlocks(t, 5*time.Second),
+			expectedEstimate: 5 * time.Second,
+		},
+		{
+			name:   "more blocks than the sample size",
+			height: acceptanceSampleSize + 5,
+			blocks: func() map[uint64][]byte {
+				intervals := make([]time.Duration, acceptanceSampleSize+5)
+				for i := range intervals {
+					// Only the oldest blocks were produced slowly.
+					intervals[i] = time.Second
+					if i < 5 {
+						intervals[i] = time.Hour
+					}
+				}
+				return newBlocks(t, intervals...)
+			}(),
+			expectedEstimate: time.Second,
+		},
+		{
+			name:        "no accepted blocks",
+			height:      0,
+			expectedErr: platformvm.ErrInsufficientBlockTimes,
+		},
+		{
+			name:   "block without timestamp",
+			height: 1,
+			blocks: map[uint64][]byte{
+				0: newApricotBlock(t, 0),
+				1: newApricotBlock(t, 1),
+			},
+			expectedErr: platformvm.ErrMissingBlockTimestamp,
+		},
+		{
+			name:        "missing block",
+			height:      1,
+			expectedErr: errTest,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			estimate, err := EstimateAcceptanceTime(
+				context.Background(),
+				&blockClient{
+					height: test.height,
+					blocks: test.blocks,
+				},
+			)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expectedEstimate, estimate)
+		})
+	}
+}
@@ -5,7 +5,9 @@ package primary
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ava-labs/coreth/ethclient"
 	"github.com/ava-labs/coreth/plugin/evm"
@@ -13,7 +15,9 @@ import (
 	"github.com/ava-labs/avalanchego/api/info"
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/upgrade"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/avm"
@@ -38,6 +42,32 @@ const (
 	fetchLimit = 1024
 )
 
+var (
+	ErrNetworkMismatch      = errors.New("network mismatch")
+	ErrSubnetNotConvertible = errors.New("subnet is not convertible")
+	ErrNodeNotBootstrapped  = errors.New("node is not bootstrapped")
+	ErrIncompleteUTXOCrawl  = errors.New("incomplete UTXO crawl")
+	ErrUnknownUpgrade       = errors.New("unknown upgrade")
+	ErrUpgradeNotActive     = errors.New("upgrade is not active")
+
+	// upgradeTimes returns the activation time of each upgrade that can be
+	// passed to [RequireUpgrade].
+	upgradeTimes = map[string]func(*upgrade.Config) time.Time{
+		"ApricotPhase1":     func(c *upgrade.Config) time.Time { return c.ApricotPhase1Time },
+		"ApricotPhase2":     func(c *upgrade.Config) time.Time { return c.ApricotPhase2Time },
+		"ApricotPhase3":     func(c *upgrade.Config) time.Time { return c.ApricotPhase3Time },
+		"ApricotPhase4":     func(c *upgrade.Config) time.Time { return c.ApricotPhase4Time },
+		"ApricotPhase5":     func(c *upgrade.Config) time.Time { return c.ApricotPhase5Time },
+		"ApricotPhasePre6":  func(c *upgrade.Config) time.Time { return c.ApricotPhasePre6Time },
+		"ApricotPhase6":     func(c *upgrade.Config) time.Time { return c.ApricotPhase6Time },
+		"ApricotPhasePost6": func(c *upgrade.Config) time.Time { return c.ApricotPhasePost6Time },
+		"Banff":             func(c *upgrade.Config) time.Time { return c.BanffTime },
+		"Cortina":           func(c *upgrade.Config) time.Time { return c.CortinaTime },
+		"Durango":           func(c *upgrade.Config) time.Time { return c.DurangoTime },
+		"Etna":              func(c *upgrade.Config) time.Time { return c.EtnaTime },
+	}
+)
+
 // TODO: Refactor UTXOClient definition to allow the client implementations to
 // perform their own assertions.
 var (
@@ -57,6 +87,151 @@ type UTXOClient interface {
 	) ([][]byte, ids.ShortID, ids.ID, error)
 }
 
+// AssertSameNetwork returns an error if the node that [infoClient] is connected
+// to is not on the network that [pCTX] was created for. This protects against
+// signing transactions or Warp messages against one network while issuing
+// them to another.
+func AssertSameNetwork(
+	ctx context.Context,
+	infoClient info.Client,
+	pCTX *pbuilder.Context,
+) error {
+	networkID, err := infoClient.GetNetworkID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch network ID: %w", err)
+	}
+	if networkID != pCTX.NetworkID {
+		return fmt.Errorf("%w: node is on network %d but wallet is on network %d",
+			ErrNetworkMismatch,
+			networkID,
+			pCTX.NetworkID,
+		)
+	}
+	return nil
+}
+
+// AssertBootstrapped returns an error if the node that [infoClient] is
+// connected to has not finished bootstrapping all of [chains]. A node that is
+// still bootstrapping may report an incomplete set of UTXOs, which would
+// otherwise appear as a wallet with a lower balance than expected.
+func AssertBootstrapped(
+	ctx context.Context,
+	infoClient info.Client,
+	chains ...string,
+) error {
+	for _, chain := range chains {
+		isBootstrapped, err := infoClient.IsBootstrapped(ctx, chain)
+		if err != nil {
+			return fmt.Errorf("failed to check if %s is bootstrapped: %w", chain, err)
+		}
+		if !isBootstrapped {
+			return fmt.Errorf("%w: %s", ErrNodeNotBootstrapped, chain)
+		}
+	}
+	return nil
+}
+
+// RequireUpgrade returns an error if [upgradeName] has not activated on the
+// node that [infoClient] is connected to, according to the upgrade schedule
+// and the clock of the node. Transactions introduced by an upgrade, such as
+// the L1 transactions introduced by Etna, are rejected by a node that has not
+// activated the upgrade.
+//
+// [upgradeName] is the name of an upgrade in [upgrade.Config], such as
+// "Durango" or "Etna".
+func RequireUpgrade(
+	ctx context.Context,
+	infoClient info.Client,
+	upgradeName string,
+) error {
+	getTime, ok := upgradeTimes[upgradeName]
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownUpgrade, upgradeName)
+	}
+
+	upgrades, err := infoClient.Upgrades(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch upgrades: %w", err)
+	}
+	now, err := infoClient.NetworkTime(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch network time: %w", err)
+	}
+
+	activationTime := getTime(upgrades)
+	if now.Before(activationTime) {
+		return fmt.Errorf("%w: %s activates at %s but the node time is %s",
+			ErrUpgradeNotActive,
+			upgradeName,
+			activationTime,
+			now,
+		)
+	}
+	return nil
+}
+
+// AssertConvertible returns an error if [subnetID] can not be converted to an
+// L1 according to the node at [uri]. This protects against paying the fee of a
+// ConvertSubnetToL1Tx that will be rejected because the subnet does not exist,
+// has been transformed into a permissionless subnet, or has already been
+// converted to an L1.
+func AssertConvertible(
+	ctx context.Context,
+	uri string,
+	subnetID ids.ID,
+) error {
+	return assertConvertible(ctx, platformvm.NewClient(uri), subnetID)
+}
+
+func assertConvertible(
+	ctx context.Context,
+	client platformvm.Client,
+	subnetID ids.ID,
+) error {
+	subnet, err := client.GetSubnet(ctx, subnetID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch subnet %s: %w", subnetID, err)
+	}
+	if subnet.IsL1() {
+		return fmt.Errorf("%w: %s has already been converted to an L1 with conversionID %s",
+			ErrSubnetNotConvertible,
+			subnetID,
+			subnet.ConversionID,
+		)
+	}
+	if subnet.SubnetTransformationTxID != ids.Empty {
+		return fmt.Errorf("%w: %s was transformed into a permissionless subnet with txID %s",
+			ErrSubnetNotConvertible,
+			subnetID,
+			subnet.SubnetTransformationTxID,
+		)
+	}
+	return nil
+}
+
+// CanDisable returns true if [kc] can currently satisfy the deactivation owner
+// of the L1 validator with [validationID], according to [client]. This
+// protects against paying the fee of a DisableL1ValidatorTx that will fail to
+// be authorized.
+func CanDisable(
+	ctx context.Context,
+	client platformvm.Client,
+	kc keychain.Keychain,
+	validationID ids.ID,
+) (bool, error) {
+	l1Validator, _, err := client.GetL1Validator(ctx, validationID)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch L1 validator %s: %w", validationID, err)
+	}
+
+	_, ok := walletcommon.MatchOwners(
+		l1Validator.DeactivationOwner,
+		kc.Addresses(),
+		uint64(time.Now().Unix()),
+	)
+	return ok, nil
+}
+
 type AVAXState struct {
 	PClient platformvm.Client
 	PCTX    *pbuilder.Context
@@ -67,18 +242,38 @@ type AVAXState struct {
 	UTXOs   walletcommon.UTXOs
 }
 
+// FetchState fetches the P-chain, X-chain, and C-chain contexts along with all
+// the UTXOs referenced by [addrs]. The provided [options] are applied to every
+// P-chain and X-chain API request.
 func FetchState(
 	ctx context.Context,
 	uri string,
 	addrs set.Set[ids.ShortID],
+	options ...rpc.Option,
 ) (
 	*AVAXState,
 	error,
 ) {
-	infoClient := info.NewClient(uri)
-	pClient := platformvm.NewClient(uri)
-	xClient := avm.NewClient(uri, "X")
-	cClient := evm.NewCChainClient(uri)
+	return fetchState(ctx, uri, addrs, false, true, options...)
+}
+
+// fetchState fetches the state described by [FetchState]. If [includeC] is
+// false, the C-chain client and context are not populated and no UTXOs
+// exported from or to the C-chain are fetched.
+func fetchState(
+	ctx context.Context,
+	uri string,
+	addrs set.Set[ids.ShortID],
+	requireComplete bool,
+	includeC bool,
+	options ...rpc.Option,
+) (
+	*AVAXState,
+	error,
+) {
+	infoClient := info.NewClientWithOptions(uri, options...)
+	pClient := platformvm.NewClientWithOptions(uri, options...)
+	xClient := avm.NewClientWithOptions(uri, "X", options...)
 
 	pCTX, err := p.NewContextFromClients(ctx, infoClient, pClient)
 	if err != nil {
@@ -90,18 +285,12 @@ func FetchState(
 		return nil, err
 	}
 
-	cCTX, err := c.NewContextFromClients(ctx, infoClient, xClient)
-	if err != nil {
-		return nil, err
-	}
-
-	utxos := walletcommon.NewUTXOs()
-	addrList := addrs.List()
-	chains := []struct {
+	type chain struct {
 		id     ids.ID
 		client UTXOClient
 		codec  codec.Manager
-	}{
+	}
+	chains := []chain{
 		{
 			id:     constants.PlatformChainID,
 			client: pClient,
@@ -112,15 +301,30 @@ func FetchState(
 			client: xClient,
 			codec:  xbuilder.Parser.Codec(),
 		},
-		{
+	}
+
+	var (
+		cClient evm.Client
+		cCTX    *c.Context
+	)
+	if includeC {
+		cClient = evm.NewCChainClient(uri)
+		cCTX, err = c.NewContextFromClients(ctx, infoClient, xClient)
+		if err != nil {
+			return nil, err
+		}
+		chains = append(chains, chain{
 			id:     cCTX.BlockchainID,
 			client: cClient,
 			codec:  evm.Codec,
-		},
+		})
 	}
+
+	utxos := walletcommon.NewUTXOs()
+	addrList := addrs.List()
 	for _, destinationChain := range chains {
 		for _, sourceChain := range chains {
-			err = AddAllUTXOs(
+			err = addAllUTXOs(
 				ctx,
 				utxos,
 				destinationChain.client,
@@ -128,6 +332,7 @@ func FetchState(
 				sourceChain.id,
 				destinationChain.id,
 				addrList,
+				requireComplete,
 			)
 			if err != nil {
 				return nil, err
@@ -145,18 +350,37 @@ func FetchState(
 	}, nil
 }
 
+// FetchPState fetches the P-chain context along with all the P-chain UTXOs
+// referenced by [addrs]. The provided [options] are applied to every API
+// request.
 func FetchPState(
 	ctx context.Context,
 	uri string,
 	addrs set.Set[ids.ShortID],
+	options ...rpc.Option,
+) (
+	platformvm.Client,
+	*pbuilder.Context,
+	walletcommon.UTXOs,
+	error,
+) {
+	return fetchPState(ctx, uri, addrs, false, options...)
+}
+
+func fetchPState(
+	ctx context.Context,
+	uri string,
+	addrs set.Set[ids.ShortID],
+	requireComplete bool,
+	options ...rpc.Option,
 ) (
 	platformvm.Client,
 	*pbuilder.Context,
 	walletcommon.UTXOs,
 	error,
 ) {
-	infoClient := info.NewClient(uri)
-	chainClient := platformvm.NewClient(uri)
+	infoClient := info.NewClientWithOptions(uri, options...)
+	chainClient := platformvm.NewClientWithOptions(uri, options...)
 
 	context, err := p.NewContextFromClients(ctx, infoClient, chainClient)
 	if err != nil {
@@ -165,7 +389,7 @@ func FetchPState(
 
 	utxos := walletcommon.NewUTXOs()
 	addrList := addrs.List()
-	err = AddAllUTXOs(
+	err = addAllUTXOs(
 		ctx,
 		utxos,
 		chainClient,
@@ -173,6 +397,7 @@ func FetchPState(
 		constants.PlatformChainID,
 		constants.PlatformChainID,
 		addrList,
+		requireComplete,
 	)
 	return chainClient, context, utxos, err
 }
@@ -230,6 +455,36 @@ func AddAllUTXOs(
 	sourceChainID ids.ID,
 	destinationChainID ids.ID,
 	addrs []ids.ShortID,
+) error {
+	return addAllUTXOs(
+		ctx,
+		utxos,
+		client,
+		codec,
+		sourceChainID,
+		destinationChainID,
+		addrs,
+		false,
+	)
+}
+
+// addAllUTXOs implements [AddAllUTXOs].
+//
+// By default, the crawl ends once a page contains fewer than [fetchLimit]
+// UTXOs. If [requireComplete] is true, the crawl only ends once a page is
+// empty, so that a node that returns smaller pages than requested can not
+// cause UTXOs to be silently skipped. [ErrIncompleteUTXOCrawl] is returned if
+// the node returns a page without advancing the cursor, as the remaining UTXOs
+// could otherwise never be fetched.
+func addAllUTXOs(
+	ctx context.Context,
+	utxos walletcommon.UTXOs,
+	client UTXOClient,
+	codec codec.Manager,
+	sourceChainID ids.ID,
+	destinationChainID ids.ID,
+	addrs []ids.ShortID,
+	requireComplete bool,
 ) error {
 	var (
 		sourceChainIDStr = sourceChainID.String()
@@ -261,9 +516,16 @@ func AddAllUTXOs(
 			}
 		}
 
-		if len(utxosBytes) < fetchLimit {
+		if len(utxosBytes) == 0 || (!requireComplete && len(utxosBytes) < fetchLimit) {
 			break
 		}
+		if requireComplete && endAddr == startAddr && endUTXO == startUTXO {
+			return fmt.Errorf("%w: cursor did not advance past %s:%s",
+				ErrIncompleteUTXOCrawl,
+				startAddr,
+				startUTXO,
+			)
+		}
 
 		// Update the vars to query the next page of UTXOs.
 		startAddr = endAddr
@@ -0,0 +1,458 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/upgrade"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	pbuilder "github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	walletcommon "github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+)
+
+var errTest = errors.New("non-nil error")
+
+type networkIDClient struct {
+	info.Client
+
+	networkID uint32
+}
+
+func (c *networkIDClient) GetNetworkID(context.Context, ...rpc.Option) (uint32, error) {
+	return c.networkID, nil
+}
+
+type bootstrappedClient struct {
+	info.Client
+
+	bootstrapped map[string]bool
+}
+
+func (c *bootstrappedClient) IsBootstrapped(_ context.Context, chain string, _ ...rpc.Option) (bool, error) {
+	return c.bootstrapped[chain], nil
+}
+
+func TestAssertBootstrapped(t *testing.T) {
+	tests := []struct {
+		name         string
+		bootstrapped map[string]bool
+		expectedErr  error
+	}{
+		{
+			name: "bootstrapped",
+			bootstrapped: map[string]bool{
+				"P": true,
+				"X": true,
+			},
+		},
+		{
+			name: "bootstrapping",
+			bootstrapped: map[string]bool{
+				"P": true,
+				"X": false,
+			},
+			expectedErr: ErrNodeNotBootstrapped,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := AssertBootstrapped(
+				context.Background(),
+				&bootstrappedClient{
+					bootstrapped: test.bootstrapped,
+				},
+				"P",
+				"X",
+			)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestAssertSameNetwork(t *testing.T) {
+	tests := []struct {
+		name          string
+		nodeNetworkID uint32
+		expectedErr   error
+	}{
+		{
+			name:          "same network",
+			nodeNetworkID: constants.FujiID,
+		},
+		{
+			name:          "different network",
+			nodeNetworkID: constants.MainnetID,
+			expectedErr:   ErrNetworkMismatch,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := AssertSameNetwork(
+				context.Background(),
+				&networkIDClient{
+					networkID: test.nodeNetworkID,
+				},
+				&pbuilder.Context{
+					NetworkID: constants.FujiID,
+				},
+			)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+type subnetClient struct {
+	platformvm.Client
+
+	subnet platformvm.GetSubnetClientResponse
+	err    error
+}
+
+func (c *subnetClient) GetSubnet(context.Context, ids.ID, ...rpc.Option) (platformvm.GetSubnetClientResponse, error) {
+	return c.subnet, c.err
+}
+
+type upgradesClient struct {
+	info.Client
+
+	upgrades upgrade.Config
+	now      time.Time
+}
+
+func (c *upgradesClient) Upgrades(context.Context, ...rpc.Option) (*upgrade.Config, error) {
+	return &c.upgrades, nil
+}
+
+func (c *upgradesClient) NetworkTime(context.Context, ...rpc.Option) (time.Time, error) {
+	return c.now, nil
+}
+
+func TestRequireUpgrade(t *testing.T) {
+	var (
+		etnaTime = time.Date(2024, time.December, 16, 17, 0, 0, 0, time.UTC)
+		upgrades = upgrade.Config{
+			DurangoTime: etnaTime.Add(-time.Hour),
+			EtnaTime:    etnaTime,
+		}
+	)
+	tests := []struct {
+		name        string
+		upgrade     string
+		now         time.Time
+		expectedErr error
+	}{
+		{
+			name:    "active",
+			upgrade: "Etna",
+			now:     etnaTime,
+		},
+		{
+			name:        "pre-upgrade node",
+			upgrade:     "Etna",
+			now:         etnaTime.Add(-time.Second),
+			expectedErr: ErrUpgradeNotActive,
+		},
+		{
+			name:    "earlier upgrade active",
+			upgrade: "Durango",
+			now:     etnaTime.Add(-time.Second),
+		},
+		{
+			name:        "unknown upgrade",
+			upgrade:     "ACP-77",
+			now:         etnaTime,
+			expectedErr: ErrUnknownUpgrade,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := RequireUpgrade(
+				context.Background(),
+				&upgradesClient{
+					upgrades: upgrades,
+					now:      test.now,
+				},
+				test.upgrade,
+			)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestAssertConvertible(t *testing.T) {
+	tests := []struct {
+		name        string
+		subnet      platformvm.GetSubnetClientResponse
+		err         error
+		expectedErr error
+	}{
+		{
+			name: "permissioned subnet",
+			subnet: platformvm.GetSubnetClientResponse{
+				IsPermissioned: true,
+				ControlKeys:    []ids.ShortID{ids.GenerateTestShortID()},
+				Threshold:      1,
+			},
+		},
+		{
+			name:        "unknown subnet",
+			err:         errTest,
+			expectedErr: errTest,
+		},
+		{
+			name: "permissionless subnet",
+			subnet: platformvm.GetSubnetClientResponse{
+				SubnetTransformationTxID: ids.GenerateTestID(),
+			},
+			expectedErr: ErrSubnetNotConvertible,
+		},
+		{
+			name: "L1",
+			subnet: platformvm.GetSubnetClientResponse{
+				ConversionID:   ids.GenerateTestID(),
+				ManagerChainID: ids.GenerateTestID(),
+			},
+			expectedErr: ErrSubnetNotConvertible,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := assertConvertible(
+				context.Background(),
+				&subnetClient{
+					subnet: test.subnet,
+					err:    test.err,
+				},
+				ids.GenerateTestID(),
+			)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+type l1ValidatorClient struct {
+	platformvm.Client
+
+	l1Validator platformvm.L1Validator
+	err         error
+}
+
+func (c *l1ValidatorClient) GetL1Validator(context.Context, ids.ID, ...rpc.Option) (platformvm.L1Validator, uint64, error) {
+	return c.l1Validator, 0, c.err
+}
+
+func TestCanDisable(t *testing.T) {
+	var (
+		keys  = secp256k1.TestKeys()
+		addr0 = keys[0].Address()
+		addr1 = keys[1].Address()
+	)
+	tests := []struct {
+		name        string
+		owner       *secp256k1fx.OutputOwners
+		err         error
+		expected    bool
+		expectedErr error
+	}{
+		{
+			name: "owned by key",
+			owner: &secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr0},
+			},
+			expected: true,
+		},
+		{
+			name: "threshold reached",
+			owner: &secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr0, addr1},
+			},
+			expected: true,
+		},
+		{
+			name: "missing owner key",
+			owner: &secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr1},
+			},
+			expected: false,
+		},
+		{
+			name: "threshold not reached",
+			owner: &secp256k1fx.OutputOwners{
+				Threshold: 2,
+				Addrs:     []ids.ShortID{addr0, addr1},
+			},
+			expected: false,
+		},
+		{
+			name: "locked",
+			owner: &secp256k1fx.OutputOwners{
+				Locktime:  math.MaxUint64,
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addr0},
+			},
+			expected: false,
+		},
+		{
+			name:        "unknown validator",
+			err:         errTest,
+			expectedErr: errTest,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			canDisable, err := CanDisable(
+				context.Background(),
+				&l1ValidatorClient{
+					l1Validator: platformvm.L1Validator{
+						DeactivationOwner: test.owner,
+					},
+					err: test.err,
+				},
+				secp256k1fx.NewKeychain(keys[0]),
+				ids.GenerateTestID(),
+			)
+			require.ErrorIs(err, test.expectedErr)
+			require.Equal(test.expected, canDisable)
+		})
+	}
+}
+
+// pagedUTXOClient returns one element of [pages] per request, followed by
+// [err] once all the pages have been returned. Each page advances the cursor
+// unless [stuck] is set.
+type pagedUTXOClient struct {
+	pages    [][][]byte
+	err      error
+	stuck    bool
+	requests int
+}
+
+func (c *pagedUTXOClient) GetAtomicUTXOs(
+	_ context.Context,
+	_ []ids.ShortID,
+	_ string,
+	_ uint32,
+	startAddr ids.ShortID,
+	startUTXO ids.ID,
+	_ ...rpc.Option,
+) ([][]byte, ids.ShortID, ids.ID, error) {
+	defer func() {
+		c.requests++
+	}()
+	if c.requests >= len(c.pages) {
+		return nil, ids.ShortEmpty, ids.Empty, c.err
+	}
+	if c.stuck {
+		return c.pages[c.requests], startAddr, startUTXO, nil
+	}
+	return c.pages[c.requests], ids.ShortEmpty, ids.ID{byte(c.requests + 1)}, nil
+}
+
+func TestAddAllUTXOsRequireComplete(t *testing.T) {
+	newUTXOBytes := func(t *testing.T) []byte {
+		utxo := &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID: ids.GenerateTestID(),
+			},
+			Asset: avax.Asset{
+				ID: ids.GenerateTestID(),
+			},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1,
+			},
+		}
+		bytes, err := txs.Codec.Marshal(txs.CodecVersion, utxo)
+		require.NoError(t, err)
+		return bytes
+	}
+
+	// The node returns fewer UTXOs than requested on the first page even
+	// though more UTXOs exist.
+	pages := [][][]byte{
+		{newUTXOBytes(t)},
+		{newUTXOBytes(t)},
+	}
+
+	tests := []struct {
+		name             string
+		requireComplete  bool
+		err              error
+		stuck            bool
+		expectedErr      error
+		expectedNumUTXOs int
+	}{
+		{
+			name:             "stops at short page",
+			expectedNumUTXOs: 1,
+		},
+		{
+			name:             "continues until empty page",
+			requireComplete:  true,
+			expectedNumUTXOs: 2,
+		},
+		{
+			name:            "fails on mid-crawl error",
+			requireComplete: true,
+			err:             errTest,
+			expectedErr:     errTest,
+		},
+		{
+			name:            "fails if the cursor does not advance",
+			requireComplete: true,
+			stuck:           true,
+			expectedErr:     ErrIncompleteUTXOCrawl,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			ctx := context.Background()
+			utxos := walletcommon.NewUTXOs()
+			err := addAllUTXOs(
+				ctx,
+				utxos,
+				&pagedUTXOClient{
+					pages: pages,
+					err:   test.err,
+					stuck: test.stuck,
+				},
+				txs.Codec,
+				constants.PlatformChainID,
+				constants.PlatformChainID,
+				nil,
+				test.requireComplete,
+			)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			fetchedUTXOs, err := utxos.UTXOs(ctx, constants.PlatformChainID, constants.PlatformChainID)
+			require.NoError(err)
+			require.Len(fetchedUTXOs, test.expectedNumUTXOs)
+		})
+	}
+}
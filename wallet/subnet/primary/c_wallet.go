@@ -0,0 +1,109 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ava-labs/coreth/plugin/evm"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/c"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	_ c.Wallet  = notConfiguredCWallet{}
+	_ c.Builder = notConfiguredCBuilder{}
+	_ c.Signer  = notConfiguredCSigner{}
+)
+
+// notConfiguredCWallet is the C-chain wallet used when no EthKeychain was
+// provided. It reports ErrCChainNotConfigured when issuing transactions, as do
+// its builder and signer.
+type notConfiguredCWallet struct{}
+
+func (notConfiguredCWallet) Builder() c.Builder {
+	return notConfiguredCBuilder{}
+}
+
+func (notConfiguredCWallet) Signer() c.Signer {
+	return notConfiguredCSigner{}
+}
+
+func (notConfiguredCWallet) IssueImportTx(
+	ids.ID,
+	ethcommon.Address,
+	...common.Option,
+) (*evm.Tx, error) {
+	return nil, ErrCChainNotConfigured
+}
+
+func (notConfiguredCWallet) IssueExportTx(
+	ids.ID,
+	[]*secp256k1fx.TransferOutput,
+	...common.Option,
+) (*evm.Tx, error) {
+	return nil, ErrCChainNotConfigured
+}
+
+func (notConfiguredCWallet) IssueUnsignedAtomicTx(
+	evm.UnsignedAtomicTx,
+	...common.Option,
+) (*evm.Tx, error) {
+	return nil, ErrCChainNotConfigured
+}
+
+func (notConfiguredCWallet) IssueAtomicTx(
+	*evm.Tx,
+	...common.Option,
+) error {
+	return ErrCChainNotConfigured
+}
+
+// notConfiguredCBuilder is the builder of [notConfiguredCWallet]. The C-chain
+// context is not fetched when the C-chain wallet is not configured, so Context
+// returns nil.
+type notConfiguredCBuilder struct{}
+
+func (notConfiguredCBuilder) Context() *c.Context {
+	return nil
+}
+
+func (notConfiguredCBuilder) GetBalance(...common.Option) (*big.Int, error) {
+	return nil, ErrCChainNotConfigured
+}
+
+func (notConfiguredCBuilder) GetImportableBalance(ids.ID, ...common.Option) (uint64, error) {
+	return 0, ErrCChainNotConfigured
+}
+
+func (notConfiguredCBuilder) NewImportTx(
+	ids.ID,
+	ethcommon.Address,
+	*big.Int,
+	...common.Option,
+) (*evm.UnsignedImportTx, error) {
+	return nil, ErrCChainNotConfigured
+}
+
+func (notConfiguredCBuilder) NewExportTx(
+	ids.ID,
+	[]*secp256k1fx.TransferOutput,
+	*big.Int,
+	...common.Option,
+) (*evm.UnsignedExportTx, error) {
+	return nil, ErrCChainNotConfigured
+}
+
+// notConfiguredCSigner is the signer of [notConfiguredCWallet].
+type notConfiguredCSigner struct{}
+
+func (notConfiguredCSigner) SignAtomic(context.Context, *evm.Tx) error {
+	return ErrCChainNotConfigured
+}
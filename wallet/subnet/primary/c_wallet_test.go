@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+func TestNotConfiguredCWallet(t *testing.T) {
+	require := require.New(t)
+
+	wallet := NewWalletWithOptions(NewWallet(nil, nil, notConfiguredCWallet{}))
+
+	_, err := wallet.C().IssueImportTx(ids.GenerateTestID(), ethcommon.Address{})
+	require.ErrorIs(err, ErrCChainNotConfigured)
+
+	_, err = wallet.C().IssueExportTx(ids.GenerateTestID(), nil)
+	require.ErrorIs(err, ErrCChainNotConfigured)
+
+	require.ErrorIs(wallet.C().IssueAtomicTx(nil), ErrCChainNotConfigured)
+
+	builder := wallet.C().Builder()
+	require.Nil(builder.Context())
+
+	_, err = builder.GetBalance()
+	require.ErrorIs(err, ErrCChainNotConfigured)
+
+	_, err = builder.NewImportTx(ids.GenerateTestID(), ethcommon.Address{}, nil)
+	require.ErrorIs(err, ErrCChainNotConfigured)
+
+	err = wallet.C().Signer().SignAtomic(context.Background(), nil)
+	require.ErrorIs(err, ErrCChainNotConfigured)
+}
+
+func TestIsNil(t *testing.T) {
+	var nilKeychain *secp256k1fx.Keychain
+	require.True(t, isNil(nil))
+	require.True(t, isNil(nilKeychain))
+	require.False(t, isNil(secp256k1fx.NewKeychain()))
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
 	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
@@ -34,6 +35,10 @@ type Options struct {
 
 	baseFee *big.Int
 
+	feeMultiplierSet         bool
+	feeMultiplierNumerator   uint64
+	feeMultiplierDenominator uint64
+
 	minIssuanceTimeSet bool
 	minIssuanceTime    uint64
 
@@ -41,13 +46,24 @@ type Options struct {
 
 	changeOwner *secp256k1fx.OutputOwners
 
+	feePayer keychain.Keychain
+
+	subnetAuthAddressesSet bool
+	subnetAuthAddresses    []ids.ShortID
+
 	memo []byte
 
 	assumeDecided bool
 
+	idempotencyCheck bool
+
 	pollFrequencySet bool
 	pollFrequency    time.Duration
 
+	confirmations uint64
+
+	unknownGracePeriod time.Duration
+
 	postIssuanceFunc PostIssuanceFunc
 }
 
@@ -85,6 +101,15 @@ func (o *Options) Addresses(defaultAddresses set.Set[ids.ShortID]) set.Set[ids.S
 	return defaultAddresses
 }
 
+// SpendAddresses returns the addresses whose UTXOs may be consumed. If a fee
+// payer was provided, only the addresses of the fee payer are returned.
+func (o *Options) SpendAddresses(defaultAddresses set.Set[ids.ShortID]) set.Set[ids.ShortID] {
+	if o.feePayer != nil {
+		return o.feePayer.Addresses()
+	}
+	return o.Addresses(defaultAddresses)
+}
+
 func (o *Options) EthAddresses(defaultAddresses set.Set[ethcommon.Address]) set.Set[ethcommon.Address] {
 	if o.customEthAddressesSet {
 		return o.customEthAddresses
@@ -99,6 +124,16 @@ func (o *Options) BaseFee(defaultBaseFee *big.Int) *big.Int {
 	return defaultBaseFee
 }
 
+// FeeMultiplier returns the numerator and denominator of the multiplier that
+// should be applied to the required fee. If no multiplier was provided, 1/1 is
+// returned.
+func (o *Options) FeeMultiplier() (uint64, uint64) {
+	if o.feeMultiplierSet {
+		return o.feeMultiplierNumerator, o.feeMultiplierDenominator
+	}
+	return 1, 1
+}
+
 func (o *Options) MinIssuanceTime() uint64 {
 	if o.minIssuanceTimeSet {
 		return o.minIssuanceTime
@@ -117,6 +152,16 @@ func (o *Options) ChangeOwner(defaultOwner *secp256k1fx.OutputOwners) *secp256k1
 	return defaultOwner
 }
 
+func (o *Options) FeePayer() keychain.Keychain {
+	return o.feePayer
+}
+
+// SubnetAuthAddresses returns the addresses that should be used to authorize
+// subnet operations and whether they were provided.
+func (o *Options) SubnetAuthAddresses() ([]ids.ShortID, bool) {
+	return o.subnetAuthAddresses, o.subnetAuthAddressesSet
+}
+
 func (o *Options) Memo() []byte {
 	return o.memo
 }
@@ -125,6 +170,10 @@ func (o *Options) AssumeDecided() bool {
 	return o.assumeDecided
 }
 
+func (o *Options) IdempotencyCheck() bool {
+	return o.idempotencyCheck
+}
+
 func (o *Options) PollFrequency() time.Duration {
 	if o.pollFrequencySet {
 		return o.pollFrequency
@@ -132,6 +181,14 @@ func (o *Options) PollFrequency() time.Duration {
 	return defaultPollFrequency
 }
 
+func (o *Options) Confirmations() uint64 {
+	return o.confirmations
+}
+
+func (o *Options) UnknownGracePeriod() time.Duration {
+	return o.unknownGracePeriod
+}
+
 func (o *Options) PostIssuanceFunc() PostIssuanceFunc {
 	return o.postIssuanceFunc
 }
@@ -162,6 +219,20 @@ func WithBaseFee(baseFee *big.Int) Option {
 	}
 }
 
+// WithFeeMultiplier pads the required fee by [numerator]/[denominator]. This can
+// be used to improve the odds of a transaction being accepted if the fee is
+// expected to increase before the transaction is included.
+//
+// The denominator must be non-zero and the numerator must be at least the
+// denominator, so that the fee is never reduced.
+func WithFeeMultiplier(numerator, denominator uint64) Option {
+	return func(o *Options) {
+		o.feeMultiplierSet = true
+		o.feeMultiplierNumerator = numerator
+		o.feeMultiplierDenominator = denominator
+	}
+}
+
 func WithMinIssuanceTime(minIssuanceTime uint64) Option {
 	return func(o *Options) {
 		o.minIssuanceTimeSet = true
@@ -181,6 +252,35 @@ func WithChangeOwner(changeOwner *secp256k1fx.OutputOwners) Option {
 	}
 }
 
+// WithFeePayer consumes the UTXOs of [feePayer], rather than the UTXOs of the
+// wallet, to fund a transaction. Change is returned to [feePayer] unless a
+// change owner is provided. Authorizations, such as subnet authorizations, are
+// still provided by the wallet.
+//
+// The P-chain wallet fetches the UTXOs of [feePayer] the first time that the
+// option is used, and the first time after the wallet is refreshed, with a
+// client that is able to sync the UTXOs. Otherwise, the UTXOs of [feePayer]
+// must already be held by the wallet.
+//
+// This option is only supported by the P-chain.
+func WithFeePayer(feePayer keychain.Keychain) Option {
+	return func(o *Options) {
+		o.feePayer = feePayer
+	}
+}
+
+// WithSubnetAuthKeys authorizes subnet operations using only the control keys
+// in [addrs], rather than any control keys the wallet holds. Every address in
+// [addrs] must be held by the wallet.
+//
+// This option is only supported by the P-chain.
+func WithSubnetAuthKeys(addrs []ids.ShortID) Option {
+	return func(o *Options) {
+		o.subnetAuthAddressesSet = true
+		o.subnetAuthAddresses = addrs
+	}
+}
+
 func WithMemo(memo []byte) Option {
 	return func(o *Options) {
 		o.memo = memo
@@ -193,6 +293,17 @@ func WithAssumeDecided() Option {
 	}
 }
 
+// WithIdempotencyCheck skips broadcasting a transaction if the node already
+// knows about a transaction with the same ID. This makes it safe to retry
+// issuing a transaction that may have already been issued.
+//
+// This option is only supported by the P-chain.
+func WithIdempotencyCheck() Option {
+	return func(o *Options) {
+		o.idempotencyCheck = true
+	}
+}
+
 func WithPollFrequency(pollFrequency time.Duration) Option {
 	return func(o *Options) {
 		o.pollFrequencySet = true
@@ -200,6 +311,32 @@ func WithPollFrequency(pollFrequency time.Duration) Option {
 	}
 }
 
+// WithConfirmations waits, after a transaction is accepted, until at least
+// [confirmations] blocks have been accepted on top of the block that included
+// the transaction.
+//
+// This option is only supported by the P-chain and is ignored if the
+// transaction is assumed to be decided.
+func WithConfirmations(confirmations uint64) Option {
+	return func(o *Options) {
+		o.confirmations = confirmations
+	}
+}
+
+// WithUnknownGracePeriod considers an issued transaction to have been dropped
+// once the node has reported it as unknown, or dropped, for longer than
+// [gracePeriod]. A transaction may briefly be reported as unknown right after
+// it is issued, so [gracePeriod] should be longer than the time a node takes
+// to add an issued transaction to its mempool.
+//
+// By default, a transaction is never considered to have been dropped. This
+// option is only supported by the P-chain.
+func WithUnknownGracePeriod(gracePeriod time.Duration) Option {
+	return func(o *Options) {
+		o.unknownGracePeriod = gracePeriod
+	}
+}
+
 func WithPostIssuanceFunc(f PostIssuanceFunc) Option {
 	return func(o *Options) {
 		o.postIssuanceFunc = f
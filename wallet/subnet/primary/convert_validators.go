@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+)
+
+// ConvertValidatorSpec describes an initial validator of an L1 that is run by
+// the node serving its API at URI.
+type ConvertValidatorSpec struct {
+	URI                   string
+	Weight                uint64
+	Balance               uint64
+	RemainingBalanceOwner message.PChainOwner
+	DeactivationOwner     message.PChainOwner
+}
+
+// BuildConvertValidators fetches the nodeID and proof of possession of every
+// node described by [specs] and returns the validators to include in a
+// ConvertSubnetToL1Tx. The nodes are queried concurrently and the provided
+// [options] are applied to every request.
+//
+// The returned validators are sorted in the order that they will be included
+// in the ConvertSubnetToL1Tx, so the validator at index i is assigned the
+// validationID subnetID.Append(i).
+func BuildConvertValidators(
+	ctx context.Context,
+	specs []ConvertValidatorSpec,
+	options ...rpc.Option,
+) ([]*txs.ConvertSubnetToL1Validator, error) {
+	uris := make([]string, len(specs))
+	for i, spec := range specs {
+		uris[i] = spec.URI
+	}
+	nodeInfos, err := info.GetNodeIDs(ctx, uris, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	validators := make([]*txs.ConvertSubnetToL1Validator, len(specs))
+	for i, spec := range specs {
+		nodeInfo := nodeInfos[spec.URI]
+		if nodeInfo.NodePOP == nil {
+			return nil, fmt.Errorf("%w from %s", ErrMissingProofOfPossession, spec.URI)
+		}
+
+		validators[i] = &txs.ConvertSubnetToL1Validator{
+			NodeID:                nodeInfo.NodeID.Bytes(),
+			Weight:                spec.Weight,
+			Balance:               spec.Balance,
+			Signer:                *nodeInfo.NodePOP,
+			RemainingBalanceOwner: spec.RemainingBalanceOwner,
+			DeactivationOwner:     spec.DeactivationOwner,
+		}
+	}
+
+	if err := txs.ValidateValidatorSet(validators); err != nil {
+		return nil, err
+	}
+	utils.Sort(validators)
+	return validators, nil
+}
+
+// OverflowRegistrationParams returns the parameters to register [validators]
+// to the L1 after it was converted with a subset of its initial validators,
+// typically the chunks after the first one returned by
+// [txs.ChunkConvertValidators].
+//
+// [chainID] and [address] identify the validator manager expected to sign the
+// registrations, and [expiry] is the expiry of every registration message.
+func OverflowRegistrationParams(
+	subnetID ids.ID,
+	chainID ids.ID,
+	address []byte,
+	expiry uint64,
+	validators []*txs.ConvertSubnetToL1Validator,
+) ([]*RegistrationParams, error) {
+	params := make([]*RegistrationParams, len(validators))
+	for i, vdr := range validators {
+		nodeID, err := ids.ToNodeID(vdr.NodeID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid nodeID of validator %d: %w", i, err)
+		}
+
+		pop := vdr.Signer
+		params[i] = &RegistrationParams{
+			SubnetID:              subnetID,
+			ChainID:               chainID,
+			Address:               address,
+			NodeID:                nodeID,
+			ProofOfPossession:     &pop,
+			Expiry:                expiry,
+			RemainingBalanceOwner: vdr.RemainingBalanceOwner,
+			DisableOwner:          vdr.DeactivationOwner,
+			Weight:                vdr.Weight,
+			Balance:               vdr.Balance,
+		}
+	}
+	return params, nil
+}
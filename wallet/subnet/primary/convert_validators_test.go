@@ -0,0 +1,176 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+)
+
+// newInfoNode returns the URI of a node that responds to info.getNodeID with
+// [reply].
+func newInfoNode(t *testing.T, reply *info.GetNodeIDReply) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"jsonrpc": "2.0",
+			"id":      0,
+			"result":  reply,
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func newNodeIDReply(t *testing.T) *info.GetNodeIDReply {
+	sk, err := bls.NewSigner()
+	require.NoError(t, err)
+	return &info.GetNodeIDReply{
+		NodeID:  ids.GenerateTestNodeID(),
+		NodePOP: signer.NewProofOfPossession(sk),
+	}
+}
+
+func TestBuildConvertValidators(t *testing.T) {
+	var (
+		node0      = newNodeIDReply(t)
+		node1      = newNodeIDReply(t)
+		node0URI   = newInfoNode(t, node0)
+		node1URI   = newInfoNode(t, node1)
+		copy0URI   = newInfoNode(t, node0)
+		withoutPoP = newInfoNode(t, &info.GetNodeIDReply{
+			NodeID: ids.GenerateTestNodeID(),
+		})
+	)
+	tests := []struct {
+		name            string
+		specs           []ConvertValidatorSpec
+		expectedNodeIDs []ids.NodeID
+		expectedErr     error
+	}{
+		{
+			name: "multiple validators",
+			specs: []ConvertValidatorSpec{
+				{URI: node0URI, Weight: 1},
+				{URI: node1URI, Weight: 2},
+			},
+			expectedNodeIDs: []ids.NodeID{node0.NodeID, node1.NodeID},
+		},
+		{
+			name: "duplicate node",
+			specs: []ConvertValidatorSpec{
+				{URI: node0URI, Weight: 1},
+				{URI: copy0URI, Weight: 1},
+			},
+			expectedErr: txs.ErrDuplicateNodeID,
+		},
+		{
+			name: "missing proof of possession",
+			specs: []ConvertValidatorSpec{
+				{URI: node0URI, Weight: 1},
+				{URI: withoutPoP, Weight: 1},
+			},
+			expectedErr: ErrMissingProofOfPossession,
+		},
+		{
+			name:        "no validators",
+			expectedErr: txs.ErrConvertMustIncludeValidators,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			validators, err := BuildConvertValidators(context.Background(), test.specs)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			// The validators are returned in their canonical ordering.
+			slices.SortFunc(test.expectedNodeIDs, ids.NodeID.Compare)
+			nodeIDs := make([]ids.NodeID, len(validators))
+			for i, vdr := range validators {
+				nodeIDs[i], err = ids.ToNodeID(vdr.NodeID)
+				require.NoError(err)
+			}
+			require.Equal(test.expectedNodeIDs, nodeIDs)
+		})
+	}
+}
+
+func TestOverflowRegistrationParams(t *testing.T) {
+	require := require.New(t)
+
+	sk, err := bls.NewSigner()
+	require.NoError(err)
+
+	var (
+		subnetID = ids.GenerateTestID()
+		chainID  = ids.GenerateTestID()
+		address  = []byte{1, 2, 3}
+		expiry   = uint64(1_700_000_000)
+		nodeID   = ids.GenerateTestNodeID()
+		owner    = message.PChainOwner{
+			Threshold: 1,
+			Addresses: []ids.ShortID{ids.GenerateTestShortID()},
+		}
+		vdr = &txs.ConvertSubnetToL1Validator{
+			NodeID:                nodeID.Bytes(),
+			Weight:                5,
+			Balance:               7,
+			Signer:                *signer.NewProofOfPossession(sk),
+			RemainingBalanceOwner: owner,
+			DeactivationOwner:     owner,
+		}
+	)
+	params, err := OverflowRegistrationParams(
+		subnetID,
+		chainID,
+		address,
+		expiry,
+		[]*txs.ConvertSubnetToL1Validator{vdr},
+	)
+	require.NoError(err)
+	require.Equal(
+		[]*RegistrationParams{
+			{
+				SubnetID:              subnetID,
+				ChainID:               chainID,
+				Address:               address,
+				NodeID:                nodeID,
+				ProofOfPossession:     &vdr.Signer,
+				Expiry:                expiry,
+				RemainingBalanceOwner: owner,
+				DisableOwner:          owner,
+				Weight:                5,
+				Balance:               7,
+			},
+		},
+		params,
+	)
+
+	vdr.NodeID = []byte{1, 2, 3}
+	_, err = OverflowRegistrationParams(
+		subnetID,
+		chainID,
+		address,
+		expiry,
+		[]*txs.ConvertSubnetToL1Validator{vdr},
+	)
+	require.ErrorIs(err, hashing.ErrInvalidHashLen)
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/vms/types"
+)
+
+const (
+	ExampleURIEnvVar        = "EXAMPLE_URI"
+	ExamplePrivateKeyEnvVar = "EXAMPLE_PRIVATE_KEY"
+	ExampleSubnetIDEnvVar   = "EXAMPLE_SUBNET_ID"
+	ExampleChainIDEnvVar    = "EXAMPLE_CHAIN_ID"
+	ExampleBLSKeyPathEnvVar = "EXAMPLE_BLS_KEY_PATH"
+)
+
+var ErrInvalidExampleConfig = errors.New("invalid example config")
+
+// ExampleConfig contains the values that the examples would otherwise
+// hard-code, so that the examples can be run against any network.
+type ExampleConfig struct {
+	// URI of the node to issue requests to.
+	URI string `json:"uri"`
+	// PrivateKey that funds the transactions issued by the example.
+	PrivateKey *secp256k1.PrivateKey `json:"privateKey"`
+	// SubnetID that the example operates on.
+	SubnetID ids.ID `json:"subnetID"`
+	// ChainID on [SubnetID] that produces Warp messages.
+	ChainID ids.ID `json:"chainID"`
+	// Address on [ChainID] that produces Warp messages.
+	Address types.JSONByteSlice `json:"address"`
+	// BLSKeyPath is the path to the file containing the BLS secret key used to
+	// sign Warp messages.
+	BLSKeyPath string `json:"blsKeyPath"`
+}
+
+// LoadExampleConfig reads the JSON encoded config at [path]. If [path] is
+// empty, no file is read. Any value provided by the environment variables
+// prefixed with EXAMPLE_ overrides the value from the file.
+//
+// If [URI] is not provided, it defaults to [LocalAPIURI].
+func LoadExampleConfig(path string) (*ExampleConfig, error) {
+	config := &ExampleConfig{
+		URI: LocalAPIURI,
+	}
+	if path != "" {
+		configBytes, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(configBytes, config); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidExampleConfig, err)
+		}
+	}
+
+	if uri, ok := os.LookupEnv(ExampleURIEnvVar); ok {
+		config.URI = uri
+	}
+	if path, ok := os.LookupEnv(ExampleBLSKeyPathEnvVar); ok {
+		config.BLSKeyPath = path
+	}
+	if privateKey, ok := os.LookupEnv(ExamplePrivateKeyEnvVar); ok {
+		config.PrivateKey = new(secp256k1.PrivateKey)
+		if err := unmarshalEnv(ExamplePrivateKeyEnvVar, privateKey, config.PrivateKey); err != nil {
+			return nil, err
+		}
+	}
+	if subnetID, ok := os.LookupEnv(ExampleSubnetIDEnvVar); ok {
+		if err := unmarshalEnv(ExampleSubnetIDEnvVar, subnetID, &config.SubnetID); err != nil {
+			return nil, err
+		}
+	}
+	if chainID, ok := os.LookupEnv(ExampleChainIDEnvVar); ok {
+		if err := unmarshalEnv(ExampleChainIDEnvVar, chainID, &config.ChainID); err != nil {
+			return nil, err
+		}
+	}
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// unmarshalEnv parses [value], which is the unquoted JSON string of the
+// environment variable [name], into [v].
+func unmarshalEnv(name string, value string, v json.Unmarshaler) error {
+	if err := v.UnmarshalJSON([]byte(strconv.Quote(value))); err != nil {
+		return fmt.Errorf("%w: failed to parse %s: %w", ErrInvalidExampleConfig, name, err)
+	}
+	return nil
+}
+
+// Verify returns an error if a required value is missing.
+func (c *ExampleConfig) Verify() error {
+	switch {
+	case c.URI == "":
+		return fmt.Errorf("%w: missing uri", ErrInvalidExampleConfig)
+	case c.PrivateKey == nil:
+		return fmt.Errorf("%w: missing privateKey", ErrInvalidExampleConfig)
+	case c.SubnetID == ids.Empty:
+		return fmt.Errorf("%w: missing subnetID", ErrInvalidExampleConfig)
+	case c.ChainID == ids.Empty:
+		return fmt.Errorf("%w: missing chainID", ErrInvalidExampleConfig)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+)
+
+func TestLoadExampleConfig(t *testing.T) {
+	var (
+		key        = secp256k1.TestKeys()[0]
+		subnetID   = ids.GenerateTestID()
+		chainID    = ids.GenerateTestID()
+		envChainID = ids.GenerateTestID()
+	)
+
+	tests := []struct {
+		name           string
+		file           string
+		env            map[string]string
+		expectedConfig *ExampleConfig
+		expectedErr    error
+	}{
+		{
+			name: "file",
+			file: `{
+				"uri": "http://127.0.0.1:9650",
+				"privateKey": "` + key.String() + `",
+				"subnetID": "` + subnetID.String() + `",
+				"chainID": "` + chainID.String() + `",
+				"address": "0x0102",
+				"blsKeyPath": "signer.key"
+			}`,
+			expectedConfig: &ExampleConfig{
+				URI:        "http://127.0.0.1:9650",
+				PrivateKey: key,
+				SubnetID:   subnetID,
+				ChainID:    chainID,
+				Address:    []byte{0x01, 0x02},
+				BLSKeyPath: "signer.key",
+			},
+		},
+		{
+			name: "env overrides file",
+			file: `{
+				"privateKey": "` + key.String() + `",
+				"subnetID": "` + subnetID.String() + `",
+				"chainID": "` + chainID.String() + `"
+			}`,
+			env: map[string]string{
+				ExampleURIEnvVar:        FujiAPIURI,
+				ExampleChainIDEnvVar:    envChainID.String(),
+				ExampleBLSKeyPathEnvVar: "env.key",
+			},
+			expectedConfig: &ExampleConfig{
+				URI:        FujiAPIURI,
+				PrivateKey: key,
+				SubnetID:   subnetID,
+				ChainID:    envChainID,
+				BLSKeyPath: "env.key",
+			},
+		},
+		{
+			name: "env only",
+			env: map[string]string{
+				ExamplePrivateKeyEnvVar: key.String(),
+				ExampleSubnetIDEnvVar:   subnetID.String(),
+				ExampleChainIDEnvVar:    chainID.String(),
+			},
+			expectedConfig: &ExampleConfig{
+				URI:        LocalAPIURI,
+				PrivateKey: key,
+				SubnetID:   subnetID,
+				ChainID:    chainID,
+			},
+		},
+		{
+			name:        "invalid json",
+			file:        `{`,
+			expectedErr: ErrInvalidExampleConfig,
+		},
+		{
+			name: "invalid env",
+			env: map[string]string{
+				ExampleSubnetIDEnvVar: "not an ID",
+			},
+			expectedErr: ErrInvalidExampleConfig,
+		},
+		{
+			name: "missing private key",
+			env: map[string]string{
+				ExampleSubnetIDEnvVar: subnetID.String(),
+				ExampleChainIDEnvVar:  chainID.String(),
+			},
+			expectedErr: ErrInvalidExampleConfig,
+		},
+		{
+			name: "missing chain ID",
+			env: map[string]string{
+				ExamplePrivateKeyEnvVar: key.String(),
+				ExampleSubnetIDEnvVar:   subnetID.String(),
+			},
+			expectedErr: ErrInvalidExampleConfig,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			for _, name := range []string{
+				ExampleURIEnvVar,
+				ExamplePrivateKeyEnvVar,
+				ExampleSubnetIDEnvVar,
+				ExampleChainIDEnvVar,
+				ExampleBLSKeyPathEnvVar,
+			} {
+				t.Setenv(name, "")
+				require.NoError(os.Unsetenv(name))
+			}
+			for name, value := range test.env {
+				t.Setenv(name, value)
+			}
+
+			var path string
+			if test.file != "" {
+				path = filepath.Join(t.TempDir(), "config.json")
+				require.NoError(os.WriteFile(path, []byte(test.file), 0o600))
+			}
+
+			config, err := LoadExampleConfig(path)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.Equal(test.expectedConfig, config)
+		})
+	}
+}
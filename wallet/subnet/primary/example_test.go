@@ -31,7 +31,7 @@ func ExampleWallet() {
 		ctx,
 		LocalAPIURI,
 		kc,
-		kc,
+		nil, // The C-chain is not used
 		WalletConfig{},
 	)
 	if err != nil {
@@ -9,7 +9,6 @@ import (
 	"log"
 	"time"
 
-	"github.com/ava-labs/avalanchego/api/info"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/units"
@@ -34,27 +33,61 @@ func main() {
 	}
 
 	ctx := context.Background()
-	infoClient := info.NewClient(uri)
 
+	// Converting a subnet that can not be converted would only waste the fee.
+	if err := primary.AssertConvertible(ctx, uri, subnetID); err != nil {
+		log.Fatalf("failed to verify subnet %s is convertible: %s\n", subnetID, err)
+	}
+
+	// Additional validators can be added by including the URIs of the nodes
+	// that will run them.
 	nodeInfoStartTime := time.Now()
-	nodeID, nodePoP, err := infoClient.GetNodeID(ctx)
+	validators, err := primary.BuildConvertValidators(ctx, []primary.ConvertValidatorSpec{
+		{
+			URI:                   uri,
+			Weight:                weight,
+			Balance:               units.Avax,
+			RemainingBalanceOwner: message.PChainOwner{},
+			DeactivationOwner:     message.PChainOwner{},
+		},
+	})
+	if err != nil {
+		log.Fatalf("failed to build validator set: %s\n", err)
+	}
+	log.Printf("fetched %d node IDs in %s\n", len(validators), time.Since(nodeInfoStartTime))
+
+	totalWeight, err := txs.TotalWeight(validators)
 	if err != nil {
-		log.Fatalf("failed to fetch node IDs: %s\n", err)
+		log.Fatalf("failed to calculate total weight: %s\n", err)
+	}
+	log.Printf("converting with a total weight of %d\n", totalWeight)
+
+	// A validator with more than a third of the weight is able to halt the L1
+	// on its own.
+	for i, vdr := range validators {
+		if vdr.Weight > totalWeight/3 {
+			log.Printf("warning: validator %d has %d of the %d total weight\n",
+				i,
+				vdr.Weight,
+				totalWeight,
+			)
+		}
 	}
-	log.Printf("fetched node ID %s in %s\n", nodeID, time.Since(nodeInfoStartTime))
 
 	validationID := subnetID.Append(0)
+	validatorData := make([]message.SubnetToL1ConversionValidatorData, len(validators))
+	for i, vdr := range validators {
+		validatorData[i] = message.SubnetToL1ConversionValidatorData{
+			NodeID:       vdr.NodeID,
+			BLSPublicKey: vdr.Signer.PublicKey,
+			Weight:       vdr.Weight,
+		}
+	}
 	conversionID, err := message.SubnetToL1ConversionID(message.SubnetToL1ConversionData{
 		SubnetID:       subnetID,
 		ManagerChainID: chainID,
 		ManagerAddress: address,
-		Validators: []message.SubnetToL1ConversionValidatorData{
-			{
-				NodeID:       nodeID.Bytes(),
-				BLSPublicKey: nodePoP.PublicKey,
-				Weight:       weight,
-			},
-		},
+		Validators:     validatorData,
 	})
 	if err != nil {
 		log.Fatalf("failed to calculate conversionID: %s\n", err)
@@ -81,16 +114,7 @@ func main() {
 		subnetID,
 		chainID,
 		address,
-		[]*txs.ConvertSubnetToL1Validator{
-			{
-				NodeID:                nodeID.Bytes(),
-				Weight:                weight,
-				Balance:               units.Avax,
-				Signer:                *nodePoP,
-				RemainingBalanceOwner: message.PChainOwner{},
-				DeactivationOwner:     message.PChainOwner{},
-			},
-		},
+		validators,
 	)
 	if err != nil {
 		log.Fatalf("failed to issue subnet conversion transaction: %s\n", err)
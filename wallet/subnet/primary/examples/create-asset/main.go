@@ -31,7 +31,7 @@ func main() {
 		ctx,
 		uri,
 		kc,
-		kc,
+		nil, // The C-chain is not used
 		primary.WalletConfig{},
 	)
 	if err != nil {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 	"github.com/ava-labs/avalanchego/wallet/subnet/primary"
 )
@@ -38,6 +39,16 @@ func main() {
 	}
 	log.Printf("synced wallet in %s\n", time.Since(walletSyncStartTime))
 
+	// The disable tx can only be authorized by the deactivation owner of the
+	// validator, which may have been set to keys other than [kc].
+	canDisable, err := primary.CanDisable(ctx, platformvm.NewClient(uri), kc, validationID)
+	if err != nil {
+		log.Fatalf("failed to check deactivation owner: %s\n", err)
+	}
+	if !canDisable {
+		log.Fatalf("%s can not be disabled by the provided key\n", validationID)
+	}
+
 	disableL1ValidatorStartTime := time.Now()
 	disableL1ValidatorTx, err := wallet.IssueDisableL1ValidatorTx(
 		validationID,
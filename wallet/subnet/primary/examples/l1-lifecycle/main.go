@@ -0,0 +1,305 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary"
+)
+
+// This example walks through the lifecycle of an L1 validator:
+//
+//  1. The subnet is converted to an L1 with the node at [uri] as its only
+//     validator.
+//  2. A new validator is registered.
+//  3. The weight of the new validator is increased.
+//  4. The balance of the new validator is increased.
+//  5. The new validator is disabled.
+//
+// The Warp messages that would normally be produced by the L1's validator
+// manager are signed with the BLS key of the node at [uri], which is the
+// L1 validator set after the conversion.
+func main() {
+	key := genesis.EWOQKey
+	uri := primary.LocalAPIURI
+	kc := secp256k1fx.NewKeychain(key)
+	subnetID := ids.FromStringOrPanic("2DeHa7Qb6sufPkmQcFWG2uCd4pBPv9WB6dkzroiMQhd1NSRtof")
+	chainID := ids.FromStringOrPanic("E8nTR9TtRwfkS7XFjTYUYHENQ91mkPMtDUwwCeu7rNgBBtkqu")
+	address := []byte{}
+	// File containing the BLS secret key of the node at [uri].
+	blsSKPath := "signer.key"
+	convertedWeight := units.Schmeckle
+	newValidatorNodeIDStr := "NodeID-MFrZFVCXPv5iCn6M9K6XduxGTYp891xXZ"
+	newValidatorWeight := uint64(1)
+	newValidatorIncreasedWeight := uint64(2)
+	newValidatorBalance := units.Avax
+	balanceIncrease := units.Avax
+
+	newValidatorNodeID, err := ids.NodeIDFromString(newValidatorNodeIDStr)
+	if err != nil {
+		log.Fatalf("failed to parse node ID: %s\n", err)
+	}
+
+	sk, err := bls.SecretKeyFromFile(blsSKPath)
+	if err != nil {
+		log.Fatalf("failed to read secret key: %s\n", err)
+	}
+
+	// The new validator's BLS key is generated locally. A real validator would
+	// provide its proof of possession.
+	newValidatorSK, err := bls.NewSigner()
+	if err != nil {
+		log.Fatalf("failed to generate secret key: %s\n", err)
+	}
+	newValidatorPoP := signer.NewProofOfPossession(newValidatorSK)
+
+	ctx := context.Background()
+	infoClient := info.NewClient(uri)
+
+	nodeInfoStartTime := time.Now()
+	nodeID, nodePoP, err := infoClient.GetNodeID(ctx)
+	if err != nil {
+		log.Fatalf("failed to fetch node IDs: %s\n", err)
+	}
+	log.Printf("fetched node ID %s in %s\n", nodeID, time.Since(nodeInfoStartTime))
+
+	// MakePWallet fetches the available UTXOs owned by [kc] on the P-chain that
+	// [uri] is hosting and registers [subnetID].
+	walletSyncStartTime := time.Now()
+	wallet, err := primary.MakePWallet(
+		ctx,
+		uri,
+		kc,
+		primary.WalletConfig{
+			SubnetIDs: []ids.ID{subnetID},
+		},
+	)
+	if err != nil {
+		log.Fatalf("failed to initialize wallet: %s\n", err)
+	}
+	log.Printf("synced wallet in %s\n", time.Since(walletSyncStartTime))
+
+	// Get the chain context
+	context := wallet.Builder().Context()
+
+	// The remaining balance and the ability to disable the validators are given
+	// to [key].
+	owner := message.PChainOwner{
+		Threshold: 1,
+		Addresses: []ids.ShortID{key.Address()},
+	}
+
+	convertSubnetToL1StartTime := time.Now()
+	convertSubnetToL1Tx, err := wallet.IssueConvertSubnetToL1Tx(
+		subnetID,
+		chainID,
+		address,
+		[]*txs.ConvertSubnetToL1Validator{
+			{
+				NodeID:                nodeID.Bytes(),
+				Weight:                convertedWeight,
+				Balance:               units.Avax,
+				Signer:                *nodePoP,
+				RemainingBalanceOwner: owner,
+				DeactivationOwner:     owner,
+			},
+		},
+	)
+	if err != nil {
+		log.Fatalf("failed to issue subnet conversion transaction: %s\n", err)
+	}
+	log.Printf("converted subnet %s with txID %s and validationID %s in %s\n",
+		subnetID,
+		convertSubnetToL1Tx.ID(),
+		subnetID.Append(0),
+		time.Since(convertSubnetToL1StartTime),
+	)
+
+	// This message will expire in 5 minutes
+	expiry, err := message.ExpiryFromDeadline(time.Now().Add(5 * time.Minute))
+	if err != nil {
+		log.Fatalf("failed to calculate expiry: %s\n", err)
+	}
+	registerL1Validator, err := message.NewRegisterL1Validator(
+		subnetID,
+		newValidatorNodeID,
+		newValidatorPoP.PublicKey,
+		expiry,
+		message.L1ValidatorOwners{
+			RemainingBalanceOwner: owner,
+			DeactivationOwner:     owner,
+		},
+		newValidatorWeight,
+	)
+	if err != nil {
+		log.Fatalf("failed to create RegisterL1Validator message: %s\n", err)
+	}
+
+	// The node at [uri] is currently the only L1 validator.
+	registerL1ValidatorWarp, err := signWarpMessage(
+		context.NetworkID,
+		chainID,
+		address,
+		registerL1Validator.Bytes(),
+		sk,
+		0,
+	)
+	if err != nil {
+		log.Fatalf("failed to create RegisterL1Validator Warp message: %s\n", err)
+	}
+
+	registerL1ValidatorStartTime := time.Now()
+	registerL1ValidatorTx, err := wallet.IssueRegisterL1ValidatorTx(
+		newValidatorBalance,
+		newValidatorPoP.ProofOfPossession,
+		registerL1ValidatorWarp.Bytes(),
+	)
+	if err != nil {
+		log.Fatalf("failed to issue register L1 validator transaction: %s\n", err)
+	}
+
+	validationID := registerL1Validator.ValidationID()
+	log.Printf("registered %s with txID %s and validationID %s in %s\n",
+		newValidatorNodeID,
+		registerL1ValidatorTx.ID(),
+		validationID,
+		time.Since(registerL1ValidatorStartTime),
+	)
+
+	l1ValidatorWeight, err := message.NewL1ValidatorWeight(
+		validationID,
+		1,
+		newValidatorIncreasedWeight,
+	)
+	if err != nil {
+		log.Fatalf("failed to create L1ValidatorWeight message: %s\n", err)
+	}
+
+	// The L1 validator set now contains both validators, so the index of the
+	// node at [uri] in the canonical ordering must be calculated.
+	signerIndex := 0
+	if bytes.Compare(
+		bls.PublicKeyToUncompressedBytes(newValidatorSK.PublicKey()),
+		bls.PublicKeyToUncompressedBytes(sk.PublicKey()),
+	) < 0 {
+		signerIndex = 1
+	}
+
+	l1ValidatorWeightWarp, err := signWarpMessage(
+		context.NetworkID,
+		chainID,
+		address,
+		l1ValidatorWeight.Bytes(),
+		sk,
+		signerIndex,
+	)
+	// The secret key is no longer needed, so it is erased from memory. A
+	// deferred Clear would not run if the example exits through log.Fatalf.
+	sk.Clear()
+	if err != nil {
+		log.Fatalf("failed to create L1ValidatorWeight Warp message: %s\n", err)
+	}
+
+	setWeightStartTime := time.Now()
+	setWeightTx, err := wallet.IssueSetL1ValidatorWeightTx(
+		l1ValidatorWeightWarp.Bytes(),
+	)
+	if err != nil {
+		log.Fatalf("failed to issue set L1 validator weight transaction: %s\n", err)
+	}
+	log.Printf("set weight of validationID %s to %d with txID %s in %s\n",
+		validationID,
+		newValidatorIncreasedWeight,
+		setWeightTx.ID(),
+		time.Since(setWeightStartTime),
+	)
+
+	increaseL1ValidatorBalanceStartTime := time.Now()
+	increaseL1ValidatorBalanceTx, err := wallet.IssueIncreaseL1ValidatorBalanceTx(
+		validationID,
+		balanceIncrease,
+	)
+	if err != nil {
+		log.Fatalf("failed to issue increase balance transaction: %s\n", err)
+	}
+	log.Printf("increased balance of validationID %s by %d with txID %s in %s\n",
+		validationID,
+		balanceIncrease,
+		increaseL1ValidatorBalanceTx.ID(),
+		time.Since(increaseL1ValidatorBalanceStartTime),
+	)
+
+	// The wallet learned the deactivation owner of [validationID] when the
+	// register L1 validator transaction was accepted.
+	disableL1ValidatorStartTime := time.Now()
+	disableL1ValidatorTx, err := wallet.IssueDisableL1ValidatorTx(
+		validationID,
+	)
+	if err != nil {
+		log.Fatalf("failed to issue disable L1 validator transaction: %s\n", err)
+	}
+	log.Printf("disabled validationID %s with txID %s in %s\n",
+		validationID,
+		disableL1ValidatorTx.ID(),
+		time.Since(disableL1ValidatorStartTime),
+	)
+}
+
+// signWarpMessage wraps [addressedCallPayload] in an AddressedCall from
+// [address] on [chainID] and signs it with [sk] as the validator at
+// [signerIndex] in the canonical validator set.
+func signWarpMessage(
+	networkID uint32,
+	chainID ids.ID,
+	address []byte,
+	addressedCallPayload []byte,
+	sk bls.Signer,
+	signerIndex int,
+) (*warp.Message, error) {
+	addressedCall, err := payload.NewAddressedCall(
+		address,
+		addressedCallPayload,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	unsignedWarp, err := warp.NewUnsignedMessage(
+		networkID,
+		chainID,
+		addressedCall.Bytes(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := bls.Sign(sk, unsignedWarp.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return warp.NewMessage(
+		unsignedWarp,
+		&warp.BitSetSignature{
+			Signers:   set.NewBits(signerIndex).Bytes(),
+			Signature: ([bls.SignatureLen]byte)(bls.SignatureToBytes(sig)),
+		},
+	)
+}
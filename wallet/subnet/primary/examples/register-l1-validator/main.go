@@ -5,46 +5,59 @@ package main
 
 import (
 	"context"
-	"encoding/hex"
 	"encoding/json"
 	"log"
+	"net/http"
 	"time"
 
 	"github.com/ava-labs/avalanchego/api/info"
-	"github.com/ava-labs/avalanchego/genesis"
-	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/rpc"
 	"github.com/ava-labs/avalanchego/utils/set"
-	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
 	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/p/signer"
 	"github.com/ava-labs/avalanchego/wallet/subnet/primary"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
 )
 
 func main() {
-	key := genesis.EWOQKey
-	uri := primary.LocalAPIURI
-	kc := secp256k1fx.NewKeychain(key)
-	subnetID := ids.FromStringOrPanic("2DeHa7Qb6sufPkmQcFWG2uCd4pBPv9WB6dkzroiMQhd1NSRtof")
-	chainID := ids.FromStringOrPanic("2BMFrJ9xeh5JdwZEx6uuFcjfZC2SV2hdbMT8ee5HrvjtfJb5br")
-	address := []byte{}
+	// JSON file containing the URI of the node, the funded private key, the
+	// subnet and chain that produce the Warp message, and the path to the file
+	// containing either the raw bytes or the hex encoding of the BLS secret key
+	// used to sign the Warp message. Any value can also be provided through the
+	// EXAMPLE_* environment variables.
+	configPath := "config.json"
 	weight := uint64(1)
-	blsSKHex := "3f783929b295f16cd1172396acb23b20eed057b9afb1caa419e9915f92860b35"
+	// Pay 10% more than the currently required fee so that the transaction is
+	// still accepted if the fee increases before it is included.
+	feeMultiplierNumerator := uint64(11)
+	feeMultiplierDenominator := uint64(10)
+	// If true, the signed transaction is printed rather than issued so that it
+	// can be submitted through another broadcaster, such as an explorer.
+	dryRun := false
 
-	blsSKBytes, err := hex.DecodeString(blsSKHex)
+	config, err := primary.LoadExampleConfig(configPath)
 	if err != nil {
-		log.Fatalf("failed to decode secret key: %s\n", err)
-	}
-
-	sk, err := bls.SecretKeyFromBytes(blsSKBytes)
-	if err != nil {
-		log.Fatalf("failed to parse secret key: %s\n", err)
+		log.Fatalf("failed to load config: %s\n", err)
 	}
+	key := config.PrivateKey
+	uri := config.URI
+	kc := secp256k1fx.NewKeychain(key)
+	subnetID := config.SubnetID
+	chainID := config.ChainID
+	address := []byte(config.Address)
 
 	ctx := context.Background()
-	infoClient := info.NewClient(uri)
+
+	// The same HTTP client is used by the info client and the wallet so that
+	// connections to the node are reused.
+	httpClient := &http.Client{}
+	infoClient := info.NewClientWithOptions(uri, rpc.WithHTTPClient(httpClient))
+	pClient := platformvm.NewClientWithOptions(uri, rpc.WithHTTPClient(httpClient))
 
 	nodeInfoStartTime := time.Now()
 	nodeID, nodePoP, err := infoClient.GetNodeID(ctx)
@@ -60,24 +73,84 @@ func main() {
 		ctx,
 		uri,
 		kc,
-		primary.WalletConfig{},
+		primary.WalletConfig{
+			HTTPClient: httpClient,
+		},
 	)
 	if err != nil {
 		log.Fatalf("failed to initialize wallet: %s\n", err)
 	}
 	log.Printf("synced wallet in %s\n", time.Since(walletSyncStartTime))
 
+	// A previously issued registration that is still in the mempool would
+	// conflict with the UTXOs spent by a new registration.
+	pendingTxIDs, err := wallet.PendingFromKey(ctx)
+	if err != nil {
+		log.Fatalf("failed to fetch pending transactions: %s\n", err)
+	}
+	if len(pendingTxIDs) > 0 {
+		log.Printf("found pending transactions from the key: %s\n", pendingTxIDs)
+	}
+
 	// Get the chain context
 	context := wallet.Builder().Context()
 
-	expiry := uint64(time.Now().Add(5 * time.Minute).Unix()) // This message will expire in 5 minutes
+	// The remaining balance and the ability to disable the validator are given
+	// to [key].
+	owner, err := message.DefaultDisableOwner(kc)
+	if err != nil {
+		log.Fatalf("failed to create owner: %s\n", err)
+	}
+
+	// This message will expire in 5 minutes, according to the clock of the
+	// node, so that skew of the local clock doesn't invalidate the message.
+	expiry, err := message.ExpiryFromNodeClock(ctx, infoClient, 5*time.Minute)
+	if err != nil {
+		log.Fatalf("failed to calculate expiry: %s\n", err)
+	}
+
+	balance, err := wallet.MinInitialL1Balance()
+	if err != nil {
+		log.Fatalf("failed to calculate initial balance: %s\n", err)
+	}
+	log.Printf("funding the new L1 validator with %d nAVAX\n", balance)
+
+	// Fail before signing anything if the registration would be rejected, for
+	// example because the node is on another network, Etna has not activated,
+	// or the wallet can not afford the fee.
+	feeMultiplier := common.WithFeeMultiplier(feeMultiplierNumerator, feeMultiplierDenominator)
+	err = primary.PreflightRegistration(
+		ctx,
+		infoClient,
+		pClient,
+		wallet,
+		&primary.RegistrationParams{
+			SubnetID:              subnetID,
+			ChainID:               chainID,
+			Address:               address,
+			NodeID:                nodeID,
+			ProofOfPossession:     nodePoP,
+			Expiry:                expiry,
+			RemainingBalanceOwner: owner,
+			DisableOwner:          owner,
+			Weight:                weight,
+			Balance:               balance,
+		},
+		feeMultiplier,
+	)
+	if err != nil {
+		log.Fatalf("failed registration preflight: %s\n", err)
+	}
+
 	addressedCallPayload, err := message.NewRegisterL1Validator(
 		subnetID,
 		nodeID,
 		nodePoP.PublicKey,
 		expiry,
-		message.PChainOwner{},
-		message.PChainOwner{},
+		message.L1ValidatorOwners{
+			RemainingBalanceOwner: owner,
+			DeactivationOwner:     owner,
+		},
 		weight,
 	)
 	if err != nil {
@@ -106,16 +179,27 @@ func main() {
 		log.Fatalf("failed to create unsigned Warp message: %s\n", err)
 	}
 
-	// This example assumes that the hard-coded BLS key is for the first
+	// This example assumes that the provided BLS key is for the first
 	// validator in the signature bit-set.
 	signers := set.NewBits(0)
 
+	// The secret key is only read once it is needed, and is erased from
+	// memory as soon as the message is signed. A deferred Clear would not run
+	// if the example exits through log.Fatalf.
+	sk, err := bls.SecretKeyFromFile(config.BLSKeyPath)
+	if err != nil {
+		log.Fatalf("failed to read secret key: %s\n", err)
+	}
 	unsignedBytes := unsignedWarp.Bytes()
-	sig := sk.Sign(unsignedBytes)
+	sig, err := bls.Sign(sk, unsignedBytes)
+	sk.Clear()
+	if err != nil {
+		log.Fatalf("failed to sign Warp message: %s\n", err)
+	}
 	sigBytes := [bls.SignatureLen]byte{}
 	copy(sigBytes[:], bls.SignatureToBytes(sig))
 
-	warp, err := warp.NewMessage(
+	warpMessage, err := warp.NewMessage(
 		unsignedWarp,
 		&warp.BitSetSignature{
 			Signers:   signers.Bytes(),
@@ -126,11 +210,54 @@ func main() {
 		log.Fatalf("failed to create Warp message: %s\n", err)
 	}
 
+	// Abort before paying any fees if the message was signed for a different
+	// network than the one the wallet issues to.
+	if err := warp.VerifyNetworkID(warpMessage, context.NetworkID); err != nil {
+		log.Fatalf("failed to verify Warp message: %s\n", err)
+	}
+
+	if dryRun {
+		utx, err := wallet.Builder().NewRegisterL1ValidatorTx(
+			balance,
+			nodePoP.ProofOfPossession,
+			warpMessage.Bytes(),
+			feeMultiplier,
+		)
+		if err != nil {
+			log.Fatalf("failed to build register L1 validator transaction: %s\n", err)
+		}
+		tx, err := signer.SignUnsigned(ctx, wallet.Signer(), utx)
+		if err != nil {
+			log.Fatalf("failed to sign register L1 validator transaction: %s\n", err)
+		}
+		txHex, err := tx.HexString()
+		if err != nil {
+			log.Fatalf("failed to encode register L1 validator transaction: %s\n", err)
+		}
+		txCB58, err := tx.CB58String()
+		if err != nil {
+			log.Fatalf("failed to encode register L1 validator transaction: %s\n", err)
+		}
+		log.Printf("signed register L1 validator transaction %s\nhex: %s\ncb58: %s\n", tx.ID(), txHex, txCB58)
+		return
+	}
+
+	// Let the operator know how long issuance is expected to block for. The
+	// estimate is only informational, so it is skipped if it fails, such as
+	// on a new network that has not accepted any blocks.
+	acceptanceTime, err := primary.EstimateAcceptanceTime(ctx, pClient)
+	if err != nil {
+		log.Printf("failed to estimate acceptance time: %s\n", err)
+	} else {
+		log.Printf("expecting confirmation in ~%s\n", acceptanceTime)
+	}
+
 	registerL1ValidatorStartTime := time.Now()
 	registerL1ValidatorTx, err := wallet.IssueRegisterL1ValidatorTx(
-		units.Avax,
+		balance,
 		nodePoP.ProofOfPossession,
-		warp.Bytes(),
+		warpMessage.Bytes(),
+		feeMultiplier,
 	)
 	if err != nil {
 		log.Fatalf("failed to issue register L1 validator transaction: %s\n", err)
@@ -0,0 +1,209 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/c"
+
+	pbuilder "github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	xbuilder "github.com/ava-labs/avalanchego/wallet/chain/x/builder"
+	walletcommon "github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	_ c.EthKeychain = (*combinedEthKeychain)(nil)
+
+	ErrNoWallets          = errors.New("no wallets provided")
+	ErrWalletNotMergeable = errors.New("wallet is not mergeable")
+	ErrContextMismatch    = errors.New("context mismatch")
+)
+
+// MergeWallets returns a wallet that can spend the UTXOs, and sign with the
+// keys, of all the provided [wallets]. This allows coin selection to draw from
+// every key without syncing each key separately.
+//
+// Only wallets created by [MakeWallet] can be merged, and all the [wallets]
+// must have been created against the same network. The API clients of the
+// first wallet are used to issue transactions. The fee parameters of the last
+// wallet are used, so [wallets] should be provided in the order that they were
+// created.
+//
+// The returned wallet maintains its own copy of the UTXOs, so the provided
+// [wallets] should not be used to issue transactions after they are merged.
+func MergeWallets(wallets ...*Wallet) (*Wallet, error) {
+	if len(wallets) == 0 {
+		return nil, ErrNoWallets
+	}
+	for i, w := range wallets {
+		if w.state == nil {
+			return nil, fmt.Errorf("%w: wallet %d was not created by MakeWallet",
+				ErrWalletNotMergeable,
+				i,
+			)
+		}
+	}
+
+	var (
+		first = wallets[0].state.avaxState
+		// The fee parameters change over time, so they are taken from the most
+		// recently created wallet.
+		latest = wallets[len(wallets)-1].state.avaxState
+		// The C-chain is only synced by wallets that configured a C-chain
+		// wallet, so the C-chain state is taken from those wallets.
+		firstC      *AVAXState
+		latestC     *AVAXState
+		firstCIndex int
+	)
+	for i, w := range wallets {
+		avaxState := w.state.avaxState
+		switch {
+		case !samePNetwork(avaxState.PCTX, first.PCTX):
+			return nil, fmt.Errorf("%w: P-chain network of wallet %d differs from wallet 0",
+				ErrContextMismatch,
+				i,
+			)
+		case !sameXNetwork(avaxState.XCTX, first.XCTX):
+			return nil, fmt.Errorf("%w: X-chain network of wallet %d differs from wallet 0",
+				ErrContextMismatch,
+				i,
+			)
+		case avaxState.CCTX == nil:
+			continue
+		case firstC == nil:
+			firstC = avaxState
+			firstCIndex = i
+		case !sameCNetwork(avaxState.CCTX, firstC.CCTX):
+			return nil, fmt.Errorf("%w: C-chain network of wallet %d differs from wallet %d",
+				ErrContextMismatch,
+				i,
+				firstCIndex,
+			)
+		}
+		latestC = avaxState
+	}
+
+	var (
+		ctx      = context.Background()
+		utxos    = walletcommon.NewUTXOs()
+		chainIDs = []ids.ID{
+			constants.PlatformChainID,
+			first.XCTX.BlockchainID,
+		}
+		mergedAVAXState = &AVAXState{
+			PClient: first.PClient,
+			PCTX:    latest.PCTX,
+			XClient: first.XClient,
+			XCTX:    latest.XCTX,
+			UTXOs:   utxos,
+		}
+		avaxKeychains = make([]keychain.Keychain, 0, len(wallets))
+		ethKeychain   = &combinedEthKeychain{}
+		ethState      *EthState
+		owners        = make(map[ids.ID]fx.Owner)
+	)
+	if firstC != nil {
+		chainIDs = append(chainIDs, firstC.CCTX.BlockchainID)
+		mergedAVAXState.CClient = firstC.CClient
+		mergedAVAXState.CCTX = latestC.CCTX
+	}
+	for _, w := range wallets {
+		state := w.state
+		for _, sourceChainID := range chainIDs {
+			for _, destinationChainID := range chainIDs {
+				chainUTXOs, err := state.avaxState.UTXOs.UTXOs(ctx, sourceChainID, destinationChainID)
+				if err != nil {
+					return nil, err
+				}
+				for _, utxo := range chainUTXOs {
+					if err := utxos.AddUTXO(ctx, sourceChainID, destinationChainID, utxo); err != nil {
+						return nil, err
+					}
+				}
+			}
+		}
+
+		avaxKeychains = append(avaxKeychains, state.avaxKeychain)
+		if state.ethKeychain != nil && state.ethState != nil {
+			ethKeychain.keychains = append(ethKeychain.keychains, state.ethKeychain)
+			if ethState == nil {
+				ethState = &EthState{
+					Client:   state.ethState.Client,
+					Accounts: make(map[ethcommon.Address]*c.Account),
+				}
+			}
+			for addr, account := range state.ethState.Accounts {
+				ethState.Accounts[addr] = account
+			}
+		}
+
+		for ownerID, owner := range state.owners {
+			owners[ownerID] = owner
+		}
+	}
+
+	merged := &walletState{
+		avaxState:    mergedAVAXState,
+		avaxKeychain: keychain.Combine(avaxKeychains...),
+		owners:       owners,
+	}
+	if ethState != nil {
+		merged.ethState = ethState
+		merged.ethKeychain = ethKeychain
+	}
+	return newWalletFromState(merged), nil
+}
+
+// samePNetwork returns true if [a] and [b] describe the same P-chain. The fee
+// parameters are ignored because they change over time.
+func samePNetwork(a, b *pbuilder.Context) bool {
+	return a.NetworkID == b.NetworkID && a.AVAXAssetID == b.AVAXAssetID
+}
+
+// sameXNetwork returns true if [a] and [b] describe the same X-chain. The fee
+// parameters are ignored because they change over time.
+func sameXNetwork(a, b *xbuilder.Context) bool {
+	return a.NetworkID == b.NetworkID &&
+		a.BlockchainID == b.BlockchainID &&
+		a.AVAXAssetID == b.AVAXAssetID
+}
+
+// sameCNetwork returns true if [a] and [b] describe the same C-chain.
+func sameCNetwork(a, b *c.Context) bool {
+	return a.NetworkID == b.NetworkID &&
+		a.BlockchainID == b.BlockchainID &&
+		a.AVAXAssetID == b.AVAXAssetID
+}
+
+// combinedEthKeychain returns the signer of the first keychain that is able to
+// sign for an address.
+type combinedEthKeychain struct {
+	keychains []c.EthKeychain
+}
+
+func (k *combinedEthKeychain) GetEth(addr ethcommon.Address) (keychain.Signer, bool) {
+	for _, kc := range k.keychains {
+		if signer, ok := kc.GetEth(addr); ok {
+			return signer, true
+		}
+	}
+	return nil, false
+}
+
+func (k *combinedEthKeychain) EthAddresses() set.Set[ethcommon.Address] {
+	var addrs set.Set[ethcommon.Address]
+	for _, kc := range k.keychains {
+		addrs.Union(kc.EthAddresses())
+	}
+	return addrs
+}
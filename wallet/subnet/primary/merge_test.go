@@ -0,0 +1,211 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/c"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+
+	pbuilder "github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	xbuilder "github.com/ava-labs/avalanchego/wallet/chain/x/builder"
+)
+
+type issueTxClient struct {
+	platformvm.Client
+
+	issued [][]byte
+}
+
+func (c *issueTxClient) IssueTx(_ context.Context, txBytes []byte, _ ...rpc.Option) (ids.ID, error) {
+	c.issued = append(c.issued, txBytes)
+	return ids.Empty, nil
+}
+
+func TestMergeWallets(t *testing.T) {
+	var (
+		avaxAssetID = ids.GenerateTestID()
+		pCTX        = &pbuilder.Context{
+			NetworkID:   constants.UnitTestID,
+			AVAXAssetID: avaxAssetID,
+			ComplexityWeights: gas.Dimensions{
+				gas.Bandwidth: 1,
+				gas.DBRead:    10,
+				gas.DBWrite:   100,
+				gas.Compute:   1000,
+			},
+			GasPrice: 1,
+		}
+		xCTX = &xbuilder.Context{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: ids.GenerateTestID(),
+			AVAXAssetID:  avaxAssetID,
+		}
+		cCTX = &c.Context{
+			NetworkID:    constants.UnitTestID,
+			BlockchainID: ids.GenerateTestID(),
+			AVAXAssetID:  avaxAssetID,
+		}
+		keys = secp256k1.TestKeys()[:2]
+	)
+
+	// newWallet returns a wallet that owns a single P-chain UTXO worth 1 AVAX
+	// that is spendable by [key].
+	newWallet := func(t *testing.T, key *secp256k1.PrivateKey, pClient platformvm.Client, pCTX *pbuilder.Context) *Wallet {
+		utxos := common.NewUTXOs()
+		utxo := &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID: ids.GenerateTestID(),
+			},
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: units.Avax,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{key.Address()},
+				},
+			},
+		}
+		require.NoError(t, utxos.AddUTXO(
+			context.Background(),
+			constants.PlatformChainID,
+			constants.PlatformChainID,
+			utxo,
+		))
+		return newWalletFromState(&walletState{
+			avaxState: &AVAXState{
+				PClient: pClient,
+				PCTX:    pCTX,
+				XCTX:    xCTX,
+				CCTX:    cCTX,
+				UTXOs:   utxos,
+			},
+			avaxKeychain: secp256k1fx.NewKeychain(key),
+		})
+	}
+
+	t.Run("funded by both wallets", func(t *testing.T) {
+		require := require.New(t)
+
+		client := &issueTxClient{}
+		merged, err := MergeWallets(
+			newWallet(t, keys[0], client, pCTX),
+			newWallet(t, keys[1], client, pCTX),
+		)
+		require.NoError(err)
+
+		balances, err := merged.P().Builder().GetBalance()
+		require.NoError(err)
+		require.Equal(map[ids.ID]uint64{avaxAssetID: 2 * units.Avax}, balances)
+
+		// Sending more than either wallet holds requires spending the UTXOs of
+		// both keys.
+		tx, err := merged.P().IssueBaseTx(
+			[]*avax.TransferableOutput{
+				{
+					Asset: avax.Asset{ID: avaxAssetID},
+					Out: &secp256k1fx.TransferOutput{
+						Amt: 3 * units.Avax / 2,
+						OutputOwners: secp256k1fx.OutputOwners{
+							Threshold: 1,
+							Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+						},
+					},
+				},
+			},
+			common.WithAssumeDecided(),
+		)
+		require.NoError(err)
+		require.Len(client.issued, 1)
+
+		baseTx := tx.Unsigned.(*txs.BaseTx)
+		require.Len(baseTx.Ins, 2)
+		require.Len(tx.Creds, 2)
+
+		signers := make([]ids.ShortID, len(tx.Creds))
+		for i, cred := range tx.Creds {
+			cred := cred.(*secp256k1fx.Credential)
+			require.Len(cred.Sigs, 1)
+
+			pk, err := secp256k1.RecoverPublicKey(tx.Unsigned.Bytes(), cred.Sigs[0][:])
+			require.NoError(err)
+			signers[i] = pk.Address()
+		}
+		require.ElementsMatch(
+			[]ids.ShortID{keys[0].Address(), keys[1].Address()},
+			signers,
+		)
+	})
+
+	t.Run("not mergeable", func(t *testing.T) {
+		_, err := MergeWallets(
+			newWallet(t, keys[0], nil, pCTX),
+			NewWallet(nil, nil, nil),
+		)
+		require.ErrorIs(t, err, ErrWalletNotMergeable)
+	})
+
+	t.Run("context mismatch", func(t *testing.T) {
+		otherPCTX := *pCTX
+		otherPCTX.NetworkID = constants.MainnetID
+
+		_, err := MergeWallets(
+			newWallet(t, keys[0], nil, pCTX),
+			newWallet(t, keys[1], nil, &otherPCTX),
+		)
+		require.ErrorIs(t, err, ErrContextMismatch)
+	})
+
+	t.Run("C-chain not configured", func(t *testing.T) {
+		require := require.New(t)
+
+		notConfigured := newWallet(t, keys[0], nil, pCTX)
+		notConfigured.state.avaxState.CCTX = nil
+
+		merged, err := MergeWallets(
+			notConfigured,
+			newWallet(t, keys[1], nil, pCTX),
+		)
+		require.NoError(err)
+		require.Equal(cCTX, merged.state.avaxState.CCTX)
+
+		merged, err = MergeWallets(notConfigured)
+		require.NoError(err)
+		require.Nil(merged.state.avaxState.CCTX)
+	})
+
+	t.Run("fee mismatch", func(t *testing.T) {
+		require := require.New(t)
+
+		latestPCTX := *pCTX
+		latestPCTX.GasPrice = 2 * pCTX.GasPrice
+		latestPCTX.ValidatorFeePrice = 3
+
+		merged, err := MergeWallets(
+			newWallet(t, keys[0], nil, pCTX),
+			newWallet(t, keys[1], nil, &latestPCTX),
+		)
+		require.NoError(err)
+		require.Equal(&latestPCTX, merged.P().Builder().Context())
+	})
+
+	t.Run("no wallets", func(t *testing.T) {
+		_, err := MergeWallets()
+		require.ErrorIs(t, err, ErrNoWallets)
+	})
+}
@@ -0,0 +1,191 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+
+	pbuilder "github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	pwallet "github.com/ava-labs/avalanchego/wallet/chain/p/wallet"
+)
+
+var (
+	ErrNodeIDMismatch    = errors.New("nodeID does not match the node")
+	ErrNonCanonicalOwner = errors.New("owner is not canonical")
+)
+
+// PreflightRegistration returns the first reason that registering the L1
+// validator described by [params] with [wallet] would fail. [infoClient] must
+// be connected to the node that is being registered and [client] to the P-chain
+// that the registration will be issued to.
+//
+// The following are verified, in order:
+//   - The node has finished bootstrapping the P-chain.
+//   - Etna has activated.
+//   - The node is on the network of [wallet].
+//   - The proof of possession in [params] is valid.
+//   - The nodeID and BLS key in [params] are those of the node.
+//   - The expiry is accepted by the next P-chain block.
+//   - The owners are canonical and [params] forms a valid message.
+//   - [wallet] can afford the fee and the initial balance.
+//
+// The owners must already be canonical, rather than being canonicalized as in
+// [BuildRegistrationBundle], because the validator manager of the L1 builds
+// the message that it signs from the owners that it was provided.
+//
+// The fee is estimated with an empty signature, so [options] should include
+// any fee multiplier that will be used to issue the registration.
+func PreflightRegistration(
+	ctx context.Context,
+	infoClient info.Client,
+	client platformvm.Client,
+	wallet pwallet.Wallet,
+	params *RegistrationParams,
+	options ...common.Option,
+) error {
+	if err := AssertBootstrapped(ctx, infoClient, pbuilder.Alias); err != nil {
+		return err
+	}
+	if err := RequireUpgrade(ctx, infoClient, "Etna"); err != nil {
+		return err
+	}
+
+	if err := AssertSameNetwork(ctx, infoClient, wallet.Builder().Context()); err != nil {
+		return err
+	}
+
+	// Proofs of possession are not bound to a network, so checking the
+	// network of the node above is what ties the proof to the network.
+	pop := params.ProofOfPossession
+	if pop == nil {
+		return ErrMissingProofOfPossession
+	}
+	if err := pop.Verify(); err != nil {
+		return fmt.Errorf("invalid proof of possession: %w", err)
+	}
+
+	nodeID, nodePoP, err := infoClient.GetNodeID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch node ID: %w", err)
+	}
+	if nodeID != params.NodeID {
+		return fmt.Errorf("%w: expected %s but node is %s",
+			ErrNodeIDMismatch,
+			params.NodeID,
+			nodeID,
+		)
+	}
+	if err := info.VerifyNodePoP(nodePoP, pop.PublicKey); err != nil {
+		return err
+	}
+
+	if err := verifyExpiry(ctx, infoClient, client, params.Expiry); err != nil {
+		return err
+	}
+
+	if err := verifyCanonicalOwner("RemainingBalanceOwner", params.RemainingBalanceOwner); err != nil {
+		return err
+	}
+	if err := verifyCanonicalOwner("DisableOwner", params.DisableOwner); err != nil {
+		return err
+	}
+	registerL1Validator, err := message.NewRegisterL1Validator(
+		params.SubnetID,
+		params.NodeID,
+		pop.PublicKey,
+		params.Expiry,
+		message.L1ValidatorOwners{
+			RemainingBalanceOwner: params.RemainingBalanceOwner,
+			DeactivationOwner:     params.DisableOwner,
+		},
+		params.Weight,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create RegisterL1Validator message: %w", err)
+	}
+	if err := registerL1Validator.Verify(); err != nil {
+		return err
+	}
+
+	bundle, err := BuildRegistrationBundle(wallet, params)
+	if err != nil {
+		return err
+	}
+	msg, err := warp.NewMessageUnsigned(bundle.UnsignedMessage)
+	if err != nil {
+		return fmt.Errorf("failed to create Warp message: %w", err)
+	}
+	_, err = wallet.Builder().NewRegisterL1ValidatorTx(
+		bundle.Balance,
+		bundle.ProofOfPossession,
+		msg.Bytes(),
+		options...,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to build RegisterL1ValidatorTx: %w", err)
+	}
+	return nil
+}
+
+// verifyExpiry returns an error if [expiry] would not be accepted by the next
+// P-chain block. The timestamp of the next block is estimated as the later of
+// the current P-chain timestamp and the time of the node, as the P-chain
+// timestamp is not advanced while no blocks are produced.
+func verifyExpiry(
+	ctx context.Context,
+	infoClient info.Client,
+	client platformvm.Client,
+	expiry uint64,
+) error {
+	chainTime, err := client.GetTimestamp(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch P-chain timestamp: %w", err)
+	}
+	nodeTime, err := infoClient.NetworkTime(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch network time: %w", err)
+	}
+
+	var (
+		timestampUnix = uint64(max(chainTime.Unix(), nodeTime.Unix()))
+		maxExpiry     = timestampUnix + uint64(message.RegisterL1ValidatorExpiryWindow/time.Second)
+	)
+	if expiry <= timestampUnix {
+		return fmt.Errorf("%w: expiry %d <= block time %d",
+			message.ErrDeadlineNotInFuture,
+			expiry,
+			timestampUnix,
+		)
+	}
+	if expiry > maxExpiry {
+		return fmt.Errorf("%w: expiry %d > %d",
+			message.ErrDeadlineTooFar,
+			expiry,
+			maxExpiry,
+		)
+	}
+	return nil
+}
+
+// verifyCanonicalOwner returns an error if the addresses of [owner] are not
+// sorted and unique. [field] is the name of [owner] in the message.
+func verifyCanonicalOwner(field string, owner message.PChainOwner) error {
+	if slices.Equal(owner.Addresses, owner.Canonicalize().Addresses) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s addresses must be sorted and unique",
+		ErrNonCanonicalOwner,
+		field,
+	)
+}
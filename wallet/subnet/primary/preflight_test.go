@@ -0,0 +1,309 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/upgrade"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/c"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+
+	pbuilder "github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	xbuilder "github.com/ava-labs/avalanchego/wallet/chain/x/builder"
+)
+
+// preflightInfoClient is the node that is being registered.
+type preflightInfoClient struct {
+	info.Client
+
+	bootstrapped bool
+	networkID    uint32
+	upgrades     upgrade.Config
+	now          time.Time
+	nodeID       ids.NodeID
+	nodePoP      *signer.ProofOfPossession
+}
+
+func (c *preflightInfoClient) IsBootstrapped(context.Context, string, ...rpc.Option) (bool, error) {
+	return c.bootstrapped, nil
+}
+
+func (c *preflightInfoClient) GetNetworkID(context.Context, ...rpc.Option) (uint32, error) {
+	return c.networkID, nil
+}
+
+func (c *preflightInfoClient) Upgrades(context.Context, ...rpc.Option) (*upgrade.Config, error) {
+	return &c.upgrades, nil
+}
+
+func (c *preflightInfoClient) NetworkTime(context.Context, ...rpc.Option) (time.Time, error) {
+	return c.now, nil
+}
+
+func (c *preflightInfoClient) GetNodeID(context.Context, ...rpc.Option) (ids.NodeID, *signer.ProofOfPossession, error) {
+	return c.nodeID, c.nodePoP, nil
+}
+
+// timestampClient is a P-chain whose current timestamp is [timestamp].
+type timestampClient struct {
+	platformvm.Client
+
+	timestamp time.Time
+}
+
+func (c *timestampClient) GetTimestamp(context.Context, ...rpc.Option) (time.Time, error) {
+	return c.timestamp, nil
+}
+
+func TestPreflightRegistration(t *testing.T) {
+	var (
+		key         = secp256k1.TestKeys()[0]
+		avaxAssetID = ids.GenerateTestID()
+		etnaTime    = time.Date(2024, time.December, 16, 17, 0, 0, 0, time.UTC)
+		now         = etnaTime.Add(time.Hour)
+		nodeID      = ids.GenerateTestNodeID()
+	)
+	vdrSK, err := bls.NewSigner()
+	require.NoError(t, err)
+	otherSK, err := bls.NewSigner()
+	require.NoError(t, err)
+
+	var (
+		pop      = signer.NewProofOfPossession(vdrSK)
+		otherPoP = signer.NewProofOfPossession(otherSK)
+		owner    = message.PChainOwner{
+			Threshold: 1,
+			Addresses: []ids.ShortID{key.Address()},
+		}
+	)
+
+	// newWallet returns a wallet on the unit test network that holds
+	// [balance].
+	newWallet := func(t *testing.T, balance uint64) *Wallet {
+		utxos := common.NewUTXOs()
+		require.NoError(t, utxos.AddUTXO(
+			context.Background(),
+			constants.PlatformChainID,
+			constants.PlatformChainID,
+			&avax.UTXO{
+				UTXOID: avax.UTXOID{
+					TxID: ids.GenerateTestID(),
+				},
+				Asset: avax.Asset{ID: avaxAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: balance,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Threshold: 1,
+						Addrs:     []ids.ShortID{key.Address()},
+					},
+				},
+			},
+		))
+		return newWalletFromState(&walletState{
+			avaxState: &AVAXState{
+				PCTX: &pbuilder.Context{
+					NetworkID:   constants.UnitTestID,
+					AVAXAssetID: avaxAssetID,
+					ComplexityWeights: gas.Dimensions{
+						gas.Bandwidth: 1,
+						gas.DBRead:    10,
+						gas.DBWrite:   100,
+						gas.Compute:   1000,
+					},
+					GasPrice: 1,
+				},
+				XCTX:  &xbuilder.Context{},
+				CCTX:  &c.Context{},
+				UTXOs: utxos,
+			},
+			avaxKeychain: secp256k1fx.NewKeychain(key),
+			owners:       make(map[ids.ID]fx.Owner),
+		})
+	}
+
+	tests := []struct {
+		name string
+		// modify is applied to a valid registration before it is checked.
+		modify        func(*preflightInfoClient, *timestampClient, *RegistrationParams)
+		walletBalance uint64
+		expectedErr   error
+	}{
+		{
+			name:          "valid",
+			walletBalance: 10 * units.Avax,
+		},
+		{
+			name: "node not bootstrapped",
+			modify: func(infoClient *preflightInfoClient, _ *timestampClient, _ *RegistrationParams) {
+				infoClient.bootstrapped = false
+			},
+			walletBalance: 10 * units.Avax,
+			expectedErr:   ErrNodeNotBootstrapped,
+		},
+		{
+			name: "upgrade not active",
+			modify: func(infoClient *preflightInfoClient, _ *timestampClient, _ *RegistrationParams) {
+				infoClient.now = etnaTime.Add(-time.Second)
+			},
+			walletBalance: 10 * units.Avax,
+			expectedErr:   ErrUpgradeNotActive,
+		},
+		{
+			name: "wrong network",
+			modify: func(infoClient *preflightInfoClient, _ *timestampClient, _ *RegistrationParams) {
+				infoClient.networkID = constants.MainnetID
+			},
+			walletBalance: 10 * units.Avax,
+			expectedErr:   ErrNetworkMismatch,
+		},
+		{
+			name: "missing proof of possession",
+			modify: func(_ *preflightInfoClient, _ *timestampClient, params *RegistrationParams) {
+				params.ProofOfPossession = nil
+			},
+			walletBalance: 10 * units.Avax,
+			expectedErr:   ErrMissingProofOfPossession,
+		},
+		{
+			name: "invalid proof of possession",
+			modify: func(_ *preflightInfoClient, _ *timestampClient, params *RegistrationParams) {
+				params.ProofOfPossession = &signer.ProofOfPossession{
+					PublicKey:         pop.PublicKey,
+					ProofOfPossession: otherPoP.ProofOfPossession,
+				}
+			},
+			walletBalance: 10 * units.Avax,
+			expectedErr:   signer.ErrInvalidProofOfPossession,
+		},
+		{
+			name: "wrong nodeID",
+			modify: func(_ *preflightInfoClient, _ *timestampClient, params *RegistrationParams) {
+				params.NodeID = ids.GenerateTestNodeID()
+			},
+			walletBalance: 10 * units.Avax,
+			expectedErr:   ErrNodeIDMismatch,
+		},
+		{
+			name: "wrong BLS key",
+			modify: func(_ *preflightInfoClient, _ *timestampClient, params *RegistrationParams) {
+				params.ProofOfPossession = otherPoP
+			},
+			walletBalance: 10 * units.Avax,
+			expectedErr:   info.ErrPublicKeyMismatch,
+		},
+		{
+			name: "expired",
+			modify: func(_ *preflightInfoClient, pClient *timestampClient, params *RegistrationParams) {
+				params.Expiry = uint64(pClient.timestamp.Unix())
+			},
+			walletBalance: 10 * units.Avax,
+			expectedErr:   message.ErrDeadlineNotInFuture,
+		},
+		{
+			name: "expired at node time",
+			modify: func(infoClient *preflightInfoClient, _ *timestampClient, _ *RegistrationParams) {
+				// The P-chain timestamp lags behind the node while no blocks
+				// are produced.
+				infoClient.now = now.Add(2 * time.Hour)
+			},
+			walletBalance: 10 * units.Avax,
+			expectedErr:   message.ErrDeadlineNotInFuture,
+		},
+		{
+			name: "expiry too far",
+			modify: func(_ *preflightInfoClient, pClient *timestampClient, params *RegistrationParams) {
+				deadline := pClient.timestamp.Add(message.RegisterL1ValidatorExpiryWindow + time.Second)
+				params.Expiry = uint64(deadline.Unix())
+			},
+			walletBalance: 10 * units.Avax,
+			expectedErr:   message.ErrDeadlineTooFar,
+		},
+		{
+			name: "non-canonical owner",
+			modify: func(_ *preflightInfoClient, _ *timestampClient, params *RegistrationParams) {
+				params.DisableOwner = message.PChainOwner{
+					Threshold: 1,
+					Addresses: []ids.ShortID{{2}, {1}},
+				}
+			},
+			walletBalance: 10 * units.Avax,
+			expectedErr:   ErrNonCanonicalOwner,
+		},
+		{
+			name: "invalid message",
+			modify: func(_ *preflightInfoClient, _ *timestampClient, params *RegistrationParams) {
+				params.Weight = 0
+			},
+			walletBalance: 10 * units.Avax,
+			expectedErr:   message.ErrInvalidWeight,
+		},
+		{
+			name:          "insufficient funds",
+			walletBalance: units.Avax,
+			expectedErr:   pbuilder.ErrInsufficientFunds,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				infoClient = &preflightInfoClient{
+					bootstrapped: true,
+					networkID:    constants.UnitTestID,
+					upgrades: upgrade.Config{
+						EtnaTime: etnaTime,
+					},
+					now:     now,
+					nodeID:  nodeID,
+					nodePoP: pop,
+				}
+				pClient = &timestampClient{
+					timestamp: now,
+				}
+				params = &RegistrationParams{
+					SubnetID:              ids.GenerateTestID(),
+					ChainID:               ids.GenerateTestID(),
+					Address:               []byte{1, 2, 3},
+					NodeID:                nodeID,
+					ProofOfPossession:     pop,
+					Expiry:                uint64(now.Add(time.Hour).Unix()),
+					RemainingBalanceOwner: owner,
+					DisableOwner:          owner,
+					Weight:                1,
+					Balance:               units.Avax,
+				}
+			)
+			if test.modify != nil {
+				test.modify(infoClient, pClient, params)
+			}
+
+			err := PreflightRegistration(
+				context.Background(),
+				infoClient,
+				pClient,
+				newWallet(t, test.walletBalance).P(),
+				params,
+			)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
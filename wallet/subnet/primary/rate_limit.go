@@ -0,0 +1,46 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+var _ http.RoundTripper = (*rateLimitedTransport)(nil)
+
+// rateLimitedTransport waits for [limiter] before sending each request with
+// [transport].
+type rateLimitedTransport struct {
+	limiter   *rate.Limiter
+	transport http.RoundTripper
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.transport.RoundTrip(req)
+}
+
+// newRateLimitedHTTPClient returns a copy of [client] that sends at most
+// [requestsPerSecond] requests per second. If [client] is nil,
+// [http.DefaultClient] is copied.
+func newRateLimitedHTTPClient(client *http.Client, requestsPerSecond float64) *http.Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	limitedClient := *client
+	limitedClient.Transport = &rateLimitedTransport{
+		limiter:   rate.NewLimiter(rate.Limit(requestsPerSecond), 1),
+		transport: transport,
+	}
+	return &limitedClient
+}
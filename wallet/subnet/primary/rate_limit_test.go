@@ -0,0 +1,66 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitedHTTPClient(t *testing.T) {
+	require := require.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	const (
+		numRequests       = 5
+		requestsPerSecond = 50
+	)
+	client := newRateLimitedHTTPClient(nil, requestsPerSecond)
+	start := time.Now()
+	for i := 0; i < numRequests; i++ {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+		require.NoError(err)
+
+		resp, err := client.Do(req)
+		require.NoError(err)
+		require.NoError(resp.Body.Close())
+	}
+
+	// The first request is sent immediately and each of the following requests
+	// waits for the limiter.
+	minDuration := time.Duration(numRequests-1) * time.Second / requestsPerSecond
+	require.GreaterOrEqual(time.Since(start), minDuration)
+}
+
+func TestRateLimitedHTTPClientCancelled(t *testing.T) {
+	require := require.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	client := newRateLimitedHTTPClient(nil, 1)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	require.NoError(err)
+	resp, err := client.Do(req)
+	require.NoError(err)
+	require.NoError(resp.Body.Close())
+
+	// The next request would need to wait a second for the limiter, which
+	// exceeds the deadline of the request.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(err)
+	_, err = client.Do(req) //nolint:bodyclose // the request is never sent
+	require.Error(err)      //nolint:forbidigo // the limiter returns an unexported error
+}
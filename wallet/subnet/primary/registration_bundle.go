@@ -0,0 +1,246 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/fee"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/payload"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+
+	pbuilder "github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	pwallet "github.com/ava-labs/avalanchego/wallet/chain/p/wallet"
+)
+
+const registrationBundleCodecVersion = 0
+
+var (
+	// ErrMissingProofOfPossession is returned when a validator is described
+	// without the proof of possession of its BLS key.
+	ErrMissingProofOfPossession = txs.ErrMissingProofOfPossession
+	ErrFeeExceedsIntended       = errors.New("fee exceeds intended fee")
+
+	registrationBundleCodec codec.Manager
+)
+
+func init() {
+	registrationBundleCodec = codec.NewManager(warp.MaxMessageSize)
+	err := registrationBundleCodec.RegisterCodec(
+		registrationBundleCodecVersion,
+		linearcodec.NewDefault(),
+	)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// RegistrationParams describe an L1 validator to register.
+type RegistrationParams struct {
+	// SubnetID is the L1 that the validator is being registered to.
+	SubnetID ids.ID
+	// ChainID and Address identify the validator manager of the L1 that is
+	// expected to sign the registration.
+	ChainID ids.ID
+	Address []byte
+
+	NodeID                ids.NodeID
+	ProofOfPossession     *signer.ProofOfPossession
+	Expiry                uint64
+	RemainingBalanceOwner message.PChainOwner
+	DisableOwner          message.PChainOwner
+	Weight                uint64
+	// Balance is the initial balance of the validator that is used to pay the
+	// continuous fee.
+	Balance uint64
+	// IntendedFee is the largest fee that the RegisterL1ValidatorTx is
+	// intended to burn. If zero, the fee is not checked when the bundle is
+	// issued.
+	IntendedFee uint64
+	// Metadata is opaque data that is carried along with the bundle.
+	Metadata []byte
+}
+
+// RegistrationBundle contains everything needed to register an L1 validator
+// once the unsigned Warp message has been signed. This allows the message to
+// be built and the transaction to be issued separately from the signing of the
+// message.
+type RegistrationBundle struct {
+	UnsignedMessage   *warp.UnsignedMessage
+	ProofOfPossession [bls.SignatureLen]byte
+	Balance           uint64
+	IntendedFee       uint64
+	Metadata          []byte
+}
+
+// registrationBundle is the serialized format of a [RegistrationBundle].
+type registrationBundle struct {
+	UnsignedMessage   []byte                 `serialize:"true"`
+	ProofOfPossession [bls.SignatureLen]byte `serialize:"true"`
+	Balance           uint64                 `serialize:"true"`
+	IntendedFee       uint64                 `serialize:"true"`
+	Metadata          []byte                 `serialize:"true"`
+}
+
+func (b *RegistrationBundle) MarshalBinary() ([]byte, error) {
+	return registrationBundleCodec.Marshal(registrationBundleCodecVersion, &registrationBundle{
+		UnsignedMessage:   b.UnsignedMessage.Bytes(),
+		ProofOfPossession: b.ProofOfPossession,
+		Balance:           b.Balance,
+		IntendedFee:       b.IntendedFee,
+		Metadata:          b.Metadata,
+	})
+}
+
+func (b *RegistrationBundle) UnmarshalBinary(bytes []byte) error {
+	var bundle registrationBundle
+	if _, err := registrationBundleCodec.Unmarshal(bytes, &bundle); err != nil {
+		return err
+	}
+
+	unsignedMessage, err := warp.ParseUnsignedMessage(bundle.UnsignedMessage)
+	if err != nil {
+		return fmt.Errorf("failed to parse unsigned message: %w", err)
+	}
+
+	*b = RegistrationBundle{
+		UnsignedMessage:   unsignedMessage,
+		ProofOfPossession: bundle.ProofOfPossession,
+		Balance:           bundle.Balance,
+		IntendedFee:       bundle.IntendedFee,
+		Metadata:          bundle.Metadata,
+	}
+	return nil
+}
+
+// BuildRegistrationBundle creates the unsigned RegisterL1Validator Warp message
+// described by [params] for the network of [wallet]. The owners in [params] are
+// canonicalized, so their addresses may be provided in any order.
+func BuildRegistrationBundle(
+	wallet pwallet.Wallet,
+	params *RegistrationParams,
+) (*RegistrationBundle, error) {
+	if params.ProofOfPossession == nil {
+		return nil, ErrMissingProofOfPossession
+	}
+
+	registerL1Validator, err := message.NewRegisterL1Validator(
+		params.SubnetID,
+		params.NodeID,
+		params.ProofOfPossession.PublicKey,
+		params.Expiry,
+		message.L1ValidatorOwners{
+			RemainingBalanceOwner: params.RemainingBalanceOwner.Canonicalize(),
+			DeactivationOwner:     params.DisableOwner.Canonicalize(),
+		},
+		params.Weight,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RegisterL1Validator message: %w", err)
+	}
+
+	addressedCall, err := payload.NewAddressedCall(
+		params.Address,
+		registerL1Validator.Bytes(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AddressedCall message: %w", err)
+	}
+
+	unsignedMessage, err := warp.NewUnsignedMessage(
+		wallet.Builder().Context().NetworkID,
+		params.ChainID,
+		addressedCall.Bytes(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create unsigned Warp message: %w", err)
+	}
+
+	return &RegistrationBundle{
+		UnsignedMessage:   unsignedMessage,
+		ProofOfPossession: params.ProofOfPossession.ProofOfPossession,
+		Balance:           params.Balance,
+		IntendedFee:       params.IntendedFee,
+		Metadata:          params.Metadata,
+	}, nil
+}
+
+// IssueRegistrationBundle attaches [signature] to the unsigned Warp message of
+// [bundle] and issues the resulting RegisterL1ValidatorTx with [wallet].
+//
+// Returns an error, without issuing a tx, if:
+//   - [warp.ErrWrongNetworkID] if [bundle] was not built for the network of
+//     [wallet].
+//   - [ErrFeeExceedsIntended] if the tx would burn more than the intended fee
+//     of [bundle].
+func IssueRegistrationBundle(
+	wallet pwallet.Wallet,
+	bundle *RegistrationBundle,
+	signature warp.Signature,
+	options ...common.Option,
+) (*txs.Tx, error) {
+	msg, err := warp.NewMessage(bundle.UnsignedMessage, signature)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Warp message: %w", err)
+	}
+	context := wallet.Builder().Context()
+	if err := warp.VerifyNetworkID(msg, context.NetworkID); err != nil {
+		return nil, err
+	}
+
+	// The fee is checked once the tx is built, so that it reflects the inputs
+	// that are consumed, but before the tx is signed and issued.
+	var buildErr error
+	issued, err := wallet.IssueSequential(
+		[]pwallet.UnsignedTxBuilder{
+			func(b pbuilder.Builder) (txs.UnsignedTx, error) {
+				var utx txs.UnsignedTx
+				utx, buildErr = b.NewRegisterL1ValidatorTx(
+					bundle.Balance,
+					bundle.ProofOfPossession,
+					msg.Bytes(),
+					options...,
+				)
+				if buildErr != nil || bundle.IntendedFee == 0 {
+					return utx, buildErr
+				}
+
+				var txFee uint64
+				txFee, buildErr = fee.NewDynamicCalculator(
+					context.ComplexityWeights,
+					context.GasPrice,
+				).CalculateFee(utx)
+				if buildErr != nil {
+					return nil, buildErr
+				}
+				if txFee > bundle.IntendedFee {
+					buildErr = fmt.Errorf("%w: %d > %d",
+						ErrFeeExceedsIntended,
+						txFee,
+						bundle.IntendedFee,
+					)
+					return nil, buildErr
+				}
+				return utx, nil
+			},
+		},
+		options...,
+	)
+	switch {
+	case buildErr != nil:
+		return nil, buildErr
+	case err != nil:
+		return nil, err
+	}
+	return issued[0], nil
+}
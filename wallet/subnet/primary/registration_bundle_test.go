@@ -0,0 +1,263 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/set"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp/message"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/c"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+
+	pbuilder "github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	xbuilder "github.com/ava-labs/avalanchego/wallet/chain/x/builder"
+)
+
+func TestRegistrationBundle(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		key         = secp256k1.TestKeys()[0]
+		avaxAssetID = ids.GenerateTestID()
+		client      = &issueTxClient{}
+		utxos       = common.NewUTXOs()
+	)
+	require.NoError(utxos.AddUTXO(
+		context.Background(),
+		constants.PlatformChainID,
+		constants.PlatformChainID,
+		&avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID: ids.GenerateTestID(),
+			},
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 10 * units.Avax,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{key.Address()},
+				},
+			},
+		},
+	))
+	wallet := newWalletFromState(&walletState{
+		avaxState: &AVAXState{
+			PClient: client,
+			PCTX: &pbuilder.Context{
+				NetworkID:   constants.UnitTestID,
+				AVAXAssetID: avaxAssetID,
+				ComplexityWeights: gas.Dimensions{
+					gas.Bandwidth: 1,
+					gas.DBRead:    10,
+					gas.DBWrite:   100,
+					gas.Compute:   1000,
+				},
+				GasPrice: 1,
+			},
+			XCTX:  &xbuilder.Context{},
+			CCTX:  &c.Context{},
+			UTXOs: utxos,
+		},
+		avaxKeychain: secp256k1fx.NewKeychain(key),
+		owners:       make(map[ids.ID]fx.Owner),
+	}).P()
+
+	vdrSK, err := bls.NewSigner()
+	require.NoError(err)
+
+	params := &RegistrationParams{
+		SubnetID:          ids.GenerateTestID(),
+		ChainID:           ids.GenerateTestID(),
+		Address:           []byte{1, 2, 3},
+		NodeID:            ids.GenerateTestNodeID(),
+		ProofOfPossession: signer.NewProofOfPossession(vdrSK),
+		Expiry:            1_700_000_000,
+		Weight:            1,
+		Balance:           units.Avax,
+		IntendedFee:       units.Avax,
+		Metadata:          []byte("metadata"),
+		// The owner is canonicalized when the bundle is built.
+		RemainingBalanceOwner: message.PChainOwner{
+			Threshold: 1,
+			Addresses: []ids.ShortID{{2}, {1}, {2}},
+		},
+	}
+	bundle, err := BuildRegistrationBundle(wallet, params)
+	require.NoError(err)
+
+	// The bundle is serialized to be handed off to a signer.
+	bundleBytes, err := bundle.MarshalBinary()
+	require.NoError(err)
+
+	var parsedBundle RegistrationBundle
+	require.NoError(parsedBundle.UnmarshalBinary(bundleBytes))
+	require.Equal(bundle, &parsedBundle)
+
+	// The signer signs the unsigned message.
+	managerSK, err := bls.NewSigner()
+	require.NoError(err)
+	signature := &warp.BitSetSignature{
+		Signers: set.NewBits(0).Bytes(),
+		Signature: [bls.SignatureLen]byte(bls.SignatureToBytes(
+			managerSK.Sign(parsedBundle.UnsignedMessage.Bytes()),
+		)),
+	}
+
+	tx, err := IssueRegistrationBundle(
+		wallet,
+		&parsedBundle,
+		signature,
+		common.WithAssumeDecided(),
+	)
+	require.NoError(err)
+	require.Len(client.issued, 1)
+
+	utx := tx.Unsigned.(*txs.RegisterL1ValidatorTx)
+	require.Equal(params.Balance, utx.Balance)
+	require.Equal(params.ProofOfPossession.ProofOfPossession, utx.ProofOfPossession)
+
+	msg, err := warp.ParseMessage(utx.Message)
+	require.NoError(err)
+	require.Equal(signature, msg.Signature)
+	require.Equal(parsedBundle.UnsignedMessage.Bytes(), msg.UnsignedMessage.Bytes())
+
+	addressedCall, payload, err := message.UnwrapAddressedCall(msg)
+	require.NoError(err)
+	require.Equal(params.Address, addressedCall.SourceAddress)
+
+	registerL1Validator := payload.(*message.RegisterL1Validator)
+	require.Equal(params.NodeID[:], []byte(registerL1Validator.NodeID))
+	require.Equal(params.Weight, registerL1Validator.Weight)
+	require.Equal([]ids.ShortID{{1}, {2}}, registerL1Validator.RemainingBalanceOwner.Addresses)
+}
+
+func TestIssueRegistrationBundleFeeExceedsIntended(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		key         = secp256k1.TestKeys()[0]
+		avaxAssetID = ids.GenerateTestID()
+		client      = &issueTxClient{}
+		utxos       = common.NewUTXOs()
+	)
+	require.NoError(utxos.AddUTXO(
+		context.Background(),
+		constants.PlatformChainID,
+		constants.PlatformChainID,
+		&avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID: ids.GenerateTestID(),
+			},
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 10 * units.Avax,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{key.Address()},
+				},
+			},
+		},
+	))
+	wallet := newWalletFromState(&walletState{
+		avaxState: &AVAXState{
+			PClient: client,
+			PCTX: &pbuilder.Context{
+				NetworkID:   constants.UnitTestID,
+				AVAXAssetID: avaxAssetID,
+				ComplexityWeights: gas.Dimensions{
+					gas.Bandwidth: 1,
+					gas.DBRead:    10,
+					gas.DBWrite:   100,
+					gas.Compute:   1000,
+				},
+				GasPrice: 1,
+			},
+			XCTX:  &xbuilder.Context{},
+			CCTX:  &c.Context{},
+			UTXOs: utxos,
+		},
+		avaxKeychain: secp256k1fx.NewKeychain(key),
+		owners:       make(map[ids.ID]fx.Owner),
+	}).P()
+
+	vdrSK, err := bls.NewSigner()
+	require.NoError(err)
+
+	bundle, err := BuildRegistrationBundle(wallet, &RegistrationParams{
+		SubnetID:          ids.GenerateTestID(),
+		ChainID:           ids.GenerateTestID(),
+		NodeID:            ids.GenerateTestNodeID(),
+		ProofOfPossession: signer.NewProofOfPossession(vdrSK),
+		Weight:            1,
+		Balance:           units.Avax,
+		// Any non-empty tx burns more than a single nAVAX.
+		IntendedFee: 1,
+	})
+	require.NoError(err)
+
+	_, err = IssueRegistrationBundle(
+		wallet,
+		bundle,
+		&warp.BitSetSignature{
+			Signers: set.NewBits(0).Bytes(),
+		},
+		common.WithAssumeDecided(),
+	)
+	require.ErrorIs(err, ErrFeeExceedsIntended)
+	require.Empty(client.issued)
+}
+
+func TestIssueRegistrationBundleWrongNetwork(t *testing.T) {
+	require := require.New(t)
+
+	client := &issueTxClient{}
+	wallet := newWalletFromState(&walletState{
+		avaxState: &AVAXState{
+			PClient: client,
+			PCTX: &pbuilder.Context{
+				NetworkID: constants.UnitTestID,
+			},
+			XCTX:  &xbuilder.Context{},
+			CCTX:  &c.Context{},
+			UTXOs: common.NewUTXOs(),
+		},
+		avaxKeychain: secp256k1fx.NewKeychain(),
+		owners:       make(map[ids.ID]fx.Owner),
+	}).P()
+
+	unsignedMessage, err := warp.NewUnsignedMessage(
+		constants.MainnetID,
+		ids.GenerateTestID(),
+		[]byte("payload"),
+	)
+	require.NoError(err)
+
+	_, err = IssueRegistrationBundle(
+		wallet,
+		&RegistrationBundle{
+			UnsignedMessage: unsignedMessage,
+		},
+		&warp.BitSetSignature{
+			Signers: set.NewBits(0).Bytes(),
+		},
+	)
+	require.ErrorIs(err, warp.ErrWrongNetworkID)
+	require.Empty(client.issued)
+}
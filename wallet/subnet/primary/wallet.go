@@ -5,11 +5,20 @@ package primary
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
 
+	"github.com/ava-labs/avalanchego/api/info"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/keychain"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/utils/set"
 	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
 	"github.com/ava-labs/avalanchego/wallet/chain/c"
 	"github.com/ava-labs/avalanchego/wallet/chain/p"
 	"github.com/ava-labs/avalanchego/wallet/chain/x"
@@ -22,11 +31,26 @@ import (
 	xsigner "github.com/ava-labs/avalanchego/wallet/chain/x/signer"
 )
 
+var (
+	ErrCChainNotConfigured  = errors.New("C-chain wallet is not configured")
+	ErrStateAddressMismatch = errors.New("wallet state was exported for different addresses")
+	ErrStateAheadOfNode     = errors.New("wallet state is more recent than the node")
+	// ErrStaleFeeContext is returned by the P-chain wallet when the node
+	// rejects a transaction after its gas price increased above the gas price
+	// of the wallet's context, which means the wallet should be recreated with
+	// a freshly fetched context.
+	ErrStaleFeeContext = p.ErrStaleFeeContext
+)
+
 // Wallet provides chain wallets for the primary network.
 type Wallet struct {
 	p pwallet.Wallet
 	x x.Wallet
 	c c.Wallet
+
+	// state is the state the wallet was created from. It is only populated by
+	// [MakeWallet] and is used to support [MergeWallets].
+	state *walletState
 }
 
 func (w *Wallet) P() pwallet.Wallet {
@@ -66,6 +90,36 @@ type WalletConfig struct {
 	// Validation IDs that the wallet should know about to be able to generate
 	// transactions.
 	ValidationIDs []ids.ID // optional
+	// HTTPClient is used to issue the info, P-chain, and X-chain API requests.
+	// Providing a shared client allows connections to be reused with other API
+	// clients. If nil, [http.DefaultClient] is used.
+	HTTPClient *http.Client // optional
+	// RequestsPerSecond limits the rate of the info, P-chain, and X-chain API
+	// requests, which includes the UTXO crawl, to avoid tripping the rate
+	// limits of shared nodes. The limit also applies to requests issued by the
+	// returned wallet. If zero, requests are not limited.
+	RequestsPerSecond float64 // optional
+	// RequireCompleteSync causes the UTXO crawl to keep paging until the node
+	// reports that no UTXOs remain, rather than stopping at the first page
+	// that is smaller than requested. This costs an additional request per
+	// crawled chain pair. If the node returns a page without advancing the
+	// crawl, [ErrIncompleteUTXOCrawl] is returned rather than a wallet built
+	// from a partial set of UTXOs. Any error during the crawl causes the
+	// wallet creation to fail regardless of this setting.
+	RequireCompleteSync bool // optional
+}
+
+func (c *WalletConfig) rpcOptions() []rpc.Option {
+	httpClient := c.HTTPClient
+	if c.RequestsPerSecond > 0 {
+		httpClient = newRateLimitedHTTPClient(httpClient, c.RequestsPerSecond)
+	}
+	if httpClient == nil {
+		return nil
+	}
+	return []rpc.Option{
+		rpc.WithHTTPClient(httpClient),
+	}
 }
 
 // MakeWallet returns a wallet that supports issuing transactions to the chains
@@ -77,6 +131,15 @@ type WalletConfig struct {
 // may become out of sync. The wallet will also fetch all requested P-chain
 // owners.
 //
+// If [ethKeychain] is nil, the C-chain is not synced: neither the C-chain state
+// nor the UTXOs exported from or to the C-chain are fetched, and the returned
+// C-chain wallet reports ErrCChainNotConfigured.
+//
+// If the node has not finished bootstrapping the chains the wallet uses,
+// [ErrNodeNotBootstrapped] is returned.
+//
+// Wallets returned by MakeWallet can be combined with [MergeWallets].
+//
 // The wallet manages all state locally, and performs all tx signing locally.
 func MakeWallet(
 	ctx context.Context,
@@ -85,46 +148,112 @@ func MakeWallet(
 	ethKeychain c.EthKeychain,
 	config WalletConfig,
 ) (*Wallet, error) {
-	avaxAddrs := avaxKeychain.Addresses()
-	avaxState, err := FetchState(ctx, uri, avaxAddrs)
-	if err != nil {
+	// A typed nil, such as a nil *secp256k1fx.Keychain, is treated the same
+	// as not providing a keychain.
+	if isNil(ethKeychain) {
+		ethKeychain = nil
+	}
+
+	chains := []string{pbuilder.Alias, xbuilder.Alias}
+	if ethKeychain != nil {
+		chains = append(chains, c.Alias)
+	}
+	infoClient := info.NewClientWithOptions(uri, config.rpcOptions()...)
+	if err := AssertBootstrapped(ctx, infoClient, chains...); err != nil {
 		return nil, err
 	}
 
-	ethAddrs := ethKeychain.EthAddresses()
-	ethState, err := FetchEthState(ctx, uri, ethAddrs)
+	avaxAddrs := avaxKeychain.Addresses()
+	avaxState, err := fetchState(
+		ctx,
+		uri,
+		avaxAddrs,
+		config.RequireCompleteSync,
+		ethKeychain != nil,
+		config.rpcOptions()...,
+	)
 	if err != nil {
 		return nil, err
 	}
 
+	var ethState *EthState
+	if ethKeychain != nil {
+		ethState, err = FetchEthState(ctx, uri, ethKeychain.EthAddresses())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	owners, err := platformvm.GetOwners(avaxState.PClient, ctx, config.SubnetIDs, config.ValidationIDs)
 	if err != nil {
 		return nil, err
 	}
 
+	return newWalletFromState(&walletState{
+		avaxState:    avaxState,
+		ethState:     ethState,
+		avaxKeychain: avaxKeychain,
+		ethKeychain:  ethKeychain,
+		owners:       owners,
+	}), nil
+}
+
+// isNil returns true if [kc] is nil or is a nil pointer.
+func isNil(kc c.EthKeychain) bool {
+	if kc == nil {
+		return true
+	}
+	v := reflect.ValueOf(kc)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+// walletState contains everything needed to create a [Wallet].
+type walletState struct {
+	avaxState *AVAXState
+	// ethState is nil if the C-chain wallet is not configured.
+	ethState     *EthState
+	avaxKeychain keychain.Keychain
+	// ethKeychain is nil if the C-chain wallet is not configured.
+	ethKeychain c.EthKeychain
+	owners      map[ids.ID]fx.Owner
+}
+
+func newWalletFromState(state *walletState) *Wallet {
+	var (
+		avaxState = state.avaxState
+		avaxAddrs = state.avaxKeychain.Addresses()
+	)
+
 	pUTXOs := common.NewChainUTXOs(constants.PlatformChainID, avaxState.UTXOs)
-	pBackend := pwallet.NewBackend(avaxState.PCTX, pUTXOs, owners)
-	pClient := p.NewClient(avaxState.PClient, pBackend)
+	pBackend := pwallet.NewBackend(avaxState.PCTX, pUTXOs, state.owners)
+	pClient := p.NewClientWithAddresses(avaxState.PClient, avaxAddrs, pBackend)
 	pBuilder := pbuilder.New(avaxAddrs, avaxState.PCTX, pBackend)
-	pSigner := psigner.New(avaxKeychain, pBackend)
+	pSigner := psigner.New(state.avaxKeychain, pBackend)
 
 	xChainID := avaxState.XCTX.BlockchainID
 	xUTXOs := common.NewChainUTXOs(xChainID, avaxState.UTXOs)
 	xBackend := x.NewBackend(avaxState.XCTX, xUTXOs)
 	xBuilder := xbuilder.New(avaxAddrs, avaxState.XCTX, xBackend)
-	xSigner := xsigner.New(avaxKeychain, xBackend)
+	xSigner := xsigner.New(state.avaxKeychain, xBackend)
 
-	cChainID := avaxState.CCTX.BlockchainID
-	cUTXOs := common.NewChainUTXOs(cChainID, avaxState.UTXOs)
-	cBackend := c.NewBackend(cUTXOs, ethState.Accounts)
-	cBuilder := c.NewBuilder(avaxAddrs, ethAddrs, avaxState.CCTX, cBackend)
-	cSigner := c.NewSigner(avaxKeychain, ethKeychain, cBackend)
+	var cWallet c.Wallet = notConfiguredCWallet{}
+	if state.ethKeychain != nil && state.ethState != nil {
+		ethAddrs := state.ethKeychain.EthAddresses()
 
-	return NewWallet(
-		pwallet.New(pClient, pBuilder, pSigner),
-		x.NewWallet(xBuilder, xSigner, avaxState.XClient, xBackend),
-		c.NewWallet(cBuilder, cSigner, avaxState.CClient, ethState.Client, cBackend),
-	), nil
+		cChainID := avaxState.CCTX.BlockchainID
+		cUTXOs := common.NewChainUTXOs(cChainID, avaxState.UTXOs)
+		cBackend := c.NewBackend(cUTXOs, state.ethState.Accounts)
+		cBuilder := c.NewBuilder(avaxAddrs, ethAddrs, avaxState.CCTX, cBackend)
+		cSigner := c.NewSigner(state.avaxKeychain, state.ethKeychain, cBackend)
+		cWallet = c.NewWallet(cBuilder, cSigner, avaxState.CClient, state.ethState.Client, cBackend)
+	}
+
+	return &Wallet{
+		p:     pwallet.New(pClient, pBuilder, pSigner),
+		x:     x.NewWallet(xBuilder, xSigner, avaxState.XClient, xBackend),
+		c:     cWallet,
+		state: state,
+	}
 }
 
 // MakePWallet returns a P-chain wallet that supports issuing transactions.
@@ -132,8 +261,11 @@ func MakeWallet(
 // On creation, the wallet attaches to the provided uri and fetches all UTXOs
 // that reference any of the provided keys. If the UTXOs are modified through an
 // external issuance process, such as another instance of the wallet, the UTXOs
-// may become out of sync. The wallet will also fetch all requested P-chain
-// owners.
+// may become out of sync. They can be re-synced with [pwallet.Wallet.Refresh].
+// The wallet will also fetch all requested P-chain owners.
+//
+// If the node has not finished bootstrapping the chains the wallet uses,
+// [ErrNodeNotBootstrapped] is returned.
 //
 // The wallet manages all state locally, and performs all tx signing locally.
 func MakePWallet(
@@ -142,8 +274,13 @@ func MakePWallet(
 	keychain keychain.Keychain,
 	config WalletConfig,
 ) (pwallet.Wallet, error) {
+	infoClient := info.NewClientWithOptions(uri, config.rpcOptions()...)
+	if err := AssertBootstrapped(ctx, infoClient, pbuilder.Alias); err != nil {
+		return nil, err
+	}
+
 	addrs := keychain.Addresses()
-	client, context, utxos, err := FetchPState(ctx, uri, addrs)
+	client, context, utxos, err := fetchPState(ctx, uri, addrs, config.RequireCompleteSync, config.rpcOptions()...)
 	if err != nil {
 		return nil, err
 	}
@@ -153,10 +290,132 @@ func MakePWallet(
 		return nil, err
 	}
 
+	return newPWallet(client, keychain, context, utxos, owners), nil
+}
+
+// MakePWalletFromState returns a P-chain wallet that is created from [state],
+// which was previously returned by [pwallet.Wallet.ExportState], rather than
+// by fetching all of the UTXOs that reference the provided keys.
+//
+// The context is re-fetched from the node so that the wallet uses the current
+// fees. The UTXOs in [state] are not re-synced, so any UTXOs that were
+// modified since the state was exported, such as by external issuance, are
+// only corrected by calling [pwallet.Wallet.Refresh]. The owners of
+// [config.SubnetIDs] and [config.ValidationIDs] that are not included in
+// [state] are fetched.
+//
+// Returns an error if the state can not be used with the node at [uri]:
+//   - [ErrNetworkMismatch] if the node is on a different network.
+//   - [ErrStateAddressMismatch] if [keychain] has different addresses.
+//   - [ErrStateAheadOfNode] if the state is more recent than the node.
+func MakePWalletFromState(
+	ctx context.Context,
+	uri string,
+	keychain keychain.Keychain,
+	state []byte,
+	config WalletConfig,
+) (pwallet.Wallet, error) {
+	options := config.rpcOptions()
+	return makePWalletFromState(
+		ctx,
+		info.NewClientWithOptions(uri, options...),
+		platformvm.NewClientWithOptions(uri, options...),
+		keychain,
+		state,
+		config,
+	)
+}
+
+func makePWalletFromState(
+	ctx context.Context,
+	infoClient info.Client,
+	client platformvm.Client,
+	keychain keychain.Keychain,
+	stateBytes []byte,
+	config WalletConfig,
+) (pwallet.Wallet, error) {
+	var state pwallet.State
+	if err := json.Unmarshal(stateBytes, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse wallet state: %w", err)
+	}
+
+	addrs := keychain.Addresses()
+	if !addrs.Equals(set.Of(state.Addresses...)) {
+		return nil, ErrStateAddressMismatch
+	}
+
+	if err := AssertBootstrapped(ctx, infoClient, pbuilder.Alias); err != nil {
+		return nil, err
+	}
+
+	context, err := p.NewContextFromClients(ctx, infoClient, client)
+	if err != nil {
+		return nil, err
+	}
+	if context.NetworkID != state.Context.NetworkID || context.AVAXAssetID != state.Context.AVAXAssetID {
+		return nil, fmt.Errorf("%w: state is for network %d but node is on network %d",
+			ErrNetworkMismatch,
+			state.Context.NetworkID,
+			context.NetworkID,
+		)
+	}
+
+	height, err := client.GetHeight(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch P-chain height: %w", err)
+	}
+	if height < state.Height {
+		return nil, fmt.Errorf("%w: state is at height %d but node is at height %d",
+			ErrStateAheadOfNode,
+			state.Height,
+			height,
+		)
+	}
+
+	utxos := common.NewUTXOs()
+	for _, utxo := range state.UTXOs {
+		if err := utxos.AddUTXO(ctx, constants.PlatformChainID, constants.PlatformChainID, utxo); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		subnetIDs     []ids.ID
+		validationIDs []ids.ID
+	)
+	for _, subnetID := range config.SubnetIDs {
+		if _, ok := state.Owners[subnetID]; !ok {
+			subnetIDs = append(subnetIDs, subnetID)
+		}
+	}
+	for _, validationID := range config.ValidationIDs {
+		if _, ok := state.Owners[validationID]; !ok {
+			validationIDs = append(validationIDs, validationID)
+		}
+	}
+	owners, err := platformvm.GetOwners(client, ctx, subnetIDs, validationIDs)
+	if err != nil {
+		return nil, err
+	}
+	for ownerID, owner := range state.Owners {
+		owners[ownerID] = owner
+	}
+
+	return newPWallet(client, keychain, context, utxos, owners), nil
+}
+
+func newPWallet(
+	client platformvm.Client,
+	keychain keychain.Keychain,
+	context *pbuilder.Context,
+	utxos common.UTXOs,
+	owners map[ids.ID]fx.Owner,
+) pwallet.Wallet {
+	addrs := keychain.Addresses()
 	pUTXOs := common.NewChainUTXOs(constants.PlatformChainID, utxos)
 	pBackend := pwallet.NewBackend(context, pUTXOs, owners)
-	pClient := p.NewClient(client, pBackend)
+	pClient := p.NewClientWithAddresses(client, addrs, pBackend)
 	pBuilder := pbuilder.New(addrs, context, pBackend)
 	pSigner := psigner.New(keychain, pBackend)
-	return pwallet.New(pClient, pBuilder, pSigner), nil
+	return pwallet.New(pClient, pBuilder, pSigner)
 }
@@ -0,0 +1,192 @@
+// Copyright (C) 2019-2024, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api/info"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/secp256k1"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/components/gas"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+
+	pbuilder "github.com/ava-labs/avalanchego/wallet/chain/p/builder"
+	pwallet "github.com/ava-labs/avalanchego/wallet/chain/p/wallet"
+)
+
+// stateInfoClient is a bootstrapped node on [networkID].
+type stateInfoClient struct {
+	info.Client
+
+	networkID uint32
+}
+
+func (c *stateInfoClient) GetNetworkID(context.Context, ...rpc.Option) (uint32, error) {
+	return c.networkID, nil
+}
+
+func (*stateInfoClient) IsBootstrapped(context.Context, string, ...rpc.Option) (bool, error) {
+	return true, nil
+}
+
+func (*stateInfoClient) GetTxFee(context.Context, ...rpc.Option) (*info.GetTxFeeResponse, error) {
+	return &info.GetTxFeeResponse{}, nil
+}
+
+// statePClient is a P-chain at [height] that uses [avaxAssetID] for staking.
+type statePClient struct {
+	platformvm.Client
+
+	avaxAssetID ids.ID
+	height      uint64
+}
+
+func (c *statePClient) GetStakingAssetID(context.Context, ids.ID, ...rpc.Option) (ids.ID, error) {
+	return c.avaxAssetID, nil
+}
+
+func (*statePClient) GetFeeConfig(context.Context, ...rpc.Option) (*gas.Config, error) {
+	return &gas.Config{}, nil
+}
+
+func (c *statePClient) GetHeight(context.Context, ...rpc.Option) (uint64, error) {
+	return c.height, nil
+}
+
+func TestMakePWalletFromState(t *testing.T) {
+	var (
+		key         = secp256k1.TestKeys()[0]
+		addr        = key.Address()
+		avaxAssetID = ids.GenerateTestID()
+		subnetID    = ids.GenerateTestID()
+		utxo        = &avax.UTXO{
+			UTXOID: avax.UTXOID{
+				TxID: ids.GenerateTestID(),
+			},
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: units.Avax,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+		}
+		state = &pwallet.State{
+			Height:    10,
+			Addresses: []ids.ShortID{addr},
+			Context: &pbuilder.Context{
+				NetworkID:   constants.UnitTestID,
+				AVAXAssetID: avaxAssetID,
+			},
+			UTXOs: []*avax.UTXO{utxo},
+			Owners: map[ids.ID]fx.Owner{
+				subnetID: &secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{addr},
+				},
+			},
+		}
+	)
+	stateBytes, err := json.Marshal(state)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		key         *secp256k1.PrivateKey
+		networkID   uint32
+		avaxAssetID ids.ID
+		height      uint64
+		expectedErr error
+	}{
+		{
+			name:        "same height",
+			key:         key,
+			networkID:   constants.UnitTestID,
+			avaxAssetID: avaxAssetID,
+			height:      10,
+		},
+		{
+			name:        "node ahead of state",
+			key:         key,
+			networkID:   constants.UnitTestID,
+			avaxAssetID: avaxAssetID,
+			height:      20,
+		},
+		{
+			name:        "state ahead of node",
+			key:         key,
+			networkID:   constants.UnitTestID,
+			avaxAssetID: avaxAssetID,
+			height:      5,
+			expectedErr: ErrStateAheadOfNode,
+		},
+		{
+			name:        "wrong network",
+			key:         key,
+			networkID:   constants.MainnetID,
+			avaxAssetID: avaxAssetID,
+			height:      10,
+			expectedErr: ErrNetworkMismatch,
+		},
+		{
+			name:        "wrong asset",
+			key:         key,
+			networkID:   constants.UnitTestID,
+			avaxAssetID: ids.GenerateTestID(),
+			height:      10,
+			expectedErr: ErrNetworkMismatch,
+		},
+		{
+			name:        "wrong key",
+			key:         secp256k1.TestKeys()[1],
+			networkID:   constants.UnitTestID,
+			avaxAssetID: avaxAssetID,
+			height:      10,
+			expectedErr: ErrStateAddressMismatch,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			wallet, err := makePWalletFromState(
+				context.Background(),
+				&stateInfoClient{
+					networkID: test.networkID,
+				},
+				&statePClient{
+					avaxAssetID: test.avaxAssetID,
+					height:      test.height,
+				},
+				secp256k1fx.NewKeychain(test.key),
+				stateBytes,
+				// statePClient doesn't support GetSubnet, so the owner of
+				// [subnetID] must be loaded from the state.
+				WalletConfig{
+					SubnetIDs: []ids.ID{subnetID},
+				},
+			)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+
+			balances, err := wallet.Builder().GetBalance()
+			require.NoError(err)
+			require.Equal(map[ids.ID]uint64{avaxAssetID: units.Avax}, balances)
+		})
+	}
+}